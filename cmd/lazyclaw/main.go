@@ -4,19 +4,76 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/lazyclaw/lazyclaw/internal/config"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
 	"github.com/lazyclaw/lazyclaw/internal/state"
 	"github.com/lazyclaw/lazyclaw/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// shutdownTimeout bounds how long exiting waits for child processes
+const shutdownTimeout = 2 * time.Second
+
 func main() {
+	// Started by an instance with ssh.client: native to connect to it
+	if gateway.NativeSSHRequested() {
+		os.Exit(gateway.RunNativeSSH(os.Args[1:]))
+	}
+
 	// Parse flags
 	mockMode := flag.Bool("mock", false, "Run in mock mode (simulated data for UI testing)")
+	encryptConfig := flag.Bool("encrypt-config", false, "Encrypt config.yml using the encryption section and exit")
+	listBackups := flag.Bool("list-backups", false, "List config backups and exit")
+	debug := flag.Bool("debug", false, "Show internal metrics such as command queueing in the bottom bar")
+	restoreBackup := flag.String("restore-backup", "", "Restore config from the named backup and exit")
+	purgeCache := flag.Bool("purge-cache", false, "Delete the status cached for each instance and exit")
+	recordDir := flag.String("record", "", "Record each instance's raw command output under this directory as fixtures")
+	replayDir := flag.String("replay", "", "Serve each instance from fixtures recorded under this directory with --record")
 	flag.Parse()
 
+	if *recordDir != "" && (*replayDir != "" || *mockMode) {
+		fmt.Fprintln(os.Stderr, "Error: --record cannot be combined with --replay or --mock")
+		os.Exit(1)
+	}
+
+	if *listBackups {
+		backups, err := config.ListBackups()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing backups: %v\n", err)
+			os.Exit(1)
+		}
+		if len(backups) == 0 {
+			fmt.Println("No config backups found")
+			return
+		}
+		for _, b := range backups {
+			fmt.Printf("%s  %s\n", b.ModTime.Format("2006-01-02 15:04:05"), b.Name)
+		}
+		return
+	}
+
+	if *restoreBackup != "" {
+		if err := config.RestoreBackup(*restoreBackup); err != nil {
+			fmt.Fprintf(os.Stderr, "Error restoring backup: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Restored config from %s\n", *restoreBackup)
+		return
+	}
+
+	if *purgeCache {
+		purged, err := state.PurgeStatusCache()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error purging status cache: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Purged %d cached statuses\n", purged)
+		return
+	}
+
 	// Load or create configuration
 	cfg, _, err := config.Load()
 	if err != nil {
@@ -24,15 +81,39 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *encryptConfig {
+		if !cfg.Encryption.Enabled() {
+			fmt.Fprintln(os.Stderr, "Error: set encryption.tool and encryption.recipients in config.yml first")
+			os.Exit(1)
+		}
+		if err := config.Save(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encrypting config: %v\n", err)
+			os.Exit(1)
+		}
+		path, _ := config.EncryptedConfigPath(cfg.Encryption.Tool)
+		fmt.Printf("Config encrypted to %s\n", path)
+		return
+	}
+
 	// Load UI state
 	uiState, _ := state.Load() // Ignore error, use defaults
 
 	// Initialize the TUI application
 	app := ui.NewApp(cfg, uiState, *mockMode)
+	app.SetDebug(*debug)
+	app.SetRecordDir(*recordDir)
+	app.SetReplayDir(*replayDir)
 
-	// Run the Bubble Tea program
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	// Run the Bubble Tea program. Bubble Tea restores the terminal after a
+	// panic; the app saves a crash report before letting it through.
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithReportFocus())
 	finalModel, err := p.Run()
+	app.Shutdown(shutdownTimeout)
+	if path, reportErr := app.CrashReportPath(); path != "" {
+		fmt.Fprintf(os.Stderr, "lazyclaw crashed; a crash report was saved to %s\n", path)
+	} else if reportErr != nil {
+		fmt.Fprintf(os.Stderr, "lazyclaw crashed; saving the crash report failed: %v\n", reportErr)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running lazyclaw: %v\n", err)
 		os.Exit(1)
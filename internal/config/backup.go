@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultBackupKeep is the number of config backups kept when not configured
+const DefaultBackupKeep = 10
+
+// backupTimeFormat is used in backup file names so they sort chronologically
+const backupTimeFormat = "20060102-150405.000"
+
+// Backup describes a saved copy of a previous config version
+type Backup struct {
+	Name    string
+	Path    string
+	ModTime time.Time
+}
+
+// BackupDir returns the directory holding config backups
+func BackupDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.d", "backups"), nil
+}
+
+// currentConfigFile returns the path of the config file currently on disk
+// (encrypted or plaintext), or "" if none exists
+func currentConfigFile() (string, error) {
+	_, encPath, err := findEncryptedConfig()
+	if err != nil {
+		return "", err
+	}
+	if encPath != "" {
+		return encPath, nil
+	}
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// backupCurrent backs up the config file on disk and prunes old backups
+// beyond keep. With enc enabled, a plaintext config is backed up encrypted.
+func backupCurrent(keep int, enc EncryptionConfig) error {
+	if keep < 0 {
+		return nil
+	}
+	if keep == 0 {
+		keep = DefaultBackupKeep
+	}
+
+	if err := copyCurrentToBackups(enc); err != nil {
+		return err
+	}
+	return pruneBackups(keep)
+}
+
+// copyCurrentToBackups writes a timestamped copy of the config file on disk.
+// A plaintext config is encrypted for enc's recipients if enc is enabled,
+// as when --encrypt-config replaces it, so no plaintext copy outlives it.
+func copyCurrentToBackups(enc EncryptionConfig) error {
+	src, err := currentConfigFile()
+	if err != nil || src == "" {
+		return err
+	}
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	dir, err := BackupDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// config.yml -> config-20260215-103000.yml, config.yml.age -> config-...yml.age
+	base := filepath.Base(src)
+	suffix := strings.TrimPrefix(base, "config")
+	if suffix == ".yml" && enc.Enabled() {
+		if data, err = encryptData(enc, data); err != nil {
+			return err
+		}
+		suffix += "." + enc.Tool
+	}
+	name := "config-" + time.Now().Format(backupTimeFormat) + suffix
+	return os.WriteFile(filepath.Join(dir, name), data, 0600)
+}
+
+// encryptPlaintextBackups encrypts the plaintext backups left from before
+// encryption was enabled, replacing each with its .age / .gpg equivalent
+// under the same timestamp
+func encryptPlaintextBackups(enc EncryptionConfig) error {
+	backups, err := ListBackups()
+	if err != nil {
+		return err
+	}
+	for _, b := range backups {
+		if !strings.HasSuffix(b.Name, ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(b.Path)
+		if err != nil {
+			return err
+		}
+		encrypted, err := encryptData(enc, data)
+		if err != nil {
+			return err
+		}
+		if err := writeAtomic(b.Path+"."+enc.Tool, encrypted, 0600); err != nil {
+			return err
+		}
+		if err := os.Remove(b.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneBackups removes the oldest backups so that at most keep remain
+func pruneBackups(keep int) error {
+	backups, err := ListBackups()
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListBackups returns available config backups, newest first
+func ListBackups() ([]Backup, error) {
+	dir, err := BackupDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "config-") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{
+			Name:    entry.Name(),
+			Path:    filepath.Join(dir, entry.Name()),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	// Names embed the timestamp, so reverse lexical order is newest first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name > backups[j].Name
+	})
+	return backups, nil
+}
+
+// RestoreBackup replaces the current config with the named backup.
+// The current config is backed up first so a restore can itself be undone.
+func RestoreBackup(name string) error {
+	backups, err := ListBackups()
+	if err != nil {
+		return err
+	}
+
+	var backup *Backup
+	for i := range backups {
+		if backups[i].Name == name {
+			backup = &backups[i]
+			break
+		}
+	}
+	if backup == nil {
+		return fmt.Errorf("backup %q not found", name)
+	}
+
+	data, err := os.ReadFile(backup.Path)
+	if err != nil {
+		return err
+	}
+
+	if err := copyCurrentToBackups(EncryptionConfig{}); err != nil {
+		return fmt.Errorf("failed to back up current config: %w", err)
+	}
+
+	// Restore to the matching plaintext/encrypted path and remove the other
+	// variants so Load picks up the restored file
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	target := path
+	for _, tool := range []string{EncryptionAge, EncryptionGPG} {
+		if strings.HasSuffix(name, "."+tool) {
+			target = path + "." + tool
+		}
+	}
+	for _, candidate := range []string{path, path + "." + EncryptionAge, path + "." + EncryptionGPG} {
+		if candidate == target {
+			continue
+		}
+		if err := os.Remove(candidate); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return writeAtomic(target, data, 0600)
+}
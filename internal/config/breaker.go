@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Circuit breaker defaults, used for BreakerConfig fields left unset
+const (
+	DefaultBreakerFailures      = 5
+	DefaultBreakerProbeInterval = 30 * time.Second
+)
+
+// BreakerConfig sets when lazyclaw stops polling a remote instance that
+// keeps failing to answer, so its timeouts do not starve the others
+type BreakerConfig struct {
+	Failures      int    `yaml:"failures,omitempty"`       // Consecutive connection failures or timeouts that mark the instance unreachable (0 = default, -1 = never)
+	ProbeInterval string `yaml:"probe_interval,omitempty"` // How often an unreachable instance is tried again
+}
+
+// Threshold returns the consecutive failures that open the circuit, or 0 if
+// it never opens
+func (b BreakerConfig) Threshold() int {
+	switch {
+	case b.Failures < 0:
+		return 0
+	case b.Failures == 0:
+		return DefaultBreakerFailures
+	}
+	return b.Failures
+}
+
+// Interval returns how often an unreachable instance is probed
+func (b BreakerConfig) Interval() time.Duration {
+	if d, err := time.ParseDuration(b.ProbeInterval); err == nil {
+		return d
+	}
+	return DefaultBreakerProbeInterval
+}
+
+// validate rejects a malformed probe interval
+func (b BreakerConfig) validate() error {
+	if b.ProbeInterval == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(b.ProbeInterval); err != nil || d <= 0 {
+		return fmt.Errorf("circuit_breaker.probe_interval: invalid duration %q (use e.g. 30s or 2m)", b.ProbeInterval)
+	}
+	return nil
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/lazyclaw/lazyclaw/internal/models"
 	"gopkg.in/yaml.v3"
@@ -11,23 +14,134 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Instances   []models.InstanceProfile `yaml:"instances"`
-	UI          UIConfig                 `yaml:"ui"`
-	Security    SecurityConfig           `yaml:"security"`
-	OpenClawCLI string                   `yaml:"openclaw_cli,omitempty"` // Path to openclaw binary
+	Instances   []models.InstanceProfile    `yaml:"instances"`
+	Templates   map[string]InstanceTemplate `yaml:"templates,omitempty"`
+	UI          UIConfig                    `yaml:"ui"`
+	Security    SecurityConfig              `yaml:"security"`
+	Channels    ChannelsConfig              `yaml:"channels"`
+	Usage       UsageConfig                 `yaml:"usage,omitempty"`
+	Encryption  EncryptionConfig            `yaml:"encryption,omitempty"`
+	BackupKeep  int                         `yaml:"backup_keep,omitempty"`  // Config backups to keep (0 = default, -1 = disabled)
+	OpenClawCLI string                      `yaml:"openclaw_cli,omitempty"` // Path to openclaw binary
+
+	// MaxConcurrentCommands caps the CLI and SSH commands run at once across
+	// all instances (0 = gateway.DefaultConcurrency)
+	MaxConcurrentCommands int `yaml:"max_concurrent_commands,omitempty"`
+
+	// MaxRecentSessions caps the recent sessions kept from each status
+	// refresh, per list (0 = gateway.DefaultMaxRecentSessions)
+	MaxRecentSessions int `yaml:"max_recent_sessions,omitempty"`
+
+	// FetchTimeout bounds each command of instances without their own
+	// timeout, e.g. "30s" or "off" (empty = DefaultFetchTimeout)
+	FetchTimeout string `yaml:"fetch_timeout,omitempty"`
+
+	// Retry sets how status and health fetches failing on a connection error
+	// are retried before the instance is reported down
+	Retry RetryConfig `yaml:"retry,omitempty"`
+
+	// CircuitBreaker sets when a remote instance that keeps failing is
+	// marked unreachable and only probed now and then
+	CircuitBreaker BreakerConfig `yaml:"circuit_breaker,omitempty"`
+
+	// Discovery finds gateways not yet configured, to add from the TUI
+	Discovery DiscoveryConfig `yaml:"discovery,omitempty"`
+
+	// Locked makes the config read-only from within lazyclaw, for setups
+	// where config.yml is managed by configuration management
+	Locked bool `yaml:"locked,omitempty"`
 }
 
+// ErrConfigLocked is returned when saving a config marked as locked
+var ErrConfigLocked = errors.New("config is locked (locked: true); edit config.yml directly")
+
 // UIConfig holds UI-related settings
 type UIConfig struct {
 	Theme        string `yaml:"theme"`
 	RefreshMs    int    `yaml:"refresh_ms"`
 	LogTailLines int    `yaml:"log_tail_lines"`
+
+	// LogArchiveMB is the memory budget for compressed log history beyond
+	// LogTailLines (0 = 8 MB, -1 = keep only counts)
+	LogArchiveMB int `yaml:"log_archive_mb,omitempty"`
+
+	// OverviewWidgets lists the Overview tab widgets in display order;
+	// widgets not listed are hidden. Empty uses DefaultOverviewWidgets.
+	OverviewWidgets []string `yaml:"overview_widgets,omitempty"`
+
+	// TabRefresh overrides how often each tab polls while active, e.g.
+	// security: 10m. Tabs not listed use DefaultTabRefresh or RefreshMs.
+	TabRefresh map[string]string `yaml:"tab_refresh,omitempty"`
+
+	// UnfocusedRefresh is the slowest cadence any tab polls at while the
+	// terminal is unfocused. Empty uses DefaultUnfocusedRefresh.
+	UnfocusedRefresh string `yaml:"unfocused_refresh,omitempty"`
+
+	// BackgroundRefresh is how often every instance other than the selected
+	// one is polled for its status badge, e.g. "1m" or "off". Empty uses
+	// DefaultBackgroundRefresh.
+	BackgroundRefresh string `yaml:"background_refresh,omitempty"`
+
+	// StatusCacheTTL is how old a status saved on exit may be and still be
+	// shown, marked stale, on the next launch, e.g. "1h" or "off". Empty
+	// uses DefaultStatusCacheTTL.
+	StatusCacheTTL string `yaml:"status_cache_ttl,omitempty"`
+
+	// BatchRefresh fetches status and health together in one remote shell
+	// invocation on each refresh of an SSH or kubectl instance, instead of
+	// polling status alone
+	BatchRefresh bool `yaml:"batch_refresh,omitempty"`
 }
 
 // SecurityConfig holds security-related settings
 type SecurityConfig struct {
 	DefaultScopes    []string `yaml:"default_scopes"`
 	AllowWriteScopes bool     `yaml:"allow_write_scopes"`
+
+	// GatewayConfigEditable lists gateway config keys that may be edited from
+	// the Config tab (requires AllowWriteScopes). "prefix.*" matches a subtree.
+	GatewayConfigEditable []string `yaml:"gateway_config_editable,omitempty"`
+
+	// DryRun confirms writes as usual, then only shows what they would run
+	DryRun bool `yaml:"dry_run,omitempty"`
+}
+
+// GatewayConfigKeyEditable reports whether a gateway config key is whitelisted for editing
+func (s SecurityConfig) GatewayConfigKeyEditable(key string) bool {
+	if !s.AllowWriteScopes {
+		return false
+	}
+	for _, pattern := range s.GatewayConfigEditable {
+		if pattern == key {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelsConfig holds channel monitoring settings
+type ChannelsConfig struct {
+	AuthExpiryDays int `yaml:"auth_expiry_days"` // Expected link lifetime before re-auth is needed
+}
+
+// UsageConfig holds Usage tab settings
+type UsageConfig struct {
+	// Pricing maps a model name to its price, for cost estimates
+	Pricing map[string]ModelPrice `yaml:"pricing,omitempty"`
+}
+
+// ModelPrice is a model's price in USD per million tokens
+type ModelPrice struct {
+	InputPerMTok  float64 `yaml:"input_per_mtok"`
+	OutputPerMTok float64 `yaml:"output_per_mtok"`
+}
+
+// Cost returns the estimated cost of the given token counts
+func (p ModelPrice) Cost(inputTokens, outputTokens int) float64 {
+	return float64(inputTokens)*p.InputPerMTok/1e6 + float64(outputTokens)*p.OutputPerMTok/1e6
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -43,6 +157,9 @@ func DefaultConfig() *Config {
 			DefaultScopes:    []string{"operator.read"},
 			AllowWriteScopes: false,
 		},
+		Channels: ChannelsConfig{
+			AuthExpiryDays: 14,
+		},
 	}
 }
 
@@ -50,6 +167,13 @@ func DefaultConfig() *Config {
 func ConfigDir() (string, error) {
 	// Check XDG_CONFIG_HOME first
 	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" && runtime.GOOS == "windows" {
+		// %AppData%, where Windows programs keep their settings
+		var err error
+		if configHome, err = os.UserConfigDir(); err != nil {
+			return "", err
+		}
+	}
 	if configHome == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -77,25 +201,89 @@ func Load() (*Config, bool, error) {
 		return nil, false, err
 	}
 
-	data, err := os.ReadFile(path)
+	// Prefer an encrypted config if one exists
+	tool, encPath, err := findEncryptedConfig()
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			// First run - return default config
-			return DefaultConfig(), true, nil
-		}
 		return nil, false, err
 	}
 
+	var data []byte
+	if tool != "" {
+		data, err = decryptFile(tool, encPath)
+		if err != nil {
+			return nil, false, err
+		}
+	} else {
+		data, err = os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// First run - return default config
+				return DefaultConfig(), true, nil
+			}
+			return nil, false, err
+		}
+	}
+
 	cfg := DefaultConfig()
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, false, err
 	}
 
+	if err := cfg.validateTemplates(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.UI.validateOverviewWidgets(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.UI.validateTabRefresh(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateTimeouts(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.Retry.validate(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.CircuitBreaker.validate(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateHostKeyPolicies(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateEscalation(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.Discovery.validate(cfg.Templates); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateEnv(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateRemoteShells(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateTransports(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateSSHClients(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateGRPC(); err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validateWS(); err != nil {
+		return nil, false, err
+	}
+
 	return cfg, false, nil
 }
 
 // Save writes the configuration to disk
 func Save(cfg *Config) error {
+	if cfg.Locked {
+		return ErrConfigLocked
+	}
+
 	dir, err := ConfigDir()
 	if err != nil {
 		return err
@@ -116,12 +304,42 @@ func Save(cfg *Config) error {
 		return err
 	}
 
-	// Write atomically: write to temp file, then rename
+	// Keep the previous version around in case the UI mangled something
+	if err := backupCurrent(cfg.BackupKeep, cfg.Encryption); err != nil {
+		return fmt.Errorf("failed to back up config: %w", err)
+	}
+
+	if cfg.Encryption.Enabled() {
+		encrypted, err := encryptData(cfg.Encryption, data)
+		if err != nil {
+			return err
+		}
+		encPath, err := EncryptedConfigPath(cfg.Encryption.Tool)
+		if err != nil {
+			return err
+		}
+		if err := writeAtomic(encPath, encrypted, 0600); err != nil {
+			return err
+		}
+		// Don't leave a plaintext copy behind, current or backed up
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err := encryptPlaintextBackups(cfg.Encryption); err != nil {
+			return fmt.Errorf("config encrypted, but plaintext backups remain in config.d/backups: %w", err)
+		}
+		return nil
+	}
+
+	return writeAtomic(path, data, 0644)
+}
+
+// writeAtomic writes to a temp file, then renames it into place
+func writeAtomic(path string, data []byte, perm os.FileMode) error {
 	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+	if err := os.WriteFile(tmpPath, data, perm); err != nil {
 		return err
 	}
-
 	return os.Rename(tmpPath, path)
 }
 
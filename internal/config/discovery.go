@@ -0,0 +1,28 @@
+package config
+
+import "fmt"
+
+// DiscoveryConfig sets where lazyclaw looks for gateways not yet in the
+// config, to offer them as new instances
+type DiscoveryConfig struct {
+	Tailscale TailscaleDiscoveryConfig `yaml:"tailscale,omitempty"`
+}
+
+// TailscaleDiscoveryConfig enables looking for openclaw on the peers of the
+// local tailnet, as listed by `tailscale status --json`
+type TailscaleDiscoveryConfig struct {
+	Enabled  bool   `yaml:"enabled,omitempty"`
+	Tag      string `yaml:"tag,omitempty"`      // Only probe peers with this ACL tag, e.g. "tag:openclaw"
+	User     string `yaml:"user,omitempty"`     // SSH user for probing and for the added instances
+	Template string `yaml:"template,omitempty"` // Template the added instances inherit from
+}
+
+// validate checks that the template given to discovered instances exists
+func (d DiscoveryConfig) validate(templates map[string]InstanceTemplate) error {
+	if tmpl := d.Tailscale.Template; tmpl != "" {
+		if _, ok := templates[tmpl]; !ok {
+			return fmt.Errorf("discovery.tailscale.template: unknown template %q", tmpl)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Supported encryption tools
+const (
+	EncryptionAge = "age"
+	EncryptionGPG = "gpg"
+)
+
+// EncryptionConfig holds settings for encrypting config.yml at rest.
+// When Tool is set, Save writes config.yml.age / config.yml.gpg instead of
+// the plaintext file, and Load decrypts it at startup (the tool prompts for
+// the passphrase or key on the terminal before the TUI starts). An age
+// identity file is read from $LAZYCLAW_AGE_IDENTITY, as the config holding
+// its path is the one being decrypted.
+type EncryptionConfig struct {
+	Tool       string   `yaml:"tool,omitempty"`       // "age" or "gpg"
+	Recipients []string `yaml:"recipients,omitempty"` // age recipients or gpg key IDs
+}
+
+// Enabled returns true if config encryption is configured
+func (e EncryptionConfig) Enabled() bool {
+	return e.Tool != ""
+}
+
+// EncryptedConfigPath returns the path of the encrypted config for a tool
+func EncryptedConfigPath(tool string) (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return path + "." + tool, nil
+}
+
+// findEncryptedConfig returns the tool and path of an existing encrypted config, if any
+func findEncryptedConfig() (string, string, error) {
+	for _, tool := range []string{EncryptionAge, EncryptionGPG} {
+		path, err := EncryptedConfigPath(tool)
+		if err != nil {
+			return "", "", err
+		}
+		if _, err := os.Stat(path); err == nil {
+			return tool, path, nil
+		}
+	}
+	return "", "", nil
+}
+
+// decryptFile decrypts an encrypted config file using the given tool.
+// Stdin and stderr are attached to the terminal so the tool can prompt.
+func decryptFile(tool, path string) ([]byte, error) {
+	var cmd *exec.Cmd
+	switch tool {
+	case EncryptionAge:
+		args := []string{"--decrypt"}
+		if identity := os.Getenv("LAZYCLAW_AGE_IDENTITY"); identity != "" {
+			args = append(args, "-i", expandHome(identity))
+		}
+		args = append(args, path)
+		cmd = exec.Command("age", args...)
+	case EncryptionGPG:
+		cmd = exec.Command("gpg", "--quiet", "--decrypt", path)
+	default:
+		return nil, fmt.Errorf("unsupported encryption tool: %s", tool)
+	}
+
+	var stdout bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", filepath.Base(path), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// encryptData encrypts data for the configured recipients
+func encryptData(enc EncryptionConfig, data []byte) ([]byte, error) {
+	if len(enc.Recipients) == 0 {
+		return nil, errors.New("encryption enabled but no recipients configured")
+	}
+
+	var args []string
+	var cmd *exec.Cmd
+	switch enc.Tool {
+	case EncryptionAge:
+		args = append(args, "--encrypt")
+		for _, r := range enc.Recipients {
+			if strings.HasPrefix(r, "age1") || strings.HasPrefix(r, "ssh-") {
+				args = append(args, "-r", r)
+			} else {
+				// Treat anything else as a recipients file
+				args = append(args, "-R", expandHome(r))
+			}
+		}
+		cmd = exec.Command("age", args...)
+	case EncryptionGPG:
+		args = append(args, "--batch", "--yes", "--quiet", "--encrypt")
+		for _, r := range enc.Recipients {
+			args = append(args, "-r", r)
+		}
+		cmd = exec.Command("gpg", args...)
+	default:
+		return nil, fmt.Errorf("unsupported encryption tool: %s", enc.Tool)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s encrypt failed: %s", enc.Tool, msg)
+		}
+		return nil, fmt.Errorf("%s encrypt failed: %w", enc.Tool, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// expandHome expands a leading ~ to the user's home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
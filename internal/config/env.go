@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// envNamePattern matches the variable names a POSIX shell can export
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateEnv rejects env entries whose names cannot be exported
+func (c *Config) validateEnv() error {
+	for name, tmpl := range c.Templates {
+		if err := checkEnvNames(tmpl.Env); err != nil {
+			return fmt.Errorf("template %q: %w", name, err)
+		}
+	}
+	for _, inst := range c.Instances {
+		if err := checkEnvNames(inst.Env); err != nil {
+			return fmt.Errorf("instance %q: %w", inst.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkEnvNames(env map[string]string) error {
+	for name := range env {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("env: invalid variable name %q", name)
+		}
+	}
+	return nil
+}
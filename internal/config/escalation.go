@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// validateEscalation rejects unknown escalation methods, and askpass set for
+// a method that cannot use it
+func (c *Config) validateEscalation() error {
+	for name, tmpl := range c.Templates {
+		if err := checkEscalation(tmpl.Escalation); err != nil {
+			return fmt.Errorf("template %q: %w", name, err)
+		}
+	}
+	for _, inst := range c.Instances {
+		if err := checkEscalation(inst.Escalation); err != nil {
+			return fmt.Errorf("instance %q: %w", inst.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkEscalation(e *models.EscalationConfig) error {
+	if e == nil {
+		return nil
+	}
+	switch e.Method {
+	case "", models.EscalationNone, models.EscalationDoas:
+		if e.Askpass != "" {
+			return fmt.Errorf("escalation.askpass is only used with method %s", models.EscalationSudo)
+		}
+	case models.EscalationSudo:
+	default:
+		return fmt.Errorf("escalation.method: unknown method %q (use %s, %s or %s)",
+			e.Method, models.EscalationSudo, models.EscalationDoas, models.EscalationNone)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// validateGRPC rejects gRPC settings that cannot work: a missing or
+// malformed address, or a client certificate without its key
+func (c *Config) validateGRPC() error {
+	for _, inst := range c.Instances {
+		g := inst.GRPC
+		if g == nil {
+			continue
+		}
+		var err error
+		switch {
+		case g.Address == "":
+			err = errors.New("grpc.address is not set")
+		case (g.Cert == "") != (g.Key == ""):
+			err = errors.New("grpc.cert and grpc.key must be set together")
+		case g.Plaintext && (g.CACert != "" || g.Cert != ""):
+			err = errors.New("grpc.plaintext cannot be combined with certificates")
+		}
+		if err == nil {
+			if _, _, splitErr := net.SplitHostPort(g.Address); splitErr != nil {
+				err = fmt.Errorf("grpc.address: %w", splitErr)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("instance %q: %w", inst.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// KnownHostsPath returns the known_hosts file lazyclaw writes SSH host keys
+// to, next to config.yml
+func KnownHostsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "known_hosts"), nil
+}
+
+// validateHostKeyPolicies rejects unknown ssh.host_key_policy values
+func (c *Config) validateHostKeyPolicies() error {
+	for name, tmpl := range c.Templates {
+		if tmpl.SSH != nil && !validHostKeyPolicy(tmpl.SSH.HostKeyPolicy) {
+			return fmt.Errorf("template %q: %w", name, hostKeyPolicyError(tmpl.SSH.HostKeyPolicy))
+		}
+	}
+	for _, inst := range c.Instances {
+		if inst.SSH != nil && !validHostKeyPolicy(inst.SSH.HostKeyPolicy) {
+			return fmt.Errorf("instance %q: %w", inst.Name, hostKeyPolicyError(inst.SSH.HostKeyPolicy))
+		}
+	}
+	return nil
+}
+
+func validHostKeyPolicy(policy string) bool {
+	switch policy {
+	case "", models.HostKeyStrict, models.HostKeyAcceptNew, models.HostKeyInsecure:
+		return true
+	}
+	return false
+}
+
+func hostKeyPolicyError(policy string) error {
+	return fmt.Errorf("ssh.host_key_policy: unknown policy %q (use %s, %s or %s)",
+		policy, models.HostKeyStrict, models.HostKeyAcceptNew, models.HostKeyInsecure)
+}
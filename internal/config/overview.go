@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Overview tab widgets
+const (
+	WidgetQuickStatus    = "quick_status"
+	WidgetAlerts         = "alerts"
+	WidgetChannels       = "channels"
+	WidgetModel          = "model"
+	WidgetMemory         = "memory"
+	WidgetRecentSessions = "recent_sessions"
+	WidgetLatency        = "latency"
+	WidgetGauges         = "gauges"
+)
+
+// OverviewWidgetNames lists all known Overview widgets
+var OverviewWidgetNames = []string{
+	WidgetQuickStatus,
+	WidgetAlerts,
+	WidgetChannels,
+	WidgetModel,
+	WidgetMemory,
+	WidgetRecentSessions,
+	WidgetLatency,
+	WidgetGauges,
+}
+
+// DefaultOverviewWidgets is the Overview layout used when ui.overview_widgets is unset
+var DefaultOverviewWidgets = []string{
+	WidgetQuickStatus,
+	WidgetGauges,
+	WidgetChannels,
+	WidgetModel,
+	WidgetMemory,
+	WidgetRecentSessions,
+}
+
+// Widgets returns the Overview widgets to show, in order
+func (u UIConfig) Widgets() []string {
+	if len(u.OverviewWidgets) == 0 {
+		return DefaultOverviewWidgets
+	}
+	return u.OverviewWidgets
+}
+
+// validateOverviewWidgets rejects unknown or duplicate widget names
+func (u UIConfig) validateOverviewWidgets() error {
+	seen := make(map[string]bool)
+	for _, w := range u.OverviewWidgets {
+		if !containsString(OverviewWidgetNames, w) {
+			return fmt.Errorf("ui.overview_widgets: unknown widget %q (valid: %s)", w, strings.Join(OverviewWidgetNames, ", "))
+		}
+		if seen[w] {
+			return fmt.Errorf("ui.overview_widgets: widget %q listed twice", w)
+		}
+		seen[w] = true
+	}
+	return nil
+}
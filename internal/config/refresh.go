@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TabRefreshNames lists the tabs whose refresh cadence can be configured
+var TabRefreshNames = []string{
+	"overview", "logs", "health", "channels", "agents", "sessions",
+	"events", "memory", "security", "system", "usage", "config", "hooks",
+	"queues",
+}
+
+// DefaultTabRefresh is the cadence of tabs not set in ui.tab_refresh. Tabs
+// without a default refresh every ui.refresh_ms. The security audit is slow,
+// so the Security tab refreshes rarely.
+var DefaultTabRefresh = map[string]time.Duration{
+	"security": 10 * time.Minute,
+	"usage":    30 * time.Second,
+	"config":   0,
+}
+
+// DefaultUnfocusedRefresh is the refresh cadence while the terminal is
+// unfocused, unless ui.unfocused_refresh says otherwise
+const DefaultUnfocusedRefresh = 30 * time.Second
+
+// DefaultBackgroundRefresh is how often non-selected instances are polled,
+// unless ui.background_refresh says otherwise
+const DefaultBackgroundRefresh = 30 * time.Second
+
+// DefaultStatusCacheTTL is how long a status saved on exit is shown on the
+// next launch, unless ui.status_cache_ttl says otherwise
+const DefaultStatusCacheTTL = 24 * time.Hour
+
+// RefreshInterval returns how often the named tab polls the gateway while it
+// is active. Zero disables periodic refresh for the tab.
+func (u UIConfig) RefreshInterval(tab string) time.Duration {
+	if value, ok := u.TabRefresh[tab]; ok {
+		interval, _ := parseRefreshInterval(value)
+		return interval
+	}
+	if interval, ok := DefaultTabRefresh[tab]; ok {
+		return interval
+	}
+	if u.RefreshMs <= 0 {
+		return time.Second
+	}
+	return time.Duration(u.RefreshMs) * time.Millisecond
+}
+
+// UnfocusedRefreshInterval returns the minimum interval between refreshes
+// while the terminal is unfocused. Zero pauses refresh until focus returns.
+func (u UIConfig) UnfocusedRefreshInterval() time.Duration {
+	if u.UnfocusedRefresh == "" {
+		return DefaultUnfocusedRefresh
+	}
+	interval, _ := parseRefreshInterval(u.UnfocusedRefresh)
+	return interval
+}
+
+// BackgroundRefreshInterval returns how often each non-selected instance is
+// polled. Zero disables background polling.
+func (u UIConfig) BackgroundRefreshInterval() time.Duration {
+	if u.BackgroundRefresh == "" {
+		return DefaultBackgroundRefresh
+	}
+	interval, _ := parseRefreshInterval(u.BackgroundRefresh)
+	return interval
+}
+
+// StatusCacheMaxAge returns how old a saved status may be to be shown while
+// fresh data loads. Zero disables the status cache.
+func (u UIConfig) StatusCacheMaxAge() time.Duration {
+	if u.StatusCacheTTL == "" {
+		return DefaultStatusCacheTTL
+	}
+	ttl, _ := parseRefreshInterval(u.StatusCacheTTL)
+	return ttl
+}
+
+// parseRefreshInterval parses a Go duration such as "5s" or "10m"; "0" and
+// "off" disable refresh
+func parseRefreshInterval(value string) (time.Duration, error) {
+	if value == "off" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("invalid interval %q (use e.g. 5s, 10m or off)", value)
+	}
+	return interval, nil
+}
+
+// validateTabRefresh rejects unknown tab names and malformed intervals
+func (u UIConfig) validateTabRefresh() error {
+	if u.UnfocusedRefresh != "" {
+		if _, err := parseRefreshInterval(u.UnfocusedRefresh); err != nil {
+			return fmt.Errorf("ui.unfocused_refresh: %w", err)
+		}
+	}
+	if u.BackgroundRefresh != "" {
+		if _, err := parseRefreshInterval(u.BackgroundRefresh); err != nil {
+			return fmt.Errorf("ui.background_refresh: %w", err)
+		}
+	}
+	if u.StatusCacheTTL != "" {
+		if _, err := parseRefreshInterval(u.StatusCacheTTL); err != nil {
+			return fmt.Errorf("ui.status_cache_ttl: %w", err)
+		}
+	}
+	for tab, value := range u.TabRefresh {
+		if !containsString(TabRefreshNames, tab) {
+			return fmt.Errorf("ui.tab_refresh: unknown tab %q (valid: %s)", tab, strings.Join(TabRefreshNames, ", "))
+		}
+		if _, err := parseRefreshInterval(value); err != nil {
+			return fmt.Errorf("ui.tab_refresh.%s: %w", tab, err)
+		}
+	}
+	return nil
+}
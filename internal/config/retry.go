@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Retry defaults, used for RetryConfig fields left unset
+const (
+	DefaultRetryAttempts   = 3
+	DefaultRetryBackoff    = 500 * time.Millisecond
+	DefaultRetryMaxBackoff = 5 * time.Second
+	DefaultRetryJitter     = 0.2
+)
+
+// RetryConfig sets how status and health fetches that fail to reach an
+// instance (an SSH or kubectl connection error) are retried before the
+// instance is reported down
+type RetryConfig struct {
+	Attempts   int     `yaml:"attempts,omitempty"`    // Tries per fetch, including the first (0 = default, 1 = no retries)
+	Backoff    string  `yaml:"backoff,omitempty"`     // Delay before the first retry, doubled for each further one
+	MaxBackoff string  `yaml:"max_backoff,omitempty"` // Cap on the delay
+	Jitter     float64 `yaml:"jitter,omitempty"`      // Random spread of each delay as a fraction of it (0 = default, -1 = none)
+}
+
+// MaxAttempts returns the tries per fetch, including the first
+func (r RetryConfig) MaxAttempts() int {
+	if r.Attempts <= 0 {
+		return DefaultRetryAttempts
+	}
+	return r.Attempts
+}
+
+// Delays returns the delay before the first retry and its cap
+func (r RetryConfig) Delays() (backoff, maxBackoff time.Duration) {
+	backoff, maxBackoff = DefaultRetryBackoff, DefaultRetryMaxBackoff
+	if d, err := time.ParseDuration(r.Backoff); err == nil {
+		backoff = d
+	}
+	if d, err := time.ParseDuration(r.MaxBackoff); err == nil {
+		maxBackoff = d
+	}
+	return backoff, maxBackoff
+}
+
+// Spread returns the random spread of each delay, as a fraction of it
+func (r RetryConfig) Spread() float64 {
+	switch {
+	case r.Jitter < 0:
+		return 0
+	case r.Jitter == 0:
+		return DefaultRetryJitter
+	}
+	return r.Jitter
+}
+
+// validate rejects malformed retry settings
+func (r RetryConfig) validate() error {
+	if r.Attempts < 0 {
+		return fmt.Errorf("retry.attempts: must be at least 1, got %d", r.Attempts)
+	}
+	for _, d := range []struct{ key, value string }{
+		{"backoff", r.Backoff}, {"max_backoff", r.MaxBackoff},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil || v < 0 {
+			return fmt.Errorf("retry.%s: invalid duration %q (use e.g. 500ms or 2s)", d.key, d.value)
+		}
+	}
+	if r.Jitter > 1 {
+		return fmt.Errorf("retry.jitter: must be between 0 and 1, got %g", r.Jitter)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// InstanceScopes returns the operator scopes granted to a resolved instance:
+// its own scopes if set, else security.default_scopes plus operator.write
+// when allow_write_scopes is on. Without allow_write_scopes, or on a
+// read_only instance, operator.write is never granted, whatever the instance
+// lists.
+func (c *Config) InstanceScopes(inst models.InstanceProfile) []string {
+	scopes := slices.Clone(inst.Scopes)
+	if scopes == nil {
+		scopes = slices.Clone(c.Security.DefaultScopes)
+		if c.Security.AllowWriteScopes && !slices.Contains(scopes, models.ScopeWrite) {
+			scopes = append(scopes, models.ScopeWrite)
+		}
+	}
+	if !c.Security.AllowWriteScopes || inst.ReadOnly {
+		scopes = slices.DeleteFunc(scopes, func(scope string) bool { return scope == models.ScopeWrite })
+	}
+	if scopes == nil {
+		// An empty grant still restricts the adapter
+		scopes = []string{}
+	}
+	return scopes
+}
+
+// InstanceReadOnly reports whether the named instance is read_only, itself
+// or through its template
+func (c *Config) InstanceReadOnly(name string) bool {
+	for _, inst := range c.Instances {
+		if inst.Name == name {
+			return c.ResolveInstance(inst).ReadOnly
+		}
+	}
+	return false
+}
+
+// InstanceWritable reports whether the named instance is granted
+// operator.write, as InstanceScopes resolves its scopes
+func (c *Config) InstanceWritable(name string) bool {
+	var inst models.InstanceProfile
+	for _, candidate := range c.Instances {
+		if candidate.Name == name {
+			inst = c.ResolveInstance(candidate)
+			break
+		}
+	}
+	return slices.Contains(c.InstanceScopes(inst), models.ScopeWrite)
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// validateRemoteShells rejects unknown ssh.shell values
+func (c *Config) validateRemoteShells() error {
+	for name, tmpl := range c.Templates {
+		if tmpl.SSH != nil && !validRemoteShell(tmpl.SSH.Shell) {
+			return fmt.Errorf("template %q: %w", name, remoteShellError(tmpl.SSH.Shell))
+		}
+	}
+	for _, inst := range c.Instances {
+		if inst.SSH != nil && !validRemoteShell(inst.SSH.Shell) {
+			return fmt.Errorf("instance %q: %w", inst.Name, remoteShellError(inst.SSH.Shell))
+		}
+	}
+	return nil
+}
+
+func validRemoteShell(shell string) bool {
+	switch shell {
+	case "", models.RemoteShellBash, models.RemoteShellPowerShell, models.RemoteShellCmd:
+		return true
+	}
+	return false
+}
+
+func remoteShellError(shell string) error {
+	return fmt.Errorf("ssh.shell: unknown shell %q (use %s, %s or %s)",
+		shell, models.RemoteShellBash, models.RemoteShellPowerShell, models.RemoteShellCmd)
+}
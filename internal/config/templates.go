@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// InstanceTemplate holds shared defaults that instances reference by name
+type InstanceTemplate struct {
+	Mode          models.ConnectionMode    `yaml:"mode,omitempty"`
+	Tags          []string                 `yaml:"tags,omitempty"`
+	SSH           *models.SSHConfig        `yaml:"ssh,omitempty"`
+	OpenClawCLI   string                   `yaml:"openclaw_cli,omitempty"`
+	Timeout       string                   `yaml:"timeout,omitempty"`
+	StatusTimeout string                   `yaml:"status_timeout,omitempty"`
+	HealthTimeout string                   `yaml:"health_timeout,omitempty"`
+	IndexTimeout  string                   `yaml:"index_timeout,omitempty"`
+	DoctorTimeout string                   `yaml:"doctor_timeout,omitempty"`
+	Escalation    *models.EscalationConfig `yaml:"escalation,omitempty"`
+	Scopes        []string                 `yaml:"scopes,omitempty"`
+	ReadOnly      bool                     `yaml:"read_only,omitempty"`
+	Env           map[string]string        `yaml:"env,omitempty"`
+}
+
+// validateTemplates checks that every template reference resolves
+func (c *Config) validateTemplates() error {
+	for _, inst := range c.Instances {
+		if inst.Template == "" {
+			continue
+		}
+		if _, ok := c.Templates[inst.Template]; !ok {
+			return fmt.Errorf("instance %q references unknown template %q", inst.Name, inst.Template)
+		}
+	}
+	return nil
+}
+
+// ResolveInstance returns a copy of the instance with its template's defaults
+// applied. Fields set on the instance always win over the template.
+func (c *Config) ResolveInstance(inst models.InstanceProfile) models.InstanceProfile {
+	if inst.Template == "" {
+		return inst
+	}
+	tmpl, ok := c.Templates[inst.Template]
+	if !ok {
+		return inst
+	}
+
+	if inst.Mode == "" {
+		inst.Mode = tmpl.Mode
+		if inst.Mode == "" && tmpl.SSH != nil {
+			inst.Mode = models.ConnectionModeSSH
+		}
+	}
+	if inst.OpenClawCLI == "" {
+		inst.OpenClawCLI = tmpl.OpenClawCLI
+	}
+	if inst.Timeout == "" {
+		inst.Timeout = tmpl.Timeout
+	}
+	if inst.StatusTimeout == "" {
+		inst.StatusTimeout = tmpl.StatusTimeout
+	}
+	if inst.HealthTimeout == "" {
+		inst.HealthTimeout = tmpl.HealthTimeout
+	}
+	if inst.IndexTimeout == "" {
+		inst.IndexTimeout = tmpl.IndexTimeout
+	}
+	if inst.DoctorTimeout == "" {
+		inst.DoctorTimeout = tmpl.DoctorTimeout
+	}
+	if inst.Escalation == nil {
+		inst.Escalation = tmpl.Escalation
+	}
+	if inst.Scopes == nil {
+		inst.Scopes = tmpl.Scopes
+	}
+	// An instance cannot lift its template's read_only
+	inst.ReadOnly = inst.ReadOnly || tmpl.ReadOnly
+	if len(tmpl.Env) > 0 {
+		// Variables set on the instance win over the template's
+		env := maps.Clone(tmpl.Env)
+		maps.Copy(env, inst.Env)
+		inst.Env = env
+	}
+	for _, tag := range tmpl.Tags {
+		if !containsString(inst.Tags, tag) {
+			inst.Tags = append(inst.Tags, tag)
+		}
+	}
+
+	if tmpl.SSH != nil {
+		// Copy so the configured instance isn't mutated
+		merged := models.SSHConfig{}
+		if inst.SSH != nil {
+			merged = *inst.SSH
+		}
+		if merged.Host == "" {
+			merged.Host = tmpl.SSH.Host
+		}
+		if merged.Host == "" {
+			// Fleet hosts are usually named after their SSH host
+			merged.Host = inst.Name
+		}
+		if merged.Port == 0 {
+			merged.Port = tmpl.SSH.Port
+		}
+		if merged.User == "" {
+			merged.User = tmpl.SSH.User
+		}
+		if merged.IdentityFile == "" {
+			merged.IdentityFile = tmpl.SSH.IdentityFile
+		}
+		if merged.ProxyJump == "" {
+			merged.ProxyJump = tmpl.SSH.ProxyJump
+		}
+		if merged.ConnectTimeout == 0 {
+			merged.ConnectTimeout = tmpl.SSH.ConnectTimeout
+		}
+		if merged.OpenClawCLI == "" {
+			merged.OpenClawCLI = tmpl.SSH.OpenClawCLI
+		}
+		if merged.HostKeyPolicy == "" {
+			merged.HostKeyPolicy = tmpl.SSH.HostKeyPolicy
+		}
+		if merged.AgentSocket == "" {
+			merged.AgentSocket = tmpl.SSH.AgentSocket
+		}
+		if merged.Shell == "" {
+			merged.Shell = tmpl.SSH.Shell
+		}
+		merged.Compression = merged.Compression || tmpl.SSH.Compression
+		if merged.ServerAliveInterval == 0 {
+			merged.ServerAliveInterval = tmpl.SSH.ServerAliveInterval
+		}
+		if merged.ServerAliveCountMax == 0 {
+			merged.ServerAliveCountMax = tmpl.SSH.ServerAliveCountMax
+		}
+		if merged.Transport == "" {
+			merged.Transport = tmpl.SSH.Transport
+		}
+		if merged.Client == "" {
+			merged.Client = tmpl.SSH.Client
+		}
+		inst.SSH = &merged
+	}
+
+	return inst
+}
+
+// ResolvedInstances returns all instances with template defaults applied
+func (c *Config) ResolvedInstances() []models.InstanceProfile {
+	resolved := make([]models.InstanceProfile, 0, len(c.Instances))
+	for _, inst := range c.Instances {
+		resolved = append(resolved, c.ResolveInstance(inst))
+	}
+	return resolved
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// DefaultFetchTimeout bounds each CLI or SSH command when neither the
+// instance nor fetch_timeout sets a timeout
+const DefaultFetchTimeout = 15 * time.Second
+
+// DefaultIndexTimeout bounds `openclaw memory index` when the instance sets
+// no index_timeout. A reindex runs until every file is embedded, which
+// takes far longer than a fetch.
+const DefaultIndexTimeout = 10 * time.Minute
+
+// DefaultDoctorTimeout bounds `openclaw doctor` when the instance sets no
+// doctor_timeout, as its checks probe the channels and providers in turn
+const DefaultDoctorTimeout = 2 * time.Minute
+
+// InstanceTimeout returns how long a command against inst may run before it
+// is abandoned and the instance is shown as degraded. The instance's own
+// timeout (or its template's) wins over fetch_timeout. Zero means no limit.
+func (c *Config) InstanceTimeout(inst models.InstanceProfile) time.Duration {
+	inst = c.ResolveInstance(inst)
+	for _, value := range []string{inst.Timeout, c.FetchTimeout} {
+		if value != "" {
+			timeout, _ := parseTimeout(value)
+			return timeout
+		}
+	}
+	return DefaultFetchTimeout
+}
+
+// InstanceCommandTimeouts returns the timeouts inst sets for particular
+// openclaw commands, by command name, overriding InstanceTimeout for them.
+// `memory index` and `doctor` get DefaultIndexTimeout and
+// DefaultDoctorTimeout unless inst sets its own.
+func (c *Config) InstanceCommandTimeouts(inst models.InstanceProfile) map[string]time.Duration {
+	inst = c.ResolveInstance(inst)
+	timeouts := map[string]time.Duration{"memory index": DefaultIndexTimeout, "doctor": DefaultDoctorTimeout}
+	for command, value := range map[string]string{
+		"status":       inst.StatusTimeout,
+		"health":       inst.HealthTimeout,
+		"memory index": inst.IndexTimeout,
+		"doctor":       inst.DoctorTimeout,
+	} {
+		if value != "" {
+			timeouts[command], _ = parseTimeout(value)
+		}
+	}
+	return timeouts
+}
+
+// DefaultInstanceTimeout returns the timeout of instances without their own
+func (c *Config) DefaultInstanceTimeout() time.Duration {
+	return c.InstanceTimeout(models.InstanceProfile{})
+}
+
+// parseTimeout parses a Go duration such as "10s"; "0" and "off" disable
+// the timeout
+func parseTimeout(value string) (time.Duration, error) {
+	if value == "off" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid timeout %q (use e.g. 10s, 1m or off)", value)
+	}
+	return timeout, nil
+}
+
+// validateTimeouts rejects malformed fetch, template and instance timeouts
+func (c *Config) validateTimeouts() error {
+	if c.FetchTimeout != "" {
+		if _, err := parseTimeout(c.FetchTimeout); err != nil {
+			return fmt.Errorf("fetch_timeout: %w", err)
+		}
+	}
+	for name, tmpl := range c.Templates {
+		if err := checkTimeouts(tmpl.Timeout, tmpl.StatusTimeout, tmpl.HealthTimeout, tmpl.IndexTimeout, tmpl.DoctorTimeout); err != nil {
+			return fmt.Errorf("template %q: %w", name, err)
+		}
+	}
+	for _, inst := range c.Instances {
+		if err := checkTimeouts(inst.Timeout, inst.StatusTimeout, inst.HealthTimeout, inst.IndexTimeout, inst.DoctorTimeout); err != nil {
+			return fmt.Errorf("instance %q: %w", inst.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkTimeouts validates a timeout, status_timeout, health_timeout,
+// index_timeout and doctor_timeout
+func checkTimeouts(timeout, status, health, index, doctor string) error {
+	for _, t := range []struct{ key, value string }{
+		{"timeout", timeout}, {"status_timeout", status}, {"health_timeout", health},
+		{"index_timeout", index}, {"doctor_timeout", doctor},
+	} {
+		if t.value == "" {
+			continue
+		}
+		if _, err := parseTimeout(t.value); err != nil {
+			return fmt.Errorf("%s: %w", t.key, err)
+		}
+	}
+	return nil
+}
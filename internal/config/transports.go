@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// validateTransports rejects unknown ssh.transport values
+func (c *Config) validateTransports() error {
+	for name, tmpl := range c.Templates {
+		if tmpl.SSH != nil && !validTransport(tmpl.SSH.Transport) {
+			return fmt.Errorf("template %q: %w", name, transportError(tmpl.SSH.Transport))
+		}
+	}
+	for _, inst := range c.Instances {
+		if inst.SSH != nil && !validTransport(inst.SSH.Transport) {
+			return fmt.Errorf("instance %q: %w", inst.Name, transportError(inst.SSH.Transport))
+		}
+	}
+	return nil
+}
+
+func validTransport(transport string) bool {
+	switch transport {
+	case "", models.TransportSSH, models.TransportResilient:
+		return true
+	}
+	return false
+}
+
+func transportError(transport string) error {
+	if transport == "mosh" {
+		// mosh syncs a terminal screen, so lines scrolled past while the
+		// link was down would be lost rather than resumed
+		return fmt.Errorf("ssh.transport: mosh cannot carry a log stream (use %s)", models.TransportResilient)
+	}
+	return fmt.Errorf("ssh.transport: unknown transport %q (use %s or %s)",
+		transport, models.TransportSSH, models.TransportResilient)
+}
+
+// validateSSHClients rejects unknown ssh.client values
+func (c *Config) validateSSHClients() error {
+	for name, tmpl := range c.Templates {
+		if tmpl.SSH != nil && !validSSHClient(tmpl.SSH.Client) {
+			return fmt.Errorf("template %q: %w", name, sshClientError(tmpl.SSH.Client))
+		}
+	}
+	for _, inst := range c.Instances {
+		if inst.SSH != nil && !validSSHClient(inst.SSH.Client) {
+			return fmt.Errorf("instance %q: %w", inst.Name, sshClientError(inst.SSH.Client))
+		}
+	}
+	return nil
+}
+
+func validSSHClient(client string) bool {
+	switch client {
+	case "", models.SSHClientOpenSSH, models.SSHClientNative:
+		return true
+	}
+	return false
+}
+
+func sshClientError(client string) error {
+	return fmt.Errorf("ssh.client: unknown client %q (use %s or %s)",
+		client, models.SSHClientOpenSSH, models.SSHClientNative)
+}
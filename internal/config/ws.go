@@ -0,0 +1,30 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// validateWS rejects ws mode instances without a usable ws.url
+func (c *Config) validateWS() error {
+	for _, inst := range c.Instances {
+		if c.ResolveInstance(inst).Mode != models.ConnectionModeWS {
+			continue
+		}
+		var err error
+		if inst.WS == nil || inst.WS.URL == "" {
+			err = errors.New("ws.url is not set")
+		} else if u, parseErr := url.Parse(inst.WS.URL); parseErr != nil {
+			err = fmt.Errorf("ws.url: %w", parseErr)
+		} else if u.Scheme != "ws" && u.Scheme != "wss" {
+			err = fmt.Errorf("ws.url: scheme %q is not ws or wss", u.Scheme)
+		}
+		if err != nil {
+			return fmt.Errorf("instance %q: %w", inst.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// Adapter is a connection to one gateway instance, whatever the transport.
+// Features beyond it (channels, memory, files, processes, ...) are only
+// available through the CLI; callers reach them by asserting *CLIAdapter.
+type Adapter interface {
+	GetInstanceName() string
+
+	// IsRemote returns true if the gateway runs on another host
+	IsRemote() bool
+
+	GetFullStatus() (*models.OpenClawStatus, error)
+	GetHealthSnapshot() (*models.HealthCheckResult, error)
+
+	// FollowLogs streams log events via logChan until ctx is done or the
+	// stream ends, then closes logChan
+	FollowLogs(ctx context.Context, logChan chan<- models.LogEvent) error
+
+	// Close releases the adapter's connections, if any
+	Close() error
+}
+
+var (
+	_ Adapter = (*CLIAdapter)(nil)
+	_ Adapter = (*WSClient)(nil)
+	_ Adapter = (*MockClient)(nil)
+	_ Adapter = (*ReplayAdapter)(nil)
+	_ Adapter = (*SocketAdapter)(nil)
+)
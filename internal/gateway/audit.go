@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// CommandRecord describes one command an adapter ran against its instance,
+// for the audit log
+type CommandRecord struct {
+	Instance string
+	Host     string // Where the command ran, e.g. "deploy@gw-1" or "local"
+	Command  string
+	Start    time.Time
+	Duration time.Duration
+	ExitCode int // 0 on success, -1 if the command did not exit
+	Err      error
+}
+
+// Target names where the adapter's commands run, e.g. "deploy@gw-1",
+// "k8s:openclaw/app=gateway" or "local"
+func (c *CLIAdapter) Target() string {
+	switch {
+	case c.isK8s():
+		target := "k8s:" + c.K8sConfig.Selector
+		if c.K8sConfig.Namespace != "" {
+			target = "k8s:" + c.K8sConfig.Namespace + "/" + c.K8sConfig.Selector
+		}
+		if c.K8sConfig.Context != "" {
+			target += "@" + c.K8sConfig.Context
+		}
+		return target
+	case c.IsRemote():
+		host := c.SSHConfig.Host
+		if c.SSHConfig.User != "" && !strings.Contains(host, "@") {
+			host = c.SSHConfig.User + "@" + host
+		}
+		return host
+	}
+	return "local"
+}
+
+// audit reports a finished command to OnCommand, if set
+func (c *CLIAdapter) audit(command string, start time.Time, err error) {
+	if c.OnCommand == nil {
+		return
+	}
+	c.OnCommand(CommandRecord{
+		Instance: c.InstanceName,
+		Host:     c.Target(),
+		Command:  command,
+		Start:    start,
+		Duration: time.Since(start),
+		ExitCode: ExitCode(err),
+		Err:      err,
+	})
+}
+
+// auditCLI reports a finished openclaw command to OnCommand, if set
+func (c *CLIAdapter) auditCLI(args []string, start time.Time, err error) {
+	c.audit(strings.Join(append([]string{"openclaw"}, args...), " "), start, err)
+}
+
+// ExitCode returns the exit status err reports: 0 for nil, -1 if the
+// command did not exit
+func ExitCode(err error) int {
+	var adapterErr *AdapterError
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &adapterErr):
+		return adapterErr.ExitCode
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode()
+	}
+	return -1
+}
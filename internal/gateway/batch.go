@@ -0,0 +1,263 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Section is a piece of instance data the UI may need several of at once,
+// such as when switching to an instance it has not loaded yet
+type Section int
+
+const (
+	SectionStatus Section = iota
+	SectionHealth
+	SectionChannels
+	SectionVersion
+)
+
+// args returns the CLI arguments fetching the section, exactly as its
+// getter passes them
+func (s Section) args() []string {
+	switch s {
+	case SectionHealth:
+		return []string{"health", "--json"}
+	case SectionChannels:
+		return []string{"channels", "--json"}
+	case SectionVersion:
+		return []string{"--version"}
+	}
+	return []string{"status", "--json"}
+}
+
+// batchCall is the result of one command run as part of a combined remote
+// invocation. done is closed once it is known.
+type batchCall struct {
+	done chan struct{}
+	out  []byte
+	ok   bool  // The command exited zero
+	err  error // The whole invocation failed
+}
+
+func batchKey(args []string) string {
+	return strings.Join(args, "\x00")
+}
+
+// Prefetch fetches sections in one remote round trip instead of one per
+// section. The sections are registered right away: their getters, called
+// before the returned function completes, wait for its output rather than
+// opening their own connections. The caller must run the function, once.
+// For local instances, where starting a command is cheap, and hosts without
+// a POSIX shell, it returns nil.
+func (c *CLIAdapter) Prefetch(sections ...Section) func() {
+	commands := make([][]string, len(sections))
+	for i, s := range sections {
+		commands[i] = s.args()
+	}
+	return c.prefetch(commands)
+}
+
+// PrefetchStatus is Prefetch for a status poll leaving out the exclude
+// sections, as GetStatus(exclude) runs it, together with sections. It lets a
+// periodic refresh fetch status and health in a single round trip.
+func (c *CLIAdapter) PrefetchStatus(exclude []string, sections ...Section) func() {
+	// Leave out only what GetStatus will, so it finds the prefetched output
+	c.mu.RLock()
+	if c.excludeUnsupported {
+		exclude = nil
+	}
+	c.mu.RUnlock()
+	version := c.CachedVersion()
+	if version != "" && compareVersions(version, capabilityMinVersions[CapStatusExclude]) < 0 {
+		exclude = nil
+	}
+
+	commands := [][]string{statusArgs(exclude)}
+	for _, s := range sections {
+		commands = append(commands, s.args())
+	}
+	if version == "" {
+		// Checked by the fetches before they run
+		commands = append(commands, SectionVersion.args())
+	}
+	return c.prefetch(commands)
+}
+
+func (c *CLIAdapter) prefetch(commands [][]string) func() {
+	// The combined script needs a POSIX shell; with the gRPC API there is
+	// nothing to batch
+	if !c.IsRemote() || !c.posixRemote() || c.GRPC != nil {
+		return nil
+	}
+
+	var args [][]string
+	var calls []*batchCall
+	c.mu.Lock()
+	if c.batched == nil {
+		c.batched = make(map[string]*batchCall)
+	}
+	for _, a := range commands {
+		key := batchKey(a)
+		if _, pending := c.batched[key]; pending {
+			continue
+		}
+		call := &batchCall{done: make(chan struct{})}
+		c.batched[key] = call
+		args = append(args, a)
+		calls = append(calls, call)
+	}
+	c.mu.Unlock()
+	if len(calls) == 0 {
+		return nil
+	}
+
+	return func() {
+		c.runBatch(args, calls)
+		c.mu.Lock()
+		for _, a := range args {
+			delete(c.batched, batchKey(a))
+		}
+		c.mu.Unlock()
+		for _, call := range calls {
+			close(call.done)
+		}
+	}
+}
+
+// runBatch runs the commands in a single remote shell, each followed by a
+// marker line carrying its exit status, and splits the output at the markers
+func (c *CLIAdapter) runBatch(args [][]string, calls []*batchCall) {
+	var nonce [8]byte
+	_, _ = rand.Read(nonce[:])
+	marker := "lazyclaw-batch-" + hex.EncodeToString(nonce[:])
+
+	// stderr is dropped; a command that fails is rerun on its own so its
+	// error message is reported as usual
+	var script strings.Builder
+	fmt.Fprintf(&script, "printf '%%s\\n' %s", marker)
+	for i, a := range args {
+		fmt.Fprintf(&script, "; %s 2>/dev/null; printf '\\n%%s %%d %%d\\n' %s %d $?", c.remoteCommand(a...), marker, i)
+	}
+
+	if err := c.admit(); err != nil {
+		for _, call := range calls {
+			call.err = err
+		}
+		return
+	}
+	d := newDeadline(c.workContext(), "", c.batchTimeout(args))
+	cmd, err := c.remoteShellCommand(d.ctx, script.String())
+	if err != nil {
+		d.cancel()
+		c.noteOutcome(err)
+		for _, call := range calls {
+			call.err = err
+		}
+		return
+	}
+	watcher := c.watchConnect(cmd)
+	var output []byte
+	runChild(func() {
+		d.start()
+		output, err = cmd.Output()
+	})
+	if timeoutErr := d.finish(); timeoutErr != nil {
+		err = timeoutErr
+	} else if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && watcher != nil {
+			exitErr.Stderr = watcher.Bytes()
+		}
+		err = c.remoteShellError(err)
+	}
+	c.noteOutcome(err)
+	if errors.Is(err, errPodGone) {
+		// Leave each command to rerun on its own, in the new pod
+		return
+	}
+	if err != nil {
+		for _, call := range calls {
+			call.err = err
+		}
+		return
+	}
+
+	// Anything before the first marker (such as login shell noise) is
+	// skipped
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), len(output)+1)
+	var buf bytes.Buffer
+	started := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, marker) {
+			if started {
+				buf.WriteString(line)
+				buf.WriteByte('\n')
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 1 {
+			started = true
+			continue
+		}
+		if len(fields) != 3 {
+			continue
+		}
+		i, err1 := strconv.Atoi(fields[1])
+		code, err2 := strconv.Atoi(fields[2])
+		if err1 == nil && err2 == nil && i >= 0 && i < len(calls) {
+			calls[i].out = bytes.TrimSpace(bytes.Clone(buf.Bytes()))
+			calls[i].ok = code == 0
+		}
+		buf.Reset()
+	}
+}
+
+// batchTimeout returns the longest timeout of the batched commands, as the
+// batch must be allowed to run as long as its slowest member
+func (c *CLIAdapter) batchTimeout(args [][]string) time.Duration {
+	var longest time.Duration
+	for _, a := range args {
+		timeout := c.commandTimeout(a)
+		if timeout == 0 {
+			return 0
+		}
+		longest = max(longest, timeout)
+	}
+	return longest
+}
+
+// prefetched reports whether a pending Prefetch will deliver the output of
+// args
+func (c *CLIAdapter) prefetched(args []string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, pending := c.batched[batchKey(args)]
+	return pending
+}
+
+// awaitBatched waits for a prefetched result for args, if one is pending.
+// found is false when there is none, or when the command failed and should
+// be rerun on its own.
+func (c *CLIAdapter) awaitBatched(args []string) (out []byte, found bool, err error) {
+	c.mu.RLock()
+	call := c.batched[batchKey(args)]
+	c.mu.RUnlock()
+	if call == nil {
+		return nil, false, nil
+	}
+	<-call.done
+	if call.err != nil {
+		return nil, true, call.err
+	}
+	return call.out, call.ok, nil
+}
@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BreakerPolicy sets when an adapter stops running remote commands against
+// an instance that keeps failing to answer, so its timeouts do not hold up
+// worker slots other instances need. The zero value never opens.
+type BreakerPolicy struct {
+	Failures      int           // Consecutive connection failures or timeouts that open the circuit
+	ProbeInterval time.Duration // How often an open circuit lets one command through to probe
+}
+
+// ErrUnreachable is matched (with errors.Is) by the errors of commands
+// rejected because the adapter's circuit is open
+var ErrUnreachable = errors.New("instance unreachable")
+
+// UnreachableError reports a command rejected without being run because
+// the instance failed too often in a row
+type UnreachableError struct {
+	Failures  int
+	NextProbe time.Time
+	Last      error // The failure that opened the circuit
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("unreachable after %d failures (%v); next probe in %s",
+		e.Failures, e.Last, time.Until(e.NextProbe).Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrUnreachable) hold for every UnreachableError
+func (e *UnreachableError) Is(target error) bool {
+	return target == ErrUnreachable
+}
+
+// breaker is the circuit state of one adapter
+type breaker struct {
+	failures  int       // Consecutive failures
+	last      error     // Most recent failure
+	open      bool      // Commands are rejected until nextProbe
+	nextProbe time.Time // When the next probe may run
+	probing   bool      // A probe is in flight
+}
+
+// breakerEnabled returns true if the circuit breaker applies to this adapter;
+// only remote commands are guarded
+func (c *CLIAdapter) breakerEnabled() bool {
+	return c.Breaker.Failures > 0 && c.IsRemote()
+}
+
+// admit returns an *UnreachableError if the circuit is open. Once the probe
+// interval has passed, a single command is let through as a probe.
+func (c *CLIAdapter) admit() error {
+	if !c.breakerEnabled() {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b := &c.circuit
+	if !b.open {
+		return nil
+	}
+	if !b.probing && !time.Now().Before(b.nextProbe) {
+		b.probing = true
+		return nil
+	}
+	return &UnreachableError{Failures: b.failures, NextProbe: b.nextProbe, Last: b.last}
+}
+
+// noteOutcome updates the circuit with the result of an admitted command.
+// Connection failures and timeouts count towards opening it; any other
+// result, including an error reported by openclaw, shows the instance is
+// reachable and closes it.
+func (c *CLIAdapter) noteOutcome(err error) {
+	if !c.breakerEnabled() || errors.Is(err, errPodGone) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b := &c.circuit
+	b.probing = false
+	if errors.Is(err, ErrInterrupted) {
+		// Says nothing about the instance; the next command probes again
+		return
+	}
+	if !retryable(err) && !errors.Is(err, ErrTimeout) {
+		c.circuit = breaker{}
+		return
+	}
+	b.failures++
+	b.last = err
+	if b.open || b.failures >= c.Breaker.Failures {
+		b.open = true
+		b.nextProbe = time.Now().Add(c.Breaker.ProbeInterval)
+	}
+}
+
+// Unreachable returns true while the adapter's circuit is open
+func (c *CLIAdapter) Unreachable() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.circuit.open
+}
@@ -0,0 +1,185 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Capability is an openclaw CLI feature that not every release supports
+type Capability int
+
+const (
+	CapHealthJSON     Capability = iota // health --json
+	CapEventsFollow                     // events --follow --json
+	CapStatusExclude                    // status --exclude
+	CapLogsTail                         // logs --tail
+	CapChannelsStatus                   // channels status --json
+)
+
+func (c Capability) String() string {
+	switch c {
+	case CapHealthJSON:
+		return "health --json"
+	case CapEventsFollow:
+		return "events --follow"
+	case CapStatusExclude:
+		return "status --exclude"
+	case CapLogsTail:
+		return "logs --tail"
+	case CapChannelsStatus:
+		return "channels status"
+	}
+	return "unknown"
+}
+
+// Capabilities lists every capability, in display order
+var Capabilities = []Capability{CapHealthJSON, CapEventsFollow, CapStatusExclude, CapLogsTail, CapChannelsStatus}
+
+// capabilityMinVersions holds the openclaw release that introduced each
+// capability
+var capabilityMinVersions = map[Capability]string{
+	CapHealthJSON:     "2026.1.5",
+	CapEventsFollow:   "2026.1.20",
+	CapStatusExclude:  "2026.1.24",
+	CapLogsTail:       "2026.1.12",
+	CapChannelsStatus: "2026.1.29",
+}
+
+// versionRetryInterval is how long after a failed version check the next
+// one waits, so an unreachable instance is not checked on every fetch
+const versionRetryInterval = time.Minute
+
+// ErrUnsupported is returned for a feature the instance's openclaw release
+// does not support
+var ErrUnsupported = errors.New("not supported by this openclaw version")
+
+// errVersionUnknown is returned by Version while waiting to check again
+var errVersionUnknown = errors.New("openclaw version not known yet")
+
+// unsupported describes a capability missing from the detected release
+func (c *CLIAdapter) unsupported(capability Capability) error {
+	return fmt.Errorf("%s: %w (%s, needs %s)", capability, ErrUnsupported,
+		c.CachedVersion(), capabilityMinVersions[capability])
+}
+
+// Version runs `openclaw --version` the first time it is called and returns
+// the release it reports, such as "2026.1.29". Once known, the version is
+// cached for the adapter's life; a failed check is repeated at most every
+// versionRetryInterval.
+func (c *CLIAdapter) Version() (string, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+	if version := c.CachedVersion(); version != "" {
+		return version, nil
+	}
+	if time.Since(c.versionFailed) < versionRetryInterval {
+		return "", errVersionUnknown
+	}
+
+	output, err := c.runCommand(SectionVersion.args()...)
+	if err != nil {
+		c.versionFailed = time.Now()
+		return "", fmt.Errorf("version check failed: %w", err)
+	}
+	version := parseVersion(output)
+	if version == "" {
+		c.versionFailed = time.Now()
+		return "", parseError("version", fmt.Errorf("unexpected output %q", truncateOutput(output)))
+	}
+	c.mu.Lock()
+	c.version = version
+	c.mu.Unlock()
+	return version, nil
+}
+
+// CachedVersion returns the detected openclaw release without running
+// anything, or "" if it is not known yet
+func (c *CLIAdapter) CachedVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.version
+}
+
+// Supports reports whether the instance's openclaw release has capability,
+// detecting the release first if needed. When the release cannot be told,
+// every capability is assumed and failures are handled as they come.
+func (c *CLIAdapter) Supports(capability Capability) bool {
+	version, err := c.Version()
+	if err != nil {
+		return true
+	}
+	return compareVersions(version, capabilityMinVersions[capability]) >= 0
+}
+
+// Missing returns the capabilities the detected release lacks, or nil if it
+// is not known yet
+func (c *CLIAdapter) Missing() []Capability {
+	version := c.CachedVersion()
+	if version == "" {
+		return nil
+	}
+	var missing []Capability
+	for _, capability := range Capabilities {
+		if compareVersions(version, capabilityMinVersions[capability]) < 0 {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
+}
+
+// parseVersion finds the version in `openclaw --version` output, which may
+// be bare ("2026.1.29") or prefixed ("openclaw v2026.1.29 (abc123)")
+func parseVersion(output string) string {
+	for _, field := range strings.Fields(output) {
+		field = strings.TrimPrefix(field, "v")
+		if field != "" && field[0] >= '0' && field[0] <= '9' && strings.Contains(field, ".") {
+			return field
+		}
+	}
+	return ""
+}
+
+// compareVersions compares dotted numeric versions, ignoring any prerelease
+// or build suffix, and returns -1, 0 or 1. Missing components count as 0.
+func compareVersions(a, b string) int {
+	as, bs := versionParts(a), versionParts(b)
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	if i := strings.IndexAny(version, "-+"); i != -1 {
+		version = version[:i]
+	}
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(part)
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// truncateOutput shortens command output quoted in an error
+func truncateOutput(output string) string {
+	if len(output) > 80 {
+		return output[:80] + "..."
+	}
+	return output
+}
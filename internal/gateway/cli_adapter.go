@@ -2,10 +2,15 @@ package gateway
 
 import (
 	"bufio"
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -22,18 +27,119 @@ type CLIAdapter struct {
 	// SSH configuration for remote instances
 	SSHConfig *models.SSHConfig
 
+	// kubectl configuration for instances running in a Kubernetes pod
+	K8sConfig *models.K8sConfig
+
 	// Instance name for display
 	InstanceName string
 
+	// MaxRecentSessions caps the recent sessions decoded from status, per
+	// list (0 = DefaultMaxRecentSessions)
+	MaxRecentSessions int
+
+	// Timeout bounds each command, excluding time queued for a worker slot
+	// (0 = no limit). Commands that exceed it fail with a *TimeoutError.
+	Timeout time.Duration
+
+	// Timeouts overrides Timeout for particular openclaw commands, by
+	// command name such as "status" or "health" (0 = no limit)
+	Timeouts map[string]time.Duration
+
+	// Retry sets how status and health fetches failing on a transient
+	// connection error are retried (zero value = no retries)
+	Retry RetryPolicy
+
+	// OnRetry, if set, is called as each retry begins
+	OnRetry func(RetryState)
+
+	// KnownHostsFile is the lazyclaw-managed known_hosts file SSH host keys
+	// are checked against and written to, before the user's own
+	KnownHostsFile string
+
+	// Breaker sets when remote commands are rejected for an instance that
+	// keeps failing (zero value = never)
+	Breaker BreakerPolicy
+
+	// Escalation sets how service and process control gain elevated
+	// privileges (nil = they run as the connecting user)
+	Escalation *models.EscalationConfig
+
+	// RecordDir, if set, receives the raw output of each successful command
+	// and of the log stream, as fixtures a ReplayAdapter serves back
+	RecordDir string
+
+	// Scopes are the operator scopes granted to the instance; write
+	// commands are refused without operator.write (nil = no restriction)
+	Scopes []string
+
+	// Env holds variables exported before openclaw runs, locally or in the
+	// remote shell. Values may refer to other variables as $NAME, expanded
+	// where openclaw runs.
+	Env map[string]string
+
+	// Socket, if set, is the local gateway's control socket. Status, health
+	// and logs are fetched through it rather than by starting the CLI,
+	// which is used again whenever the socket fails.
+	Socket *SocketAdapter
+
+	// GRPC, if set, is the gateway's gRPC control API. Status, health and
+	// logs are fetched through it, after the socket and before the CLI, and
+	// it pushes health updates to FollowHealth.
+	GRPC *GRPCAdapter
+
+	// OnCommand, if set, is called with each command run against the
+	// instance once it finishes, for the audit log
+	OnCommand func(CommandRecord)
+
+	// OnConnect, if set, is called as a status fetch reaches each stage
+	// while the adapter has no status yet or its last fetch failed
+	OnConnect func(ConnectProgress)
+
 	// Cached status
 	mu          sync.RWMutex
 	lastStatus  *models.OpenClawStatus
 	lastFetched time.Time
 	lastError   error
 
-	// For log following
-	logCmd    *exec.Cmd
-	logCancel context.CancelFunc
+	// Set once the gateway has rejected `status --exclude`
+	excludeUnsupported bool
+
+	// Commands whose output a pending Prefetch will deliver, by batchKey
+	batched map[string]*batchCall
+
+	// Gateway pod last resolved from K8sConfig.Selector
+	pod string
+
+	// Fetches being retried, by command name
+	retrying map[string]*RetryState
+
+	// Circuit breaker state, see Breaker
+	circuit breaker
+
+	// Latest status fetches, see Metrics
+	metrics fetchMetrics
+
+	// Context of the commands in flight, cancelled by Interrupt
+	work     context.Context
+	stopWork context.CancelFunc
+
+	// lazyclaw's own ssh-agent, once UnlockKey has added the identity to it
+	agentSocket string
+
+	// Serializes and coalesces status, health and channels fetches
+	requests requestQueue
+
+	// openclaw release reported by --version, once detected; versionMu
+	// makes concurrent callers of Version wait for a single check
+	version       string
+	versionMu     sync.Mutex
+	versionFailed time.Time // When detection last failed
+
+	// Where a DryRun adapter records its commands instead of running them
+	dryRun *[]string
+
+	// Passed the output of write commands, see WatchOutput
+	watch func(string)
 }
 
 // NewCLIAdapter creates a new CLI adapter for local execution
@@ -52,9 +158,29 @@ func NewSSHCLIAdapter(name string, sshConfig *models.SSHConfig, openclawPath str
 	}
 }
 
-// IsRemote returns true if this adapter connects via SSH
+// IsRemote returns true if this adapter runs commands on another host, via
+// SSH or kubectl exec
 func (c *CLIAdapter) IsRemote() bool {
-	return c.SSHConfig != nil && c.SSHConfig.Host != ""
+	return c.SSHConfig != nil && c.SSHConfig.Host != "" || c.isK8s()
+}
+
+// Mode returns how this adapter reaches its gateway
+func (c *CLIAdapter) Mode() models.ConnectionMode {
+	switch {
+	case c.isK8s():
+		return models.ConnectionModeK8s
+	case c.IsRemote():
+		return models.ConnectionModeSSH
+	}
+	return models.ConnectionModeLocal
+}
+
+// transport names the program remote commands run through, for errors
+func (c *CLIAdapter) transport() string {
+	if c.isK8s() {
+		return "kubectl"
+	}
+	return "SSH"
 }
 
 // GetInstanceName returns the instance name
@@ -62,6 +188,16 @@ func (c *CLIAdapter) GetInstanceName() string {
 	return c.InstanceName
 }
 
+// Close closes the gRPC adapter, if the instance has one. Commands are
+// started per call and log streams end with their context, so there is
+// nothing else to close; Shutdown terminates whatever is still running.
+func (c *CLIAdapter) Close() error {
+	if c.GRPC != nil {
+		return c.GRPC.Close()
+	}
+	return nil
+}
+
 // GetLastError returns the last error encountered
 func (c *CLIAdapter) GetLastError() error {
 	c.mu.RLock()
@@ -69,33 +205,120 @@ func (c *CLIAdapter) GetLastError() error {
 	return c.lastError
 }
 
+// Status sections that are expensive for the gateway to compute and can be
+// left out of a status fetch
+const (
+	StatusSectionSecurityAudit = "securityAudit"
+	StatusSectionMemory        = "memory"
+	StatusSectionAgents        = "agents"
+)
+
 // GetFullStatus runs `openclaw status --json` and returns the full status
 func (c *CLIAdapter) GetFullStatus() (*models.OpenClawStatus, error) {
-	output, err := c.runCommand("status", "--json")
+	return c.GetStatus(nil)
+}
+
+// GetStatus runs `openclaw status --json`, asking the gateway to leave out the
+// given sections. Gateways too old to support --exclude get the full status
+// request instead, and are not asked again. A call made while the same
+// fetch is pending shares its result.
+func (c *CLIAdapter) GetStatus(exclude []string) (*models.OpenClawStatus, error) {
+	c.mu.RLock()
+	if c.excludeUnsupported {
+		exclude = nil
+	}
+	c.mu.RUnlock()
+	if len(exclude) > 0 && !c.Supports(CapStatusExclude) {
+		exclude = nil
+	}
+
+	status, err := c.queued(statusArgs(exclude), func() (any, error) {
+		return c.getStatus(exclude)
+	})
 	if err != nil {
-		c.mu.Lock()
-		c.lastError = err
-		c.mu.Unlock()
 		return nil, err
 	}
+	return status.(*models.OpenClawStatus), nil
+}
 
-	var status models.OpenClawStatus
-	if err := json.Unmarshal([]byte(output), &status); err != nil {
-		parseErr := fmt.Errorf("failed to parse status JSON: %w", err)
+func (c *CLIAdapter) getStatus(exclude []string) (status *models.OpenClawStatus, err error) {
+	via := "socket"
+	work := c.workContext()
+	defer func(start time.Time) {
+		// An interrupted fetch says nothing about the instance
+		if errors.Is(err, ErrInterrupted) {
+			return
+		}
+		c.recordFetch(time.Since(start), via, status, err)
+	}(time.Now())
+	if c.connecting() {
+		c.reportConnect(ConnectStarting, nil)
+		defer func() {
+			if err != nil {
+				c.reportConnect(ConnectFailed, err)
+			}
+		}()
+	}
+	if c.Socket != nil {
+		status, err = c.Socket.getStatus(work, exclude, c.maxRecentSessions())
+	}
+	if c.GRPC != nil && (c.Socket == nil || err != nil) {
+		via = "grpc"
+		status, err = c.GRPC.getStatus(work, exclude, c.maxRecentSessions())
+	}
+	if (c.Socket == nil && c.GRPC == nil) || err != nil {
+		via = "cli"
+		err = c.withRetry("status", func() (err error) {
+			status, err = c.fetchStatus(exclude)
+			return err
+		})
+	}
+	if err != nil && work.Err() != nil && shutdownCtx.Err() == nil {
+		return nil, ErrInterrupted
+	}
+	if err != nil && len(exclude) > 0 && !errors.Is(err, ErrTimeout) && !retryable(err) {
+		if status, err = c.fetchStatus(nil); err == nil {
+			c.mu.Lock()
+			c.excludeUnsupported = true
+			c.mu.Unlock()
+		}
+	}
+	if err != nil {
 		c.mu.Lock()
-		c.lastError = parseErr
+		c.lastError = err
 		c.mu.Unlock()
-		return nil, parseErr
+		return nil, err
 	}
 
 	// Cache the result
 	c.mu.Lock()
-	c.lastStatus = &status
+	c.lastStatus = status
 	c.lastFetched = time.Now()
 	c.lastError = nil
 	c.mu.Unlock()
 
-	return &status, nil
+	return status, nil
+}
+
+func statusArgs(exclude []string) []string {
+	args := []string{"status", "--json"}
+	if len(exclude) > 0 {
+		args = append(args, "--exclude", strings.Join(exclude, ","))
+	}
+	return args
+}
+
+func (c *CLIAdapter) fetchStatus(exclude []string) (*models.OpenClawStatus, error) {
+	var status *models.OpenClawStatus
+	err := c.streamCommand(func(r io.Reader) error {
+		decoded, err := decodeStatus(r, c.maxRecentSessions())
+		if err != nil {
+			return parseError("status", err)
+		}
+		status = decoded
+		return nil
+	}, statusArgs(exclude)...)
+	return status, err
 }
 
 // GetCachedStatus returns the last fetched status without making a new request
@@ -125,44 +348,427 @@ func (c *CLIAdapter) IsGatewayReachable() bool {
 	return c.lastStatus.Gateway.Reachable
 }
 
-// GetHealthSnapshot runs `openclaw health --json` and returns the health check result
+// GetHealthSnapshot runs `openclaw health --json` and returns the health check
+// result. Releases without --json get plain `openclaw health`, whose output
+// is returned raw.
 func (c *CLIAdapter) GetHealthSnapshot() (*models.HealthCheckResult, error) {
-	output, err := c.runCommand("health", "--json")
+	args := SectionHealth.args()
+	// A pending prefetch of health --json is used as is: checking the version
+	// first would wait for the prefetch and then miss its output
+	if !c.prefetched(args) && !c.Supports(CapHealthJSON) {
+		args = []string{"health"}
+	}
+	result, err := c.queued(args, func() (any, error) {
+		return c.getHealthSnapshot(args)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.HealthCheckResult), nil
+}
+
+func (c *CLIAdapter) getHealthSnapshot(args []string) (*models.HealthCheckResult, error) {
+	if c.Socket != nil {
+		if result, err := c.Socket.getHealth(c.workContext()); err == nil {
+			return result, nil
+		}
+	}
+	if c.GRPC != nil {
+		if result, err := c.GRPC.getHealth(c.workContext()); err == nil {
+			return result, nil
+		}
+	}
+	var output string
+	err := c.withRetry("health", func() (err error) {
+		output, err = c.runCommand(args...)
+		return err
+	})
+	if err != nil && len(args) > 1 && !c.Supports(CapHealthJSON) {
+		// Prefetched before the version was known
+		return c.getHealthSnapshot([]string{"health"})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("health check failed: %w", err)
 	}
+	return parseHealth(output), nil
+}
 
+// parseHealth decodes `openclaw health` output, keeping output that is not
+// JSON raw for fallback display
+func parseHealth(output string) *models.HealthCheckResult {
 	var result models.HealthCheckResult
 	if err := json.Unmarshal([]byte(output), &result); err != nil {
-		// If JSON parsing fails, store the raw output for fallback display
 		return &models.HealthCheckResult{
 			Overall: "unknown",
 			Raw:     output,
-		}, nil
+		}
+	}
+	return &result
+}
+
+// GetChannels runs `openclaw channels --json` and returns structured per-channel data
+func (c *CLIAdapter) GetChannels() (*models.ChannelsList, error) {
+	channels, err := c.queued(SectionChannels.args(), func() (any, error) {
+		return c.getChannels()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return channels.(*models.ChannelsList), nil
+}
+
+func (c *CLIAdapter) getChannels() (*models.ChannelsList, error) {
+	output, err := c.runCommand("channels", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("channels fetch failed: %w", err)
+	}
+
+	var channels models.ChannelsList
+	if err := json.Unmarshal([]byte(output), &channels); err != nil {
+		return nil, parseError("channels", err)
+	}
+
+	return &channels, nil
+}
+
+// GetChannelsStatus runs `openclaw channels status --json` and returns each
+// channel's live connection state
+func (c *CLIAdapter) GetChannelsStatus() (*models.ChannelsStatus, error) {
+	if !c.Supports(CapChannelsStatus) {
+		return nil, c.unsupported(CapChannelsStatus)
+	}
+	args := []string{"channels", "status", "--json"}
+	status, err := c.queued(args, func() (any, error) {
+		output, err := c.runCommand(args...)
+		if err != nil {
+			return nil, fmt.Errorf("channels status fetch failed: %w", err)
+		}
+		var status models.ChannelsStatus
+		if err := json.Unmarshal([]byte(output), &status); err != nil {
+			return nil, parseError("channels status", err)
+		}
+		return &status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status.(*models.ChannelsStatus), nil
+}
+
+// SearchMemory runs `openclaw memory search --json -- <query>` and returns
+// ranked results. The query follows --, so one starting with - is searched
+// for rather than parsed as an option.
+func (c *CLIAdapter) SearchMemory(query string) (*models.MemorySearchResult, error) {
+	output, err := c.runCommand("memory", "search", "--json", "--", query)
+	if err != nil {
+		return nil, fmt.Errorf("memory search failed: %w", err)
+	}
+
+	var result models.MemorySearchResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return nil, parseError("memory search", err)
+	}
+	if result.Query == "" {
+		result.Query = query
+	}
+
+	return &result, nil
+}
+
+// ListMemoryFiles runs `openclaw memory files --json` and returns the indexed files
+func (c *CLIAdapter) ListMemoryFiles() (*models.MemoryFilesList, error) {
+	output, err := c.runCommand("memory", "files", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("memory files fetch failed: %w", err)
+	}
+
+	var files models.MemoryFilesList
+	if err := json.Unmarshal([]byte(output), &files); err != nil {
+		return nil, parseError("memory files", err)
+	}
+
+	return &files, nil
+}
+
+// GetMemoryIndexStatus runs `openclaw memory status --json` to report indexing progress
+func (c *CLIAdapter) GetMemoryIndexStatus() (*models.MemoryIndexStatus, error) {
+	output, err := c.runCommand("memory", "status", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("memory status failed: %w", err)
+	}
+
+	var status models.MemoryIndexStatus
+	if err := json.Unmarshal([]byte(output), &status); err != nil {
+		return nil, parseError("memory status", err)
+	}
+
+	return &status, nil
+}
+
+// ReindexMemory runs `openclaw memory index` and waits for it to finish,
+// bounded by Timeouts["memory index"] rather than the fetch Timeout
+func (c *CLIAdapter) ReindexMemory() error {
+	if _, err := c.runCommand("memory", "index"); err != nil {
+		return fmt.Errorf("reindex failed: %w", err)
+	}
+	return nil
+}
+
+// RunDoctor runs `openclaw doctor --json` and returns its checks. Doctor can
+// take a while, so it is bounded by Timeouts["doctor"] rather than the fetch
+// Timeout.
+func (c *CLIAdapter) RunDoctor() (*models.DoctorReport, error) {
+	output, err := c.runCommand("doctor", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("doctor failed: %w", err)
+	}
+	var report models.DoctorReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, parseError("doctor", err)
+	}
+	return &report, nil
+}
+
+// ClearEmbeddingCache runs `openclaw memory cache clear`, which drops the
+// cached embeddings of the memory index
+func (c *CLIAdapter) ClearEmbeddingCache() error {
+	if _, err := c.runCommand("memory", "cache", "clear"); err != nil {
+		return fmt.Errorf("cache clear failed: %w", err)
+	}
+	return nil
+}
+
+// GetGatewayConfig runs `openclaw config show --json` and returns the
+// gateway's configuration as decoded JSON
+func (c *CLIAdapter) GetGatewayConfig() (map[string]interface{}, error) {
+	output, err := c.runCommand("config", "show", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("config fetch failed: %w", err)
+	}
+
+	var cfg map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &cfg); err != nil {
+		return nil, parseError("config", err)
+	}
+
+	return cfg, nil
+}
+
+// SetGatewayConfig runs `openclaw config set <key> <value>`
+func (c *CLIAdapter) SetGatewayConfig(key, value string) error {
+	if _, err := c.runCommand("config", "set", key, value); err != nil {
+		return fmt.Errorf("config set failed: %w", err)
+	}
+	return nil
+}
+
+// SetHeartbeatEnabled runs `openclaw heartbeat enable|disable --agent <id>`
+func (c *CLIAdapter) SetHeartbeatEnabled(agentID string, enabled bool) error {
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+	if _, err := c.runCommand("heartbeat", action, "--agent", agentID); err != nil {
+		return fmt.Errorf("heartbeat %s failed: %w", action, err)
+	}
+	return nil
+}
+
+// SetHeartbeatInterval runs `openclaw heartbeat set --agent <id> --every <interval>`
+func (c *CLIAdapter) SetHeartbeatInterval(agentID, every string) error {
+	if _, err := c.runCommand("heartbeat", "set", "--agent", agentID, "--every", every); err != nil {
+		return fmt.Errorf("heartbeat interval change failed: %w", err)
+	}
+	return nil
+}
+
+// SetHeartbeatDefaultAgent runs `openclaw heartbeat default --agent <id>`
+func (c *CLIAdapter) SetHeartbeatDefaultAgent(agentID string) error {
+	if _, err := c.runCommand("heartbeat", "default", "--agent", agentID); err != nil {
+		return fmt.Errorf("heartbeat default change failed: %w", err)
+	}
+	return nil
+}
+
+// CreateAgent runs `openclaw agents add <name> --non-interactive`, adding an
+// agent with its own workspace. An empty workspace or model leaves openclaw
+// to choose it.
+func (c *CLIAdapter) CreateAgent(name, workspace, model string) error {
+	args := []string{"agents", "add", name, "--non-interactive"}
+	if workspace != "" {
+		args = append(args, "--workspace", workspace)
 	}
+	if model != "" {
+		args = append(args, "--model", model)
+	}
+	if _, err := c.runCommand(args...); err != nil {
+		return fmt.Errorf("agent creation failed: %w", err)
+	}
+	return nil
+}
+
+// BootstrapAgent runs `openclaw agents bootstrap --agent <id>`, setting up
+// the workspace of an agent pending bootstrap
+func (c *CLIAdapter) BootstrapAgent(agentID string) error {
+	if _, err := c.runCommand("agents", "bootstrap", "--agent", agentID); err != nil {
+		return fmt.Errorf("agent bootstrap failed: %w", err)
+	}
+	return nil
+}
+
+// GetChangelog runs `openclaw update changelog --since <version>` and returns
+// the release notes for versions newer than the given one
+func (c *CLIAdapter) GetChangelog(since string) (string, error) {
+	output, err := c.runCommand("update", "changelog", "--since", since)
+	if err != nil {
+		return "", fmt.Errorf("changelog fetch failed: %w", err)
+	}
+	return output, nil
+}
+
+// RunUpdate runs `openclaw update --yes`
+func (c *CLIAdapter) RunUpdate() error {
+	args := []string{"update", "--yes"}
+	if err := c.runLive(c.workContext(), args, false, c.commandTimeout(args), c.watcher(AccessWrite)); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+	return nil
+}
+
+// SetUpdateChannel runs `openclaw update channel <channel>`, switching the
+// release channel (e.g. "beta") the gateway updates from
+func (c *CLIAdapter) SetUpdateChannel(channel string) error {
+	if _, err := c.runCommand("update", "channel", channel); err != nil {
+		return fmt.Errorf("update channel switch failed: %w", err)
+	}
+	return nil
+}
+
+// ControlService runs `openclaw <service> <action>`, e.g. `openclaw node restart`,
+// and returns its output. service is "gateway" or "node"; action is "start",
+// "stop", "restart", or "enable" or "disable" to set whether the service
+// starts at boot. It runs under the instance's escalation, if set.
+func (c *CLIAdapter) ControlService(service, action string) (string, error) {
+	output, err := c.runEscalated(service, action)
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w", service, action, err)
+	}
+	return output, nil
+}
+
+// ControlChannel runs `openclaw channels <action> --channel <id>` and returns
+// its output. action is "logout", which unlinks the channel until it is paired
+// again, or "restart", which drops and re-opens its connection.
+func (c *CLIAdapter) ControlChannel(id, action string) (string, error) {
+	output, err := c.runCommand("channels", action, "--channel", id)
+	if err != nil {
+		return "", fmt.Errorf("channel %s %s failed: %w", id, action, err)
+	}
+	return output, nil
+}
+
+// SendMessage runs `openclaw message send` to send text to target, e.g. a
+// phone number or chat ID, through the channel with the given ID. It returns
+// once the gateway has delivered the message or given up.
+func (c *CLIAdapter) SendMessage(channel, target, text string) error {
+	if _, err := c.runCommand("message", "send", "--channel", channel, "--target", target, "--message", text); err != nil {
+		return fmt.Errorf("message send failed: %w", err)
+	}
+	return nil
+}
+
+// ControlSession runs `openclaw sessions <action> <key> --agent <id>`. action
+// is "kill", which aborts the session's current run and closes it, or
+// "reset", which clears its conversation history.
+func (c *CLIAdapter) ControlSession(agentID, key, action string) error {
+	if _, err := c.runCommand("sessions", action, key, "--agent", agentID); err != nil {
+		return fmt.Errorf("session %s failed: %w", action, err)
+	}
+	return nil
+}
 
+// CompactSession runs `openclaw sessions compact <key> --agent <id> --json`,
+// which has the gateway summarize the session's history to free context, and
+// returns the token counts before and after
+func (c *CLIAdapter) CompactSession(agentID, key string) (*models.SessionCompaction, error) {
+	output, err := c.runCommand("sessions", "compact", key, "--agent", agentID, "--json")
+	if err != nil {
+		return nil, fmt.Errorf("session compact failed: %w", err)
+	}
+	var result models.SessionCompaction
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return nil, parseError("sessions compact", err)
+	}
 	return &result, nil
 }
 
+// TestWebhook runs `openclaw webhooks test <id>`, which sends a test delivery,
+// and returns its output
+func (c *CLIAdapter) TestWebhook(id string) (string, error) {
+	output, err := c.runCommand("webhooks", "test", id)
+	if err != nil {
+		return "", fmt.Errorf("webhook test failed: %w", err)
+	}
+	return output, nil
+}
+
+// TailLogs runs `openclaw logs --tail n` and returns the last n log lines,
+// oldest first
+func (c *CLIAdapter) TailLogs(n int) ([]models.LogEvent, error) {
+	if !c.Supports(CapLogsTail) {
+		return nil, c.unsupported(CapLogsTail)
+	}
+	output, err := c.runCommand("logs", "--tail", strconv.Itoa(n))
+	if err != nil {
+		return nil, fmt.Errorf("log tail failed: %w", err)
+	}
+	var events []models.LogEvent
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			events = append(events, parseLogLine(line))
+		}
+	}
+	return events, nil
+}
+
 // FollowLogs runs `openclaw logs --follow` and streams log events via channel.
-// Supports both local and SSH execution.
+// Supports both local and SSH execution. The process runs until ctx is done
+// or it exits; logChan is closed once it has been reaped and all its output
+// read, so nothing outlives the stream.
 func (c *CLIAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEvent) error {
+	if c.Socket != nil {
+		if err := c.Socket.FollowLogs(ctx, logChan); err == nil {
+			return nil
+		}
+	}
+	if c.GRPC != nil {
+		if err := c.GRPC.FollowLogs(ctx, logChan); err == nil {
+			return nil
+		}
+	}
+	if c.resilient() {
+		return c.followResilient(ctx, logChan)
+	}
+	return c.streamLogs(ctx, logsFollowArgs, logChan, nil)
+}
+
+// streamLogs runs the logs command args and streams its log events via
+// logChan, as FollowLogs does. exited, if set, is called with the command's
+// exit status just before logChan is closed.
+func (c *CLIAdapter) streamLogs(ctx context.Context, args []string, logChan chan<- models.LogEvent, exited func(error)) error {
 	// Create a cancellable context
 	ctx, cancel := context.WithCancel(ctx)
-	c.logCancel = cancel
 
 	var cmd *exec.Cmd
 	if c.IsRemote() {
-		// Build SSH command for remote log following
-		sshArgs := c.buildSSHArgs()
-		remoteCmd := fmt.Sprintf("%s logs --follow", c.getBinary())
-		remoteCmd = fmt.Sprintf("bash -lc %s", shellQuote(remoteCmd))
-		sshArgs = append(sshArgs, remoteCmd)
-		cmd = exec.CommandContext(ctx, "ssh", sshArgs...)
+		var err error
+		if cmd, err = c.remoteShellCommand(ctx, c.remoteCommand(args...)); err != nil {
+			cancel()
+			return err
+		}
 	} else {
-		cmd = exec.CommandContext(ctx, c.getBinary(), "logs", "--follow")
+		cmd = c.localCommand(ctx, args...)
 	}
-	c.logCmd = cmd
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -176,19 +782,30 @@ func (c *CLIAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEv
 		return fmt.Errorf("failed to get stderr pipe: %w", err)
 	}
 
+	start := time.Now()
 	if err := cmd.Start(); err != nil {
 		cancel()
+		c.auditCLI(args, start, err)
 		return fmt.Errorf("failed to start logs command: %w", err)
 	}
+	liveChildren.Add(1)
+	fixture := c.createLogFixture()
+
+	var readers sync.WaitGroup
+	readers.Add(2)
 
 	// Read stdout
 	go func() {
+		defer readers.Done()
 		scanner := bufio.NewScanner(stdout)
 		for scanner.Scan() {
 			line := scanner.Text()
 			if line == "" {
 				continue
 			}
+			if fixture != nil {
+				_, _ = fixture.WriteString(line + "\n")
+			}
 			event := parseLogLine(line)
 			select {
 			case logChan <- event:
@@ -200,6 +817,7 @@ func (c *CLIAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEv
 
 	// Read stderr (for errors)
 	go func() {
+		defer readers.Done()
 		scanner := bufio.NewScanner(stderr)
 		for scanner.Scan() {
 			line := scanner.Text()
@@ -209,7 +827,7 @@ func (c *CLIAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEv
 			event := models.LogEvent{
 				Timestamp: time.Now(),
 				Level:     "error",
-				Source:    "openclaw-cli",
+				Source:    stderrSource,
 				Message:   line,
 				Raw:       line,
 			}
@@ -221,21 +839,26 @@ func (c *CLIAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEv
 		}
 	}()
 
-	// Wait for command to finish in background
+	// Reap the command once its output is consumed (or abandoned because ctx
+	// is done, which also terminates it)
 	go func() {
-		_ = cmd.Wait()
+		readers.Wait()
+		err := cmd.Wait()
+		c.auditCLI(args, start, err)
+		liveChildren.Add(-1)
+		cancel()
+		if fixture != nil {
+			_ = fixture.Close()
+		}
+		if exited != nil {
+			exited(err)
+		}
+		close(logChan)
 	}()
 
 	return nil
 }
 
-// StopFollowingLogs stops the log following process
-func (c *CLIAdapter) StopFollowingLogs() {
-	if c.logCancel != nil {
-		c.logCancel()
-	}
-}
-
 // parseLogLine attempts to parse a log line into structured form
 // Format varies but often: "2024-01-15 10:30:45 [INFO] message"
 func parseLogLine(line string) models.LogEvent {
@@ -296,24 +919,141 @@ func parseLogLine(line string) models.LogEvent {
 }
 
 // runCommand executes an openclaw CLI command (locally or via SSH)
-func (c *CLIAdapter) runCommand(args ...string) (string, error) {
+func (c *CLIAdapter) runCommand(args ...string) (out string, err error) {
+	defer func(start time.Time) {
+		recordCommand(args, time.Since(start), err)
+		c.auditCLI(args, start, err)
+	}(time.Now())
+	defer func() {
+		if err == nil {
+			c.saveFixture(args, []byte(out))
+		}
+	}()
+	if err := c.authorize(args); err != nil {
+		return "", err
+	}
+	if batched, found, err := c.awaitBatched(args); found {
+		return string(batched), err
+	}
 	if c.IsRemote() {
 		return c.runSSHCommand(args...)
 	}
 	return c.runLocalCommand(args...)
 }
 
+// streamCommand runs an openclaw CLI command and hands its output to decode
+// as it is produced, so large payloads are never buffered whole. If decode
+// fails, the rest of the output is discarded so the command can exit.
+func (c *CLIAdapter) streamCommand(decode func(io.Reader) error, args ...string) (err error) {
+	defer func(start time.Time) {
+		recordCommand(args, time.Since(start), err)
+		c.auditCLI(args, start, err)
+	}(time.Now())
+	if err := c.authorize(args); err != nil {
+		return err
+	}
+	if c.RecordDir != "" {
+		decode = c.recordingDecoder(args, decode)
+	}
+	if batched, found, err := c.awaitBatched(args); found {
+		if err != nil {
+			return err
+		}
+		return decode(bytes.NewReader(batched))
+	}
+	if err := c.admit(); err != nil {
+		return err
+	}
+	err = c.streamOnce(decode, args...)
+	if errors.Is(err, errPodGone) {
+		// The gateway pod was replaced; run again in the new one
+		err = c.streamOnce(decode, args...)
+	}
+	c.noteOutcome(err)
+	return err
+}
+
+func (c *CLIAdapter) streamOnce(decode func(io.Reader) error, args ...string) error {
+	d := newDeadline(c.workContext(), commandName(args), c.commandTimeout(args))
+	var cmd *exec.Cmd
+	if c.IsRemote() {
+		var err error
+		if cmd, err = c.remoteShellCommand(d.ctx, c.remoteCommand(args...)); err != nil {
+			d.cancel()
+			return err
+		}
+	} else {
+		cmd = c.localCommand(d.ctx, args...)
+	}
+	var stderr interface{ Bytes() []byte }
+	if commandName(args) == "status" {
+		if w := c.watchConnect(cmd); w != nil {
+			stderr = w
+		}
+	}
+	if stderr == nil {
+		buf := new(bytes.Buffer)
+		cmd.Stderr = buf
+		stderr = buf
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		d.cancel()
+		return err
+	}
+
+	var decodeErr, waitErr error
+	runChild(func() {
+		d.start()
+		if waitErr = cmd.Start(); waitErr != nil {
+			return
+		}
+		if decodeErr = decode(stdout); decodeErr != nil {
+			_, _ = io.Copy(io.Discard, stdout)
+		}
+		waitErr = cmd.Wait()
+	})
+
+	if err := d.finish(); err != nil {
+		return err
+	}
+	if waitErr != nil {
+		if c.IsRemote() {
+			if exitErr, ok := waitErr.(*exec.ExitError); ok {
+				exitErr.Stderr = stderr.Bytes()
+			}
+			return c.remoteShellError(waitErr)
+		}
+		if _, ok := waitErr.(*exec.ExitError); !ok {
+			return commandFailure(waitErr, "", waitErr)
+		}
+		msg := strings.TrimSpace(string(stderr.Bytes()))
+		return commandFailure(waitErr, msg, fmt.Errorf("command failed: %s", msg))
+	}
+	return decodeErr
+}
+
 // runLocalCommand executes openclaw locally
 func (c *CLIAdapter) runLocalCommand(args ...string) (string, error) {
-	binary := c.getBinary()
-	cmd := exec.Command(binary, args...)
+	d := newDeadline(c.workContext(), commandName(args), c.commandTimeout(args))
+	cmd := c.localCommand(d.ctx, args...)
+	watch := c.watcher(Classify(args))
 
-	output, err := cmd.Output()
+	var output []byte
+	var err error
+	runChild(func() {
+		d.start()
+		output, err = captureOutput(cmd, false, watch)
+	})
+	if err := d.finish(); err != nil {
+		return "", err
+	}
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
-			return "", fmt.Errorf("command failed: %s", string(exitErr.Stderr))
+			msg := strings.TrimSpace(string(exitErr.Stderr))
+			return "", commandFailure(err, msg, fmt.Errorf("command failed: %s", exitErr.Stderr))
 		}
-		return "", err
+		return "", commandFailure(err, "", err)
 	}
 
 	return strings.TrimSpace(string(output)), nil
@@ -321,42 +1061,157 @@ func (c *CLIAdapter) runLocalCommand(args ...string) (string, error) {
 
 // runSSHCommand executes openclaw on a remote host via SSH
 func (c *CLIAdapter) runSSHCommand(args ...string) (string, error) {
-	sshArgs := c.buildSSHArgs()
+	return c.runRemoteScript(c.remoteCommand(args...), commandName(args), c.commandTimeout(args), c.watcher(Classify(args)))
+}
 
-	// Build the remote command
+// commandTimeout returns the timeout of the openclaw command run with args
+func (c *CLIAdapter) commandTimeout(args []string) time.Duration {
+	if timeout, ok := c.Timeouts[commandName(args)]; ok {
+		return timeout
+	}
+	return c.Timeout
+}
+
+// remoteCommand builds the remote shell command line for an openclaw call
+func (c *CLIAdapter) remoteCommand(args ...string) string {
 	remoteCmd := c.getBinary()
-	for _, arg := range args {
-		// Shell-escape arguments
-		if strings.Contains(arg, " ") || strings.Contains(arg, "'") || strings.Contains(arg, "\"") {
-			remoteCmd += " '" + strings.ReplaceAll(arg, "'", "'\\''") + "'"
-		} else {
-			remoteCmd += " " + arg
+	switch c.remoteShell() {
+	case models.RemoteShellPowerShell:
+		remoteCmd = "& " + c.quoteRemoteArg(remoteCmd)
+	case models.RemoteShellCmd:
+		if strings.Contains(remoteCmd, " ") {
+			remoteCmd = c.quoteRemoteArg(remoteCmd)
 		}
 	}
+	for _, arg := range args {
+		// Shell-escape arguments (user input such as search queries may
+		// contain any shell metacharacter)
+		remoteCmd += " " + c.quoteRemoteArg(arg)
+	}
+	return remoteCmd
+}
+
+// remoteShellCommand prepares an invocation running script on the remote
+// host, or in the gateway pod
+func (c *CLIAdapter) remoteShellCommand(ctx context.Context, script string) (*exec.Cmd, error) {
+	script = c.exportEnv(script)
+	if c.isK8s() {
+		return c.kubectlCommand(ctx, script)
+	}
+	return c.sshCommand(ctx, script), nil
+}
 
-	// Wrap in a login shell so the remote user's PATH (e.g. linuxbrew, nvm)
-	// is loaded. Non-interactive SSH doesn't source .bashrc/.profile.
-	remoteCmd = fmt.Sprintf("bash -lc %s", shellQuote(remoteCmd))
+// sshCommand prepares an ssh invocation running script on the remote host,
+// with the instance's ssh.client
+func (c *CLIAdapter) sshCommand(ctx context.Context, script string) *exec.Cmd {
+	sshArgs := c.buildSSHArgs()
+	sshArgs = append(sshArgs, c.wrapRemote(script))
 
-	sshArgs = append(sshArgs, remoteCmd)
+	if c.SSHConfig.Client == models.SSHClientNative {
+		return nativeSSHCommand(ctx, sshArgs)
+	}
+	return command(ctx, "ssh", sshArgs...)
+}
 
-	cmd := exec.Command("ssh", sshArgs...)
+// runRemoteShell executes a shell script on the remote host via SSH, or in
+// the gateway pod via kubectl exec. access is what the script does, for
+// WatchOutput.
+func (c *CLIAdapter) runRemoteShell(script string, access Access) (output string, err error) {
+	if !c.posixRemote() {
+		return "", c.needsPOSIXShell()
+	}
+	defer func(start time.Time) { c.audit(script, start, err) }(time.Now())
+	return c.runRemoteScript(script, "", c.Timeout, c.watcher(access))
+}
+
+// runRemoteScript runs script remotely, bounded by timeout. name is the
+// openclaw command it runs, if any, for timeout errors. Each line printed
+// is passed to onLine, if set.
+func (c *CLIAdapter) runRemoteScript(script, name string, timeout time.Duration, onLine func(string)) (string, error) {
+	if err := c.admit(); err != nil {
+		return "", err
+	}
+	output, err := c.runRemoteScriptOnce(script, name, timeout, onLine)
+	if errors.Is(err, errPodGone) {
+		// The gateway pod was replaced; run again in the new one
+		output, err = c.runRemoteScriptOnce(script, name, timeout, onLine)
+	}
+	c.noteOutcome(err)
+	return output, err
+}
 
-	output, err := cmd.Output()
+func (c *CLIAdapter) runRemoteScriptOnce(script, name string, timeout time.Duration, onLine func(string)) (string, error) {
+	d := newDeadline(c.workContext(), name, timeout)
+	cmd, err := c.remoteShellCommand(d.ctx, script)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			stderr := strings.TrimSpace(string(exitErr.Stderr))
-			if stderr != "" {
-				return "", fmt.Errorf("SSH command failed: %s", stderr)
-			}
-			return "", fmt.Errorf("SSH command failed with exit code %d", exitErr.ExitCode())
-		}
-		return "", fmt.Errorf("SSH connection failed: %w", err)
+		d.cancel()
+		return "", err
+	}
+
+	var output []byte
+	runChild(func() {
+		d.start()
+		output, err = captureOutput(cmd, false, onLine)
+	})
+	if err := d.finish(); err != nil {
+		return "", err
+	}
+	if err != nil {
+		return "", c.remoteShellError(err)
 	}
 
 	return strings.TrimSpace(string(output)), nil
 }
 
+// errPodGone marks a kubectl failure caused by the gateway pod having gone
+// away; the command may be retried once a new pod is resolved
+var errPodGone = errors.New("gateway pod is gone")
+
+// remoteShellError describes why a remote invocation failed. A kubectl
+// failure also drops the cached pod, in case it was restarted.
+func (c *CLIAdapter) remoteShellError(err error) error {
+	var stderr string
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		stderr = strings.TrimSpace(string(exitErr.Stderr))
+	}
+	if c.isK8s() {
+		c.forgetPod()
+		if podGone(stderr) {
+			return fmt.Errorf("%w: %s", errPodGone, stderr)
+		}
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		var failed error
+		if stderr != "" {
+			failed = fmt.Errorf("%s command failed: %s", c.transport(), stderr)
+		} else {
+			failed = fmt.Errorf("%s command failed with exit code %d", c.transport(), exitErr.ExitCode())
+		}
+		if kind := c.transportKind(exitErr.ExitCode(), stderr); kind != ErrorUnknown {
+			return &AdapterError{Kind: kind, ExitCode: exitErr.ExitCode(), Stderr: stderr, Err: failed}
+		}
+		return commandFailure(err, stderr, failed)
+	}
+	return fmt.Errorf("%s connection failed: %w", c.transport(), err)
+}
+
+// needsQuoting returns true if an argument contains anything other than
+// characters that are always safe unquoted in a POSIX shell
+func needsQuoting(arg string) bool {
+	if arg == "" {
+		return true
+	}
+	for _, r := range arg {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("-_./=:,@%+", r):
+		default:
+			return true
+		}
+	}
+	return false
+}
+
 // shellQuote wraps a string in single quotes for safe shell passing
 func shellQuote(s string) string {
 	return "'" + strings.ReplaceAll(s, "'", "'\\''") + "'"
@@ -373,8 +1228,11 @@ func (c *CLIAdapter) buildSSHArgs() []string {
 	// Batch mode - don't ask for passwords
 	args = append(args, "-o", "BatchMode=yes")
 
-	// Strict host key checking - disable for convenience (user can override)
-	args = append(args, "-o", "StrictHostKeyChecking=accept-new")
+	// Host key checking per the instance's host_key_policy
+	args = append(args, c.hostKeyArgs()...)
+
+	// Agent holding the instance's key, if not ssh's default
+	args = append(args, c.agentArgs()...)
 
 	// Connection timeout
 	timeout := c.SSHConfig.ConnectTimeout
@@ -383,6 +1241,24 @@ func (c *CLIAdapter) buildSSHArgs() []string {
 	}
 	args = append(args, "-o", fmt.Sprintf("ConnectTimeout=%d", timeout))
 
+	// Keepalives, so a flaky link drops a stalled stream rather than leaving
+	// it hanging, and compression for large outputs over slow links
+	aliveInterval, aliveCountMax := c.SSHConfig.ServerAliveInterval, c.SSHConfig.ServerAliveCountMax
+	if c.resilient() {
+		// Reconnecting needs the drop noticed
+		aliveInterval = cmp.Or(aliveInterval, resilientAliveInterval)
+		aliveCountMax = cmp.Or(aliveCountMax, resilientAliveCountMax)
+	}
+	if aliveInterval > 0 {
+		args = append(args, "-o", fmt.Sprintf("ServerAliveInterval=%d", aliveInterval))
+	}
+	if aliveCountMax > 0 {
+		args = append(args, "-o", fmt.Sprintf("ServerAliveCountMax=%d", aliveCountMax))
+	}
+	if c.SSHConfig.Compression {
+		args = append(args, "-o", "Compression=yes")
+	}
+
 	// Port
 	if c.SSHConfig.Port > 0 {
 		args = append(args, "-p", fmt.Sprintf("%d", c.SSHConfig.Port))
@@ -417,7 +1293,7 @@ func (c *CLIAdapter) getBinary() string {
 
 // CheckCLIAvailable checks if the openclaw CLI is available locally
 func CheckCLIAvailable() bool {
-	_, err := exec.LookPath("openclaw")
+	_, err := exec.LookPath(findLocalBinary())
 	return err == nil
 }
 
@@ -0,0 +1,163 @@
+package gateway
+
+import (
+	"bytes"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ConnectStage is a step of reaching an instance for its status
+type ConnectStage int
+
+const (
+	ConnectStarting       ConnectStage = iota // Fetch requested, nothing run yet
+	ConnectResolving                          // ssh resolving the host name
+	ConnectDialing                            // ssh opening the TCP connection
+	ConnectAuthenticating                     // ssh authenticating
+	ConnectRunning                            // openclaw status running
+	ConnectFailed                             // The fetch failed, see ConnectProgress.Err
+)
+
+func (s ConnectStage) String() string {
+	switch s {
+	case ConnectStarting:
+		return "connecting"
+	case ConnectResolving:
+		return "resolving host"
+	case ConnectDialing:
+		return "connecting to host"
+	case ConnectAuthenticating:
+		return "authenticating"
+	case ConnectRunning:
+		return "running status"
+	case ConnectFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// ConnectProgress reports a stage reached while an adapter that has no
+// status yet, or whose last fetch failed, fetches one
+type ConnectProgress struct {
+	Instance string
+	Stage    ConnectStage
+	Err      error // Set with ConnectFailed
+}
+
+// sshStageMarkers map lines of ssh -v output to the stage they begin
+var sshStageMarkers = []struct {
+	prefix string
+	stage  ConnectStage
+}{
+	{"debug1: Connecting to ", ConnectDialing},
+	{"debug1: Authenticating to ", ConnectAuthenticating},
+	{"debug1: Authentication succeeded", ConnectRunning},
+	{"Authenticated to ", ConnectRunning},
+}
+
+// sshVerbosePrefixes start the lines ssh -v adds to stderr, which are kept
+// out of error messages
+var sshVerbosePrefixes = []string{"debug1: ", "OpenSSH_", "Authenticated to ", "Transferred: ", "Bytes per second: "}
+
+// connecting returns true if status fetches report their progress: the
+// adapter has OnConnect set and no status yet, or its last fetch failed
+func (c *CLIAdapter) connecting() bool {
+	if c.OnConnect == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastStatus == nil || c.lastError != nil
+}
+
+// reportConnect reports a connect stage to OnConnect, if set
+func (c *CLIAdapter) reportConnect(stage ConnectStage, err error) {
+	if c.OnConnect != nil {
+		c.OnConnect(ConnectProgress{Instance: c.InstanceName, Stage: stage, Err: err})
+	}
+}
+
+// connectWatcher collects the stderr of a status invocation, reporting the
+// stages ssh -v logs and keeping the other lines for error messages
+type connectWatcher struct {
+	c       *CLIAdapter
+	mu      sync.Mutex
+	stage   ConnectStage
+	pending []byte
+	stderr  bytes.Buffer
+}
+
+// watchConnect makes cmd, which is about to fetch the status, report its
+// progress. ssh is run verbosely for its stages; anything else only reports
+// it is running. Returns nil if progress is not wanted; otherwise the
+// returned watcher is cmd's stderr.
+func (c *CLIAdapter) watchConnect(cmd *exec.Cmd) *connectWatcher {
+	if !c.connecting() {
+		return nil
+	}
+	w := &connectWatcher{c: c}
+	if !c.IsRemote() || c.isK8s() {
+		w.advance(ConnectRunning)
+	} else {
+		// -v must precede the destination
+		cmd.Args = slices.Insert(cmd.Args, 1, "-v")
+		w.advance(ConnectResolving)
+	}
+	cmd.Stderr = w
+	return w
+}
+
+// advance reports stage if it is past the current one
+func (w *connectWatcher) advance(stage ConnectStage) {
+	if stage <= w.stage {
+		return
+	}
+	w.stage = stage
+	w.c.reportConnect(stage, nil)
+}
+
+func (w *connectWatcher) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.line(string(w.pending[:i+1]))
+		w.pending = w.pending[i+1:]
+	}
+}
+
+// line handles one line of stderr
+func (w *connectWatcher) line(line string) {
+	text := strings.TrimRight(line, "\r\n")
+	for _, m := range sshStageMarkers {
+		if strings.HasPrefix(text, m.prefix) {
+			w.advance(m.stage)
+		}
+	}
+	if !containsPrefix(text, sshVerbosePrefixes) {
+		w.stderr.WriteString(line)
+	}
+}
+
+// Bytes returns the stderr written, without ssh's verbose lines
+func (w *connectWatcher) Bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append(slices.Clone(w.stderr.Bytes()), w.pending...)
+}
+
+// containsPrefix returns true if s starts with any of prefixes
+func containsPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
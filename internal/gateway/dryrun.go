@@ -0,0 +1,48 @@
+package gateway
+
+import (
+	"errors"
+	"strings"
+)
+
+// errDryRun stops the commands of a DryRun adapter before they run
+var errDryRun = errors.New("dry run")
+
+// DryRun returns the commands fn would run against the instance, without
+// running them. fn is handed an adapter for the same instance whose
+// commands are recorded, then fail; as a failed command ends most
+// operations, usually only the first is recorded.
+func (c *CLIAdapter) DryRun(fn func(*CLIAdapter) error) []string {
+	var commands []string
+	dry := &CLIAdapter{
+		BinaryPath:   c.BinaryPath,
+		SSHConfig:    c.SSHConfig,
+		K8sConfig:    c.K8sConfig,
+		InstanceName: c.InstanceName,
+		Escalation:   c.Escalation,
+		Env:          c.Env,
+		dryRun:       &commands,
+	}
+	defer dry.Interrupt()
+	_ = fn(dry)
+	return commands
+}
+
+// describeCommand renders args, as authorize receives them, as a command
+// line: openclaw's arguments, or the shell script or kill they stand for
+func describeCommand(args []string) string {
+	switch {
+	case len(args) == 3 && args[0] == "sh" && args[1] == "-c":
+		return args[2]
+	case len(args) > 0 && args[0] == "kill":
+		return strings.Join(args, " ")
+	}
+	words := []string{"openclaw"}
+	for _, arg := range args {
+		if needsQuoting(arg) {
+			arg = shellQuote(arg)
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// envNames returns the names of the adapter's Env variables, sorted so
+// commands are built the same way each time
+func (c *CLIAdapter) envNames() []string {
+	names := make([]string, 0, len(c.Env))
+	for name := range c.Env {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// environ returns the environment of local openclaw commands: lazyclaw's
+// own plus Env, with $NAME references expanded against it. It returns nil,
+// inheriting lazyclaw's environment, when Env is empty.
+func (c *CLIAdapter) environ() []string {
+	if len(c.Env) == 0 {
+		return nil
+	}
+	env := os.Environ()
+	for _, name := range c.envNames() {
+		value := os.Expand(c.Env[name], func(ref string) string {
+			// Later variables see earlier ones, as with shell exports
+			for i := len(env) - 1; i >= 0; i-- {
+				if k, v, ok := strings.Cut(env[i], "="); ok && k == ref {
+					return v
+				}
+			}
+			return ""
+		})
+		env = append(env, name+"="+value)
+	}
+	return env
+}
+
+// localCommand prepares a local openclaw invocation with Env applied
+func (c *CLIAdapter) localCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := command(ctx, c.localBinary(), args...)
+	cmd.Env = c.environ()
+	return cmd
+}
+
+// envRefPattern matches $NAME and ${NAME} references in Env values
+var envRefPattern = regexp.MustCompile(`\$(?:\{(\w+)\}|(\w+))`)
+
+// psEnvRefPattern matches the references of envRefPattern once every $ is
+// escaped for PowerShell
+var psEnvRefPattern = regexp.MustCompile("`" + `\$(?:\{(\w+)\}|(\w+))`)
+
+// exportEnv prefixes script with exports of Env in the remote shell's
+// syntax. $NAME references expand remotely; nothing else in a value is
+// interpreted.
+func (c *CLIAdapter) exportEnv(script string) string {
+	if len(c.Env) == 0 {
+		return script
+	}
+	var exports strings.Builder
+	for _, name := range c.envNames() {
+		value := c.Env[name]
+		switch c.remoteShell() {
+		case models.RemoteShellPowerShell:
+			// Every $ is escaped, then references are turned back into
+			// PowerShell's ${env:NAME}
+			value = strings.NewReplacer("`", "``", `"`, "`\"", "$", "`$").Replace(value)
+			value = psEnvRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+				return "${env:" + strings.Trim(ref, "`${}") + "}"
+			})
+			exports.WriteString("$env:" + name + ` = "` + value + `"; `)
+		case models.RemoteShellCmd:
+			value = envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+				return "%" + strings.Trim(ref, "${}") + "%"
+			})
+			exports.WriteString(`set "` + name + "=" + value + `" && `)
+		default:
+			value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", "$(", `\$(`).Replace(value)
+			exports.WriteString("export " + name + `="` + value + `"; `)
+		}
+	}
+	return exports.String() + script
+}
@@ -0,0 +1,152 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strings"
+)
+
+// ErrorKind classifies why a command against an instance failed
+type ErrorKind int
+
+const (
+	ErrorUnknown          ErrorKind = iota
+	ErrorBinaryNotFound             // openclaw is not installed, or not on PATH
+	ErrorSSHAuth                    // The SSH server rejected the user's key
+	ErrorHostKey                    // The SSH host key is unknown or has changed
+	ErrorConnection                 // The host or cluster could not be reached
+	ErrorTimeout                    // The command exceeded its timeout
+	ErrorJSONParse                  // openclaw printed something other than the expected JSON
+	ErrorGatewayDown                // openclaw ran but could not reach its gateway
+	ErrorCommand                    // openclaw ran and failed for another reason
+	ErrorEscalationDenied           // sudo or doas refused to run the command
+	ErrorScopeDenied                // The instance is not granted the scope the command needs
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorBinaryNotFound:
+		return "binary-not-found"
+	case ErrorSSHAuth:
+		return "ssh-auth"
+	case ErrorHostKey:
+		return "host-key"
+	case ErrorConnection:
+		return "connection"
+	case ErrorTimeout:
+		return "timeout"
+	case ErrorJSONParse:
+		return "json-parse"
+	case ErrorGatewayDown:
+		return "gateway-down"
+	case ErrorCommand:
+		return "command"
+	case ErrorEscalationDenied:
+		return "escalation-denied"
+	case ErrorScopeDenied:
+		return "scope-denied"
+	}
+	return "unknown"
+}
+
+// AdapterError is a failed command, classified so the UI can say what to do
+// about it. Its message is that of Err.
+type AdapterError struct {
+	Kind     ErrorKind
+	ExitCode int    // -1 if the command did not exit
+	Stderr   string // Trimmed
+	Err      error
+}
+
+func (e *AdapterError) Error() string { return e.Err.Error() }
+func (e *AdapterError) Unwrap() error { return e.Err }
+
+// KindOf classifies err. Timeouts and commands rejected by the circuit
+// breaker are classified even though they are not AdapterErrors.
+func KindOf(err error) ErrorKind {
+	var adapterErr *AdapterError
+	switch {
+	case err == nil:
+		return ErrorUnknown
+	case errors.Is(err, ErrTimeout):
+		return ErrorTimeout
+	case errors.Is(err, ErrUnreachable):
+		return ErrorConnection
+	case errors.As(err, &adapterErr):
+		return adapterErr.Kind
+	}
+	return ErrorUnknown
+}
+
+// parseError reports output of openclaw that is not the JSON expected, such
+// as from a version printing a different format
+func parseError(what string, err error) error {
+	return &AdapterError{Kind: ErrorJSONParse, ExitCode: -1, Err: fmt.Errorf("failed to parse %s JSON: %w", what, err)}
+}
+
+// gatewayDownMarkers are printed by openclaw when it runs but cannot reach
+// its gateway
+var gatewayDownMarkers = []string{"ECONNREFUSED", "gateway not running", "gateway unreachable", "Gateway is not running"}
+
+// commandFailure classifies the failure of an openclaw command, run locally
+// or through the remote shell. message describes it as before classification.
+func commandFailure(err error, stderr string, message error) *AdapterError {
+	e := &AdapterError{Kind: ErrorCommand, ExitCode: -1, Stderr: stderr, Err: message}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		e.Kind = ErrorBinaryNotFound
+	case errors.As(err, &exitErr):
+		e.ExitCode = exitErr.ExitCode()
+		if e.ExitCode == 127 || strings.Contains(stderr, "command not found") {
+			// The remote shell could not find openclaw
+			e.Kind = ErrorBinaryNotFound
+		} else if containsAny(stderr, gatewayDownMarkers) {
+			e.Kind = ErrorGatewayDown
+		}
+	}
+	return e
+}
+
+// sshAuthMarkers are printed by ssh when it reaches the host but is not let in
+var sshAuthMarkers = []string{"Permission denied", "Too many authentication failures"}
+
+// hostKeyMarkers are printed by ssh when it does not trust the host's key
+var hostKeyMarkers = []string{"Host key verification failed", "REMOTE HOST IDENTIFICATION HAS CHANGED"}
+
+// kubectlConnectionMarkers are printed by kubectl when it cannot reach the
+// cluster
+var kubectlConnectionMarkers = []string{"Unable to connect to the server", "connection refused", "i/o timeout", "TLS handshake timeout"}
+
+// transportKind classifies a failed remote invocation by the failure of ssh
+// or kubectl itself, or returns ErrorUnknown if that reached the host and
+// the failure is the command's. ssh exits 255 on its own errors.
+func (c *CLIAdapter) transportKind(exitCode int, stderr string) ErrorKind {
+	if c.isK8s() {
+		if containsAny(stderr, kubectlConnectionMarkers) {
+			return ErrorConnection
+		}
+		return ErrorUnknown
+	}
+	if exitCode != 255 {
+		return ErrorUnknown
+	}
+	if containsAny(stderr, hostKeyMarkers) {
+		return ErrorHostKey
+	}
+	if containsAny(stderr, sshAuthMarkers) {
+		return ErrorSSHAuth
+	}
+	return ErrorConnection
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// escalationDeniedMarkers are printed by sudo and doas when they will not run
+// a command, never prompting as they are run non-interactively
+var escalationDeniedMarkers = []string{
+	"a password is required",
+	"a terminal is required",
+	"is not in the sudoers file",
+	"is not allowed to",
+	"incorrect password attempt",
+	"no askpass program",
+	"Authentication failed",
+	"doas: Operation not permitted",
+	"sudo: not found", "sudo: command not found",
+	"doas: not found", "doas: command not found",
+}
+
+// escalationMethod returns the instance's escalation method, or "" if it has
+// none
+func (c *CLIAdapter) escalationMethod() string {
+	if c.Escalation == nil || c.Escalation.Method == models.EscalationNone {
+		return ""
+	}
+	return c.Escalation.Method
+}
+
+// escalationArgs returns the program and flags that run a command with
+// elevated privileges, or nil if the instance has no escalation set. They
+// never prompt: sudo uses the askpass program if one is set.
+func (c *CLIAdapter) escalationArgs() []string {
+	switch c.escalationMethod() {
+	case models.EscalationSudo:
+		if c.Escalation.Askpass != "" {
+			return []string{"sudo", "-A"}
+		}
+		return []string{"sudo", "-n"}
+	case models.EscalationDoas:
+		return []string{"doas", "-n"}
+	}
+	return nil
+}
+
+// escalate prefixes a remote shell command line with the escalation program
+func (c *CLIAdapter) escalate(script string) string {
+	args := c.escalationArgs()
+	if args == nil {
+		return script
+	}
+	prefix := strings.Join(args, " ") + " "
+	if args[0] == "sudo" && c.Escalation.Askpass != "" {
+		prefix = "SUDO_ASKPASS=" + shellQuote(c.Escalation.Askpass) + " " + prefix
+	}
+	return prefix + script
+}
+
+// escalatedCommand prepares a local command run through the escalation
+// program
+func (c *CLIAdapter) escalatedCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	escalation := c.escalationArgs()
+	if escalation == nil {
+		cmd := command(ctx, name, args...)
+		cmd.Env = c.environ()
+		return cmd
+	}
+	cmd := command(ctx, escalation[0], append(append(escalation[1:], name), args...)...)
+	cmd.Env = c.environ()
+	if escalation[0] == "sudo" && c.Escalation.Askpass != "" {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, "SUDO_ASKPASS="+c.Escalation.Askpass)
+	}
+	return cmd
+}
+
+// runEscalated runs an openclaw command with elevated privileges, locally
+// or remotely
+func (c *CLIAdapter) runEscalated(args ...string) (output string, err error) {
+	if err := c.authorize(args); err != nil {
+		return "", err
+	}
+	if c.escalationArgs() == nil {
+		return c.runCommand(args...)
+	}
+	defer func(start time.Time) {
+		c.audit(strings.Join(c.escalationArgs(), " ")+" openclaw "+strings.Join(args, " "), start, err)
+	}(time.Now())
+	if c.IsRemote() {
+		out, runErr := c.runRemoteScript(c.escalate(c.remoteCommand(args...)), commandName(args), c.commandTimeout(args), c.watcher(Classify(args)))
+		return out, c.escalationError(runErr)
+	}
+
+	d := newDeadline(c.workContext(), commandName(args), c.commandTimeout(args))
+	cmd := c.escalatedCommand(d.ctx, c.localBinary(), args...)
+	watch := c.watcher(Classify(args))
+	var out []byte
+	var runErr error
+	runChild(func() {
+		d.start()
+		out, runErr = captureOutput(cmd, false, watch)
+	})
+	if err := d.finish(); err != nil {
+		return "", err
+	}
+	if runErr != nil {
+		if exitErr, ok := runErr.(*exec.ExitError); ok {
+			msg := strings.TrimSpace(string(exitErr.Stderr))
+			return "", c.escalationError(commandFailure(runErr, msg, fmt.Errorf("command failed: %s", msg)))
+		}
+		return "", commandFailure(runErr, "", runErr)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// escalationError reclassifies a failure caused by sudo or doas refusing to
+// run the command, saying how to let it
+func (c *CLIAdapter) escalationError(err error) error {
+	var adapterErr *AdapterError
+	if !errors.As(err, &adapterErr) || !containsAny(adapterErr.Stderr, escalationDeniedMarkers) {
+		return err
+	}
+	method := c.escalationMethod()
+	hint := "allow the command without a password in " + method + "'s config"
+	if method == models.EscalationSudo && c.Escalation.Askpass == "" {
+		hint += ", or set escalation.askpass"
+	}
+	reason, _, _ := strings.Cut(adapterErr.Stderr, "\n")
+	return &AdapterError{
+		Kind:     ErrorEscalationDenied,
+		ExitCode: adapterErr.ExitCode,
+		Stderr:   adapterErr.Stderr,
+		Err:      fmt.Errorf("%s denied (%s): %s", method, reason, hint),
+	}
+}
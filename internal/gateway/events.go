@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// FollowEvents runs `openclaw events --follow --json` and streams typed gateway
+// events via eventChan. The channel is closed when the stream ends, e.g. when
+// the gateway does not support event subscriptions. Releases without the
+// command fail right away.
+func (c *CLIAdapter) FollowEvents(ctx context.Context, eventChan chan<- models.GatewayEvent) error {
+	if !c.Supports(CapEventsFollow) {
+		return c.unsupported(CapEventsFollow)
+	}
+	args := []string{"events", "--follow", "--json"}
+	var cmd *exec.Cmd
+	if c.IsRemote() {
+		var err error
+		if cmd, err = c.remoteShellCommand(ctx, c.remoteCommand(args...)); err != nil {
+			return err
+		}
+	} else {
+		cmd = c.localCommand(ctx, args...)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to get stdout pipe: %w", err)
+	}
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		c.auditCLI(args, start, err)
+		return fmt.Errorf("failed to start events command: %w", err)
+	}
+	liveChildren.Add(1)
+
+	go func() {
+		defer close(eventChan)
+		defer liveChildren.Add(-1)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var event models.GatewayEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				// Skip non-JSON lines (banners, warnings)
+				continue
+			}
+			normalizeEvent(&event)
+			select {
+			case eventChan <- event:
+			case <-ctx.Done():
+				c.auditCLI(args, start, cmd.Wait())
+				return
+			}
+		}
+		c.auditCLI(args, start, cmd.Wait())
+	}()
+
+	return nil
+}
+
+// normalizeEvent fills the channel and session of events that carry them in
+// their data only, as some releases do, and defaults the severity
+func normalizeEvent(event *models.GatewayEvent) {
+	if event.Channel == "" {
+		event.Channel = eventData(event, "channel", "channelId")
+	}
+	if event.Session == "" {
+		event.Session = eventData(event, "session", "sessionKey")
+	}
+	if event.Severity == "" {
+		event.Severity = "info"
+	}
+}
+
+// eventData returns the first of keys set to a string in the event's data
+func eventData(event *models.GatewayEvent, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := event.Data[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// MaxPreviewBytes limits how much of a file ReadFile returns
+const MaxPreviewBytes = 64 * 1024
+
+// ListDir lists a directory on the instance's host (locally or via SSH).
+// Directories sort before files.
+func (c *CLIAdapter) ListDir(path string) ([]models.FileEntry, error) {
+	var entries []models.FileEntry
+	var err error
+	if c.IsRemote() {
+		entries, err = c.listRemoteDir(path)
+	} else {
+		entries, err = listLocalDir(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].IsDir != entries[j].IsDir {
+			return entries[i].IsDir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+func listLocalDir(path string) ([]models.FileEntry, error) {
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.FileEntry, 0, len(dirEntries))
+	for _, de := range dirEntries {
+		entry := models.FileEntry{Name: de.Name(), IsDir: de.IsDir()}
+		if info, err := de.Info(); err == nil {
+			entry.Size = info.Size()
+			entry.ModTime = info.ModTime()
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// listRemoteDir uses GNU find's -printf, falling back to plain ls on hosts
+// without it (e.g. BSD/macOS), which only yields names and types
+func (c *CLIAdapter) listRemoteDir(path string) ([]models.FileEntry, error) {
+	quoted := shellQuote(path)
+	script := fmt.Sprintf(
+		"find %s -mindepth 1 -maxdepth 1 -printf '%%y\\t%%s\\t%%T@\\t%%f\\n' 2>/dev/null || ls -1Ap %s",
+		quoted, quoted)
+	output, err := c.runRemoteShell(script, AccessRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []models.FileEntry
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) == 4 {
+			entry := models.FileEntry{Name: fields[3], IsDir: fields[0] == "d"}
+			entry.Size, _ = strconv.ParseInt(fields[1], 10, 64)
+			if secs, err := strconv.ParseFloat(fields[2], 64); err == nil {
+				entry.ModTime = time.Unix(int64(secs), 0)
+			}
+			entries = append(entries, entry)
+			continue
+		}
+		// ls -p fallback: directories end in "/"
+		name := strings.TrimSuffix(line, "/")
+		entries = append(entries, models.FileEntry{Name: name, IsDir: strings.HasSuffix(line, "/")})
+	}
+	return entries, nil
+}
+
+// ReadFile returns up to MaxPreviewBytes of a text file on the instance's host.
+// Binary files are rejected.
+func (c *CLIAdapter) ReadFile(path string) (string, error) {
+	var data []byte
+	if c.IsRemote() {
+		output, err := c.runRemoteShell(fmt.Sprintf("head -c %d -- %s", MaxPreviewBytes, shellQuote(path)), AccessRead)
+		if err != nil {
+			return "", err
+		}
+		data = []byte(output)
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		data, err = io.ReadAll(io.LimitReader(f, MaxPreviewBytes))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if bytes.IndexByte(data, 0) != -1 {
+		return "", fmt.Errorf("binary file, preview not available")
+	}
+	return string(data), nil
+}
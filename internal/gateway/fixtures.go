@@ -0,0 +1,219 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// DefaultReplayLogInterval is the pause between log lines a ReplayAdapter
+// streams
+const DefaultReplayLogInterval = 500 * time.Millisecond
+
+// logsFollowArgs are the arguments of the log stream FollowLogs runs
+var logsFollowArgs = []string{"logs", "--follow"}
+
+// fixtureFileName returns a file name safe on any platform for s
+func fixtureFileName(s string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, s)
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// fixturePath returns where the output of the command run with args is
+// recorded in dir, e.g. "status_--json.out"
+func fixturePath(dir string, args []string) string {
+	return filepath.Join(dir, fixtureFileName(strings.Join(args, " "))+".out")
+}
+
+// FixtureDir returns the directory under root holding an instance's
+// fixtures
+func FixtureDir(root, instance string) string {
+	return filepath.Join(root, fixtureFileName(instance))
+}
+
+// saveFixture records the raw output of a successful command in RecordDir,
+// replacing any earlier recording of it. Recording is best effort: a fixture
+// that cannot be written never fails the command.
+func (c *CLIAdapter) saveFixture(args []string, output []byte) {
+	if c.RecordDir == "" {
+		return
+	}
+	if err := os.MkdirAll(c.RecordDir, 0700); err != nil {
+		return
+	}
+	// Outputs describe the user's setup, so fixtures are private
+	path := fixturePath(c.RecordDir, args)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, output, 0600); err != nil {
+		return
+	}
+	_ = os.Rename(tmpPath, path)
+}
+
+// recordingDecoder wraps decode so the output it reads is recorded once it
+// decodes successfully
+func (c *CLIAdapter) recordingDecoder(args []string, decode func(io.Reader) error) func(io.Reader) error {
+	return func(r io.Reader) error {
+		var output bytes.Buffer
+		if err := decode(io.TeeReader(r, &output)); err != nil {
+			return err
+		}
+		c.saveFixture(args, output.Bytes())
+		return nil
+	}
+}
+
+// createLogFixture starts a new recording of the log stream, or returns nil
+// if the adapter is not recording
+func (c *CLIAdapter) createLogFixture() *os.File {
+	if c.RecordDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.RecordDir, 0700); err != nil {
+		return nil
+	}
+	f, err := os.OpenFile(fixturePath(c.RecordDir, logsFollowArgs), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// ReplayAdapter serves a gateway from fixtures a CLIAdapter recorded with
+// RecordDir set (--replay), for offline demos and for checking the decoders
+// against real-world output
+type ReplayAdapter struct {
+	name string
+	dir  string
+
+	// LogInterval is the pause between streamed log lines
+	// (0 = DefaultReplayLogInterval)
+	LogInterval time.Duration
+}
+
+// NewReplayAdapter creates an adapter replaying the fixtures in dir
+func NewReplayAdapter(name, dir string) *ReplayAdapter {
+	return &ReplayAdapter{name: name, dir: dir}
+}
+
+// GetInstanceName returns the instance name
+func (r *ReplayAdapter) GetInstanceName() string {
+	return r.name
+}
+
+// IsRemote returns false; fixtures are read locally
+func (r *ReplayAdapter) IsRemote() bool {
+	return false
+}
+
+// Close does nothing; log streams end with their context
+func (r *ReplayAdapter) Close() error {
+	return nil
+}
+
+// fixture returns the first recorded output among the given commands. A
+// command given as a prefix ending in "*" matches any recorded command
+// starting with it, such as a status fetched with --exclude.
+func (r *ReplayAdapter) fixture(candidates ...[]string) ([]byte, error) {
+	for _, args := range candidates {
+		path := fixturePath(r.dir, args)
+		if n := len(args) - 1; args[n] == "*" {
+			prefix := fixtureFileName(strings.Join(args[:n], " ") + " ")
+			matches, _ := filepath.Glob(filepath.Join(r.dir, prefix+"*.out"))
+			if len(matches) == 0 {
+				continue
+			}
+			sort.Strings(matches)
+			path = matches[0]
+		}
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		return data, err
+	}
+	return nil, fmt.Errorf("no %q fixture in %s: %w", strings.Join(candidates[0], " "), r.dir, os.ErrNotExist)
+}
+
+// GetFullStatus decodes the recorded status, preferring a full one
+func (r *ReplayAdapter) GetFullStatus() (*models.OpenClawStatus, error) {
+	data, err := r.fixture(statusArgs(nil), []string{"status", "--json", "*"})
+	if err != nil {
+		return nil, err
+	}
+	status, err := decodeStatus(bytes.NewReader(data), DefaultMaxRecentSessions)
+	if err != nil {
+		return nil, parseError("status", err)
+	}
+	return status, nil
+}
+
+// GetHealthSnapshot decodes the recorded health check
+func (r *ReplayAdapter) GetHealthSnapshot() (*models.HealthCheckResult, error) {
+	data, err := r.fixture(SectionHealth.args(), []string{"health"})
+	if err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
+	}
+	return parseHealth(strings.TrimSpace(string(data))), nil
+}
+
+// FollowLogs streams the recorded log lines via logChan, one every
+// LogInterval and starting over after the last, until ctx is done
+func (r *ReplayAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEvent) error {
+	data, err := r.fixture(logsFollowArgs, []string{"logs", "--tail", "*"})
+	if err != nil {
+		return err
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("log fixture in %s is empty", r.dir)
+	}
+	interval := r.LogInterval
+	if interval <= 0 {
+		interval = DefaultReplayLogInterval
+	}
+
+	go func() {
+		defer close(logChan)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for i := 0; ; i = (i + 1) % len(lines) {
+			select {
+			case logChan <- parseLogLine(lines[i]):
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
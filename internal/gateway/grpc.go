@@ -0,0 +1,367 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// grpcService is the path prefix of the gateway's gRPC control API methods
+const grpcService = "/openclaw.gateway.v1.Gateway/"
+
+// grpcMaxMessage bounds a single message read from the gateway
+const grpcMaxMessage = 64 << 20
+
+// GRPCAdapter talks to a gateway over its gRPC control API. Messages use
+// gRPC's JSON codec (application/grpc+json) and carry the same payloads as
+// the gateway protocol, so no generated code is needed. Logs and health
+// updates are server streams the gateway pushes to.
+type GRPCAdapter struct {
+	// Address is the API's host:port
+	Address string
+
+	// Instance name for display
+	InstanceName string
+
+	// Timeout bounds each unary call (0 = no limit). Calls that exceed it
+	// fail with a *TimeoutError.
+	Timeout time.Duration
+
+	// MaxRecentSessions caps the recent sessions decoded from status, per
+	// list (0 = DefaultMaxRecentSessions)
+	MaxRecentSessions int
+
+	client *http.Client
+	scheme string
+}
+
+// GRPCError is a call the gateway answered with a non-OK gRPC status
+type GRPCError struct {
+	Method  string
+	Code    int
+	Message string
+}
+
+func (e *GRPCError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s failed: grpc status %d", e.Method, e.Code)
+	}
+	return fmt.Sprintf("%s failed: %s (grpc status %d)", e.Method, e.Message, e.Code)
+}
+
+// NewGRPCAdapter creates an adapter for the API cfg describes, loading its
+// certificates
+func NewGRPCAdapter(name string, cfg *models.GRPCConfig) (*GRPCAdapter, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("grpc.address is not set")
+	}
+	protocols := new(http.Protocols)
+	transport := &http.Transport{
+		Proxy:             http.ProxyFromEnvironment,
+		ForceAttemptHTTP2: true,
+		Protocols:         protocols,
+	}
+	scheme := "https"
+	if cfg.Plaintext {
+		protocols.SetUnencryptedHTTP2(true)
+		scheme = "http"
+	} else {
+		protocols.SetHTTP2(true)
+		tlsConfig, err := grpcTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &GRPCAdapter{
+		Address:      cfg.Address,
+		InstanceName: name,
+		client:       &http.Client{Transport: transport},
+		scheme:       scheme,
+	}, nil
+}
+
+// grpcTLSConfig builds the TLS settings of cfg
+func grpcTLSConfig(cfg *models.GRPCConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: cfg.ServerName, MinVersion: tls.VersionTLS12}
+	if cfg.CACert != "" {
+		pem, err := os.ReadFile(expandHome(cfg.CACert))
+		if err != nil {
+			return nil, fmt.Errorf("grpc.ca_cert: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("grpc.ca_cert: no certificates in %s", cfg.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.Cert != "" || cfg.Key != "" {
+		cert, err := tls.LoadX509KeyPair(expandHome(cfg.Cert), expandHome(cfg.Key))
+		if err != nil {
+			return nil, fmt.Errorf("grpc.cert/grpc.key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// GetInstanceName returns the instance name
+func (g *GRPCAdapter) GetInstanceName() string {
+	return g.InstanceName
+}
+
+// IsRemote returns true unless the address is a loopback one
+func (g *GRPCAdapter) IsRemote() bool {
+	host, _, err := net.SplitHostPort(g.Address)
+	if err != nil {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return host != "localhost" && (ip == nil || !ip.IsLoopback())
+}
+
+// Close releases idle connections
+func (g *GRPCAdapter) Close() error {
+	g.client.CloseIdleConnections()
+	return nil
+}
+
+// open starts a call of method with req as its only request message, and
+// returns the response once its headers arrived
+func (g *GRPCAdapter) open(ctx context.Context, method string, req any) (*http.Response, error) {
+	if req == nil {
+		req = struct{}{}
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	body := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(data)))
+	body = append(body, data...)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.scheme+"://"+g.Address+grpcService+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc+json")
+	httpReq.Header.Set("TE", "trailers")
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("grpc %s failed: %w", method, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("grpc %s failed: HTTP %s", method, resp.Status)
+	}
+	// A call failing outright answers with headers only
+	if err := grpcStatus(method, resp.Header); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// grpcStatus returns the call's error if h carries a non-OK status
+func grpcStatus(method string, h http.Header) error {
+	code := h.Get("Grpc-Status")
+	if code == "" || code == "0" {
+		return nil
+	}
+	n, _ := strconv.Atoi(code)
+	msg, err := url.PathUnescape(h.Get("Grpc-Message"))
+	if err != nil {
+		msg = h.Get("Grpc-Message")
+	}
+	return &GRPCError{Method: method, Code: n, Message: msg}
+}
+
+// readMessage reads the next length-prefixed message, or returns io.EOF at
+// the end of the stream
+func readMessage(r *bufio.Reader) (json.RawMessage, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated grpc message")
+		}
+		return nil, err
+	}
+	if prefix[0] != 0 {
+		return nil, errors.New("compressed grpc messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > grpcMaxMessage {
+		return nil, fmt.Errorf("grpc message of %d bytes is too large", size)
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, fmt.Errorf("truncated grpc message")
+	}
+	return msg, nil
+}
+
+// stream calls method and passes each response message to fn until the
+// stream ends, ctx is done, or fn returns false. The call's status is
+// returned once the stream ends.
+func (g *GRPCAdapter) stream(ctx context.Context, method string, req any, fn func(json.RawMessage) bool) error {
+	resp, err := g.open(ctx, method, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	r := bufio.NewReader(resp.Body)
+	for {
+		msg, err := readMessage(r)
+		if err == io.EOF {
+			return grpcStatus(method, resp.Trailer)
+		}
+		if err != nil {
+			return fmt.Errorf("grpc %s failed: %w", method, err)
+		}
+		if !fn(msg) {
+			return nil
+		}
+	}
+}
+
+// call makes a unary call and returns its response message, giving up once
+// ctx is done
+func (g *GRPCAdapter) call(ctx context.Context, method string, req any) (json.RawMessage, error) {
+	if g.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.Timeout)
+		defer cancel()
+	}
+	var payload json.RawMessage
+	err := g.stream(ctx, method, req, func(msg json.RawMessage) bool {
+		payload = msg
+		return true
+	})
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, &TimeoutError{Command: method, After: g.Timeout}
+	}
+	if err == nil && payload == nil {
+		err = fmt.Errorf("grpc %s failed: no response message", method)
+	}
+	return payload, err
+}
+
+// GetFullStatus calls Status, as `openclaw status --json` reports it
+func (g *GRPCAdapter) GetFullStatus() (*models.OpenClawStatus, error) {
+	maxRecent := g.MaxRecentSessions
+	if maxRecent <= 0 {
+		maxRecent = DefaultMaxRecentSessions
+	}
+	return g.getStatus(context.Background(), nil, maxRecent)
+}
+
+// getStatus calls Status without the exclude sections
+func (g *GRPCAdapter) getStatus(ctx context.Context, exclude []string, maxRecent int) (*models.OpenClawStatus, error) {
+	req := struct {
+		Exclude []string `json:"exclude,omitempty"`
+	}{exclude}
+	payload, err := g.call(ctx, "Status", req)
+	if err != nil {
+		return nil, err
+	}
+	status, err := decodeStatus(bytes.NewReader(payload), maxRecent)
+	if err != nil {
+		return nil, parseError("status", err)
+	}
+	return status, nil
+}
+
+// GetHealthSnapshot calls Health for the gateway health check result
+func (g *GRPCAdapter) GetHealthSnapshot() (*models.HealthCheckResult, error) {
+	return g.getHealth(context.Background())
+}
+
+func (g *GRPCAdapter) getHealth(ctx context.Context) (*models.HealthCheckResult, error) {
+	payload, err := g.call(ctx, "Health", nil)
+	if err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
+	}
+	return parseHealth(string(payload)), nil
+}
+
+// FollowLogs calls StreamLogs and forwards its log events via logChan until
+// ctx is done or the gateway ends the stream; logChan is then closed. An
+// error is returned if the stream could not be opened.
+func (g *GRPCAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEvent) error {
+	resp, err := g.open(ctx, "StreamLogs", nil)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(logChan)
+		defer resp.Body.Close()
+		r := bufio.NewReader(resp.Body)
+		for {
+			msg, err := readMessage(r)
+			if err != nil {
+				return
+			}
+			if event, ok := decodeLogPayload(msg); ok {
+				select {
+				case logChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// WatchHealth calls WatchHealth and forwards each health check result the
+// gateway pushes via healthChan until ctx is done or the stream ends;
+// healthChan is then closed. An error is returned if the stream could not
+// be opened.
+func (g *GRPCAdapter) WatchHealth(ctx context.Context, healthChan chan<- *models.HealthCheckResult) error {
+	resp, err := g.open(ctx, "WatchHealth", nil)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(healthChan)
+		defer resp.Body.Close()
+		r := bufio.NewReader(resp.Body)
+		for {
+			msg, err := readMessage(r)
+			if err != nil {
+				return
+			}
+			select {
+			case healthChan <- parseHealth(string(msg)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// FollowHealth streams the health check results the gateway pushes over its
+// gRPC API via healthChan until ctx is done or the stream ends; healthChan
+// is then closed. Without the API, the health check can only be polled.
+func (c *CLIAdapter) FollowHealth(ctx context.Context, healthChan chan<- *models.HealthCheckResult) error {
+	if c.GRPC == nil {
+		return errors.New("health updates need the gRPC API")
+	}
+	return c.GRPC.WatchHealth(ctx, healthChan)
+}
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// HostKey holds the SSH host keys an instance offers, for review before
+// they are trusted
+type HostKey struct {
+	Host         string   // As written to known_hosts, e.g. "example.com" or "[example.com]:2222"
+	Lines        []string // known_hosts entries
+	Fingerprints []string // As printed by ssh-keygen -l, e.g. "256 SHA256:... example.com (ED25519)"
+}
+
+// hostKeyArgs returns the ssh options applying the instance's host key
+// policy. Keys are looked up in, and new ones written to, KnownHostsFile
+// before the user's own known_hosts.
+func (c *CLIAdapter) hostKeyArgs() []string {
+	if c.SSHConfig.HostKeyPolicy == models.HostKeyInsecure {
+		return []string{"-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null"}
+	}
+
+	var args []string
+	if c.SSHConfig.HostKeyPolicy == models.HostKeyStrict {
+		args = append(args, "-o", "StrictHostKeyChecking=yes")
+	} else {
+		args = append(args, "-o", "StrictHostKeyChecking=accept-new")
+	}
+	if c.KnownHostsFile != "" {
+		args = append(args, "-o", fmt.Sprintf("UserKnownHostsFile=%q ~/.ssh/known_hosts ~/.ssh/known_hosts2", c.KnownHostsFile))
+	}
+	return args
+}
+
+// sshHost returns the SSH host without any user@ prefix
+func (c *CLIAdapter) sshHost() string {
+	host := c.SSHConfig.Host
+	if i := strings.LastIndex(host, "@"); i != -1 {
+		host = host[i+1:]
+	}
+	return host
+}
+
+// ScanHostKey fetches the host keys the instance's SSH server offers with
+// ssh-keyscan. Hosts reached through proxy_jump cannot be scanned.
+func (c *CLIAdapter) ScanHostKey() (*HostKey, error) {
+	if c.SSHConfig == nil || c.SSHConfig.Host == "" {
+		return nil, errors.New("not an SSH instance")
+	}
+	if c.SSHConfig.ProxyJump != "" {
+		return nil, errors.New("host keys cannot be scanned through proxy_jump; connect once with ssh to review the key")
+	}
+
+	args := []string{"-T", "10"}
+	if c.SSHConfig.ConnectTimeout > 0 {
+		args[1] = strconv.Itoa(c.SSHConfig.ConnectTimeout)
+	}
+	if c.SSHConfig.Port > 0 {
+		args = append(args, "-p", strconv.Itoa(c.SSHConfig.Port))
+	}
+	args = append(args, c.sshHost())
+	scanned, err := c.runHelper(nil, "ssh-keyscan", args...)
+	if err != nil {
+		return nil, fmt.Errorf("ssh-keyscan failed: %w", err)
+	}
+
+	key := &HostKey{}
+	for _, line := range strings.Split(scanned, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key.Host = fields[0]
+		key.Lines = append(key.Lines, line)
+	}
+	if len(key.Lines) == 0 {
+		return nil, fmt.Errorf("%s offered no host keys", c.sshHost())
+	}
+
+	fingerprints, err := c.runHelper([]byte(strings.Join(key.Lines, "\n")+"\n"), "ssh-keygen", "-l", "-f", "-")
+	if err != nil {
+		return nil, fmt.Errorf("ssh-keygen failed: %w", err)
+	}
+	key.Fingerprints = strings.Split(fingerprints, "\n")
+	return key, nil
+}
+
+// runHelper runs a local helper program bounded by the adapter's timeout,
+// feeding it stdin, and returns its trimmed output
+func (c *CLIAdapter) runHelper(stdin []byte, name string, args ...string) (output string, err error) {
+	defer func(start time.Time) {
+		c.audit(strings.Join(append([]string{name}, args...), " "), start, err)
+	}(time.Now())
+	d := newDeadline(c.workContext(), name, c.Timeout)
+	cmd := command(d.ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var out []byte
+	runChild(func() {
+		d.start()
+		out, err = cmd.Output()
+	})
+	if timeoutErr := d.finish(); timeoutErr != nil {
+		return "", timeoutErr
+	}
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if stderr := strings.TrimSpace(string(exitErr.Stderr)); stderr != "" {
+				return "", errors.New(stderr)
+			}
+			return "", fmt.Errorf("exit code %d", exitErr.ExitCode())
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// TrustHostKey writes key to KnownHostsFile, replacing any entries it holds
+// for the same host, such as a key the host has since changed
+func (c *CLIAdapter) TrustHostKey(key *HostKey) error {
+	if c.KnownHostsFile == "" {
+		return errors.New("no known_hosts file configured")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.KnownHostsFile), 0700); err != nil {
+		return err
+	}
+
+	existing, err := os.ReadFile(c.KnownHostsFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(existing), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if slices.Contains(strings.Split(fields[0], ","), key.Host) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, key.Lines...)
+	return os.WriteFile(c.KnownHostsFile, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
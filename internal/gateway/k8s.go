@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// NewK8sCLIAdapter creates a new CLI adapter running openclaw in a
+// Kubernetes pod via kubectl exec
+func NewK8sCLIAdapter(name string, k8sConfig *models.K8sConfig, openclawPath string) *CLIAdapter {
+	return &CLIAdapter{
+		InstanceName: name,
+		K8sConfig:    k8sConfig,
+		BinaryPath:   openclawPath,
+	}
+}
+
+// isK8s returns true if this adapter runs commands in a pod
+func (c *CLIAdapter) isK8s() bool {
+	return c.K8sConfig != nil && c.K8sConfig.Selector != ""
+}
+
+// kubectlArgs returns the global kubectl flags selecting the cluster and
+// namespace
+func (c *CLIAdapter) kubectlArgs() []string {
+	var args []string
+	if c.K8sConfig.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", c.K8sConfig.Kubeconfig)
+	}
+	if c.K8sConfig.Context != "" {
+		args = append(args, "--context", c.K8sConfig.Context)
+	}
+	if c.K8sConfig.Namespace != "" {
+		args = append(args, "--namespace", c.K8sConfig.Namespace)
+	}
+	return args
+}
+
+// resolvePod returns the name of a running pod matching the selector. The
+// name is cached until a command fails because the pod is gone.
+func (c *CLIAdapter) resolvePod(ctx context.Context) (string, error) {
+	c.mu.RLock()
+	pod := c.pod
+	c.mu.RUnlock()
+	if pod != "" {
+		return pod, nil
+	}
+
+	args := append(c.kubectlArgs(), "get", "pods",
+		"--selector", c.K8sConfig.Selector,
+		"--field-selector", "status.phase=Running",
+		"--output", "jsonpath={.items[*].metadata.name}")
+	output, err := command(ctx, "kubectl", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("kubectl get pods failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("kubectl failed: %w", err)
+	}
+	pods := strings.Fields(string(output))
+	if len(pods) == 0 {
+		return "", fmt.Errorf("no running pod matches %q", c.K8sConfig.Selector)
+	}
+
+	c.mu.Lock()
+	c.pod = pods[0]
+	c.mu.Unlock()
+	return pods[0], nil
+}
+
+// forgetPod drops the cached pod name so the next command looks it up again
+func (c *CLIAdapter) forgetPod() {
+	c.mu.Lock()
+	c.pod = ""
+	c.mu.Unlock()
+}
+
+// kubectlCommand prepares a kubectl exec running script in the gateway pod
+func (c *CLIAdapter) kubectlCommand(ctx context.Context, script string) (*exec.Cmd, error) {
+	pod, err := c.resolvePod(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args := append(c.kubectlArgs(), "exec", pod)
+	if c.K8sConfig.Container != "" {
+		args = append(args, "--container", c.K8sConfig.Container)
+	}
+	// Gateway images often ship without bash
+	args = append(args, "--", "sh", "-c", script)
+	return command(ctx, "kubectl", args...), nil
+}
+
+// podGone reports whether kubectl failed because the pod it was pointed at
+// no longer exists or is not running, as after a pod restart
+func podGone(stderr string) bool {
+	return containsAny(stderr, []string{"NotFound", "container not found", "completed pod", "unable to upgrade connection"})
+}
+
+// CheckKubectlAvailable checks if kubectl is available
+func CheckKubectlAvailable() bool {
+	_, err := exec.LookPath("kubectl")
+	return err == nil
+}
@@ -0,0 +1,166 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// shutdownGrace is how long a child gets to exit after SIGTERM before it is
+// killed
+const shutdownGrace = 500 * time.Millisecond
+
+var (
+	// shutdownCtx is cancelled by Shutdown; every child process is tied to it
+	shutdownCtx, cancelChildren = context.WithCancel(context.Background())
+
+	// liveChildren counts started child processes not yet waited for
+	liveChildren atomic.Int32
+)
+
+// command prepares a child process that is sent SIGTERM when ctx is done or
+// Shutdown is called, and killed shutdownGrace later if still running
+func command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	if ctx != shutdownCtx {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		stop := context.AfterFunc(shutdownCtx, cancel)
+		// The derived context lives as long as the caller's; release the
+		// shutdown hook with it
+		context.AfterFunc(ctx, func() { stop() })
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Cancel = func() error {
+		if runtime.GOOS == "windows" {
+			// Windows has no SIGTERM to send
+			return cmd.Process.Kill()
+		}
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = shutdownGrace
+	return cmd
+}
+
+// runChild runs fn, which starts and waits for a short-lived child process,
+// in a worker pool slot, counting the child so Shutdown can wait for it
+func runChild(fn func()) {
+	defaultPool.Do(func() {
+		liveChildren.Add(1)
+		defer liveChildren.Add(-1)
+		fn()
+	})
+}
+
+// Shutdown terminates all child processes (commands, log and event
+// followers) and waits up to timeout for them to exit. It reports whether
+// they all did. Commands started afterwards fail immediately.
+func Shutdown(timeout time.Duration) bool {
+	cancelChildren()
+	deadline := time.Now().Add(timeout)
+	for liveChildren.Load() > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return true
+}
+
+// ErrTimeout is matched (with errors.Is) by the errors of commands that
+// exceed their timeout
+var ErrTimeout = errors.New("timed out")
+
+// ErrInterrupted is returned by commands cut short by Interrupt
+var ErrInterrupted = errors.New("interrupted")
+
+// TimeoutError reports a command abandoned once its timeout expired
+type TimeoutError struct {
+	Command string // Such as "status"; empty for other remote commands
+	After   time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Command == "" {
+		return fmt.Sprintf("timed out after %s", e.After)
+	}
+	return fmt.Sprintf("%s timed out after %s", e.Command, e.After)
+}
+
+// Is makes errors.Is(err, ErrTimeout) hold for every TimeoutError
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// deadline enforces an adapter's timeout on one command. The clock starts
+// when the command gets a worker slot, not while it waits for one, so a
+// backlog of slow instances cannot time out commands for a healthy one.
+type deadline struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	parent  context.Context
+	command string
+	timeout time.Duration
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+func newDeadline(parent context.Context, command string, timeout time.Duration) *deadline {
+	d := &deadline{command: command, timeout: timeout, parent: parent}
+	d.ctx, d.cancel = context.WithCancel(parent)
+	return d
+}
+
+// start begins the countdown; call it from inside runChild
+func (d *deadline) start() {
+	if d.timeout > 0 {
+		d.timer = time.AfterFunc(d.timeout, func() {
+			d.expired.Store(true)
+			d.cancel()
+		})
+	}
+}
+
+// finish releases the deadline, returning a *TimeoutError if it expired or
+// ErrInterrupted if its parent was cancelled by Interrupt
+func (d *deadline) finish() error {
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.cancel()
+	if d.expired.Load() {
+		return &TimeoutError{Command: d.command, After: d.timeout}
+	}
+	if d.parent.Err() != nil && shutdownCtx.Err() == nil {
+		return ErrInterrupted
+	}
+	return nil
+}
+
+// workContext returns the context the adapter's commands run under until
+// the next Interrupt
+func (c *CLIAdapter) workContext() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.work == nil {
+		c.work, c.stopWork = context.WithCancel(shutdownCtx)
+	}
+	return c.work
+}
+
+// Interrupt terminates the adapter's commands in flight, and fails those
+// still waiting for a worker slot, with ErrInterrupted. The UI calls it
+// when switching away from the instance, whose results it no longer wants.
+// Commands started afterwards run as usual.
+func (c *CLIAdapter) Interrupt() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopWork != nil {
+		c.stopWork()
+	}
+	c.work, c.stopWork = nil, nil
+}
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// runLive runs the openclaw command args until it exits, ctx is done or
+// timeout (0 = none) expires, passing each line it prints to onLine as it
+// appears, stdout and stderr alike (nil = discard). onLine is called from
+// another goroutine, one line at a time. escalated runs it under the
+// instance's escalation, if set.
+func (c *CLIAdapter) runLive(ctx context.Context, args []string, escalated bool, timeout time.Duration, onLine func(string)) (err error) {
+	if onLine == nil {
+		onLine = func(string) {}
+	}
+	command := "openclaw " + strings.Join(args, " ")
+	escalation := c.escalationArgs()
+	if !escalated {
+		escalation = nil
+	}
+	if escalation != nil {
+		command = strings.Join(escalation, " ") + " " + command
+	}
+	defer func(start time.Time) {
+		recordCommand(args, time.Since(start), err)
+		c.audit(command, start, err)
+	}(time.Now())
+	if err := c.authorize(args); err != nil {
+		return err
+	}
+
+	d := newDeadline(ctx, commandName(args), timeout)
+	var cmd *exec.Cmd
+	if c.IsRemote() {
+		if err := c.admit(); err != nil {
+			d.cancel()
+			return err
+		}
+		script := c.remoteCommand(args...)
+		if escalation != nil {
+			script = c.escalate(script)
+		}
+		if cmd, err = c.remoteShellCommand(d.ctx, script); err != nil {
+			d.cancel()
+			return err
+		}
+	} else if escalation != nil {
+		cmd = c.escalatedCommand(d.ctx, c.localBinary(), args...)
+	} else {
+		cmd = c.localCommand(d.ctx, args...)
+	}
+	var mu sync.Mutex
+	stdout := &lineWriter{mu: &mu, onLine: onLine}
+	stderr := &lineWriter{mu: &mu, onLine: onLine}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	var runErr error
+	runChild(func() {
+		d.start()
+		runErr = cmd.Run()
+	})
+	stdout.flush()
+	stderr.flush()
+	if err := d.finish(); err != nil {
+		return err
+	}
+
+	// Classified like the output-capturing commands, from what was printed
+	// to stderr
+	exitErr, exited := runErr.(*exec.ExitError)
+	if exited {
+		exitErr.Stderr = stderr.written.Bytes()
+	}
+	if c.IsRemote() {
+		if runErr != nil {
+			runErr = c.remoteShellError(runErr)
+		}
+		c.noteOutcome(runErr)
+		return c.escalationError(runErr)
+	}
+	if exited {
+		msg := strings.TrimSpace(string(exitErr.Stderr))
+		return c.escalationError(commandFailure(runErr, msg, fmt.Errorf("command failed: %s", msg)))
+	}
+	if runErr != nil {
+		return commandFailure(runErr, "", runErr)
+	}
+	return nil
+}
+
+// lineWriter passes each complete line written to it to onLine, and keeps
+// everything written. Writers sharing mu hand over their lines one at a time.
+type lineWriter struct {
+	mu      *sync.Mutex
+	onLine  func(string)
+	pending []byte
+	written bytes.Buffer
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written.Write(p)
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.onLine(strings.TrimRight(string(w.pending[:i]), "\r"))
+		w.pending = w.pending[i+1:]
+	}
+}
+
+// flush passes on a last line left without a newline
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) > 0 {
+		w.onLine(string(w.pending))
+		w.pending = nil
+	}
+}
@@ -0,0 +1,191 @@
+package gateway
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// CommandStat summarizes the calls of one CLI command (such as "status" or
+// "channels status"), timed from queueing to exit
+type CommandStat struct {
+	Name   string
+	Calls  uint64
+	Errors uint64
+	Last   time.Duration
+	Total  time.Duration
+	Max    time.Duration
+}
+
+// Avg returns the mean call duration
+func (s CommandStat) Avg() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Calls)
+}
+
+var commandStats = struct {
+	mu     sync.Mutex
+	byName map[string]*CommandStat
+}{byName: make(map[string]*CommandStat)}
+
+// commandName names a command by its leading subcommand words
+func commandName(args []string) string {
+	var words []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") || len(words) == 2 {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// recordCommand adds a finished call to the command stats
+func recordCommand(args []string, d time.Duration, err error) {
+	if errors.Is(err, errDryRun) {
+		return
+	}
+	name := commandName(args)
+	commandStats.mu.Lock()
+	defer commandStats.mu.Unlock()
+	s := commandStats.byName[name]
+	if s == nil {
+		s = &CommandStat{Name: name}
+		commandStats.byName[name] = s
+	}
+	s.Calls++
+	if err != nil {
+		s.Errors++
+	}
+	s.Last = d
+	s.Total += d
+	s.Max = max(s.Max, d)
+}
+
+// CommandStats returns the stats of every command run so far, across all
+// adapters, by name
+func CommandStats() []CommandStat {
+	commandStats.mu.Lock()
+	defer commandStats.mu.Unlock()
+	stats := make([]CommandStat, 0, len(commandStats.byName))
+	for _, s := range commandStats.byName {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// metricsWindow is how many of an adapter's latest status fetches its
+// metrics cover
+const metricsWindow = 100
+
+// fetchSample is one status fetch of an adapter
+type fetchSample struct {
+	took    time.Duration // From the request to the decoded status
+	gateway time.Duration // Connect latency the gateway reported, if any
+	failed  bool
+}
+
+// fetchMetrics holds an adapter's latest status fetches in a ring
+type fetchMetrics struct {
+	samples     []fetchSample
+	next        int
+	fetches     uint64
+	errors      uint64
+	lastSuccess time.Time
+	lastVia     string
+}
+
+// FetchMetrics summarizes an adapter's latest status fetches. Comparing
+// Latency with GatewayLatency, the gateway's own connect latency, tells
+// time spent reaching the instance (SSH, kubectl, starting the CLI) from
+// time the gateway takes to answer.
+type FetchMetrics struct {
+	Fetches     uint64        // Status fetches since start
+	Errors      uint64        // Failed fetches since start
+	Window      int           // Latest fetches the fields below cover
+	ErrorRate   float64       // Fraction of the window that failed
+	P50, P95    time.Duration // Latency of the window's successful fetches
+	GatewayP50  time.Duration // Connect latency the gateway reported
+	GatewayP95  time.Duration
+	LastSuccess time.Time
+	Via         string // How the last fetch was served: "socket", "grpc" or "cli"
+}
+
+// recordFetch adds a finished status fetch to the adapter's metrics
+func (c *CLIAdapter) recordFetch(took time.Duration, via string, status *models.OpenClawStatus, err error) {
+	sample := fetchSample{took: took, failed: err != nil}
+	if status != nil && status.Gateway != nil {
+		sample.gateway = time.Duration(status.Gateway.ConnectLatencyMs) * time.Millisecond
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := &c.metrics
+	if len(m.samples) < metricsWindow {
+		m.samples = append(m.samples, sample)
+	} else {
+		m.samples[m.next] = sample
+		m.next = (m.next + 1) % metricsWindow
+	}
+	m.fetches++
+	if err != nil {
+		m.errors++
+		return
+	}
+	m.lastSuccess = time.Now()
+	m.lastVia = via
+}
+
+// Metrics returns a summary of the adapter's latest status fetches
+func (c *CLIAdapter) Metrics() FetchMetrics {
+	c.mu.RLock()
+	m := c.metrics
+	samples := slices.Clone(m.samples)
+	c.mu.RUnlock()
+
+	metrics := FetchMetrics{
+		Fetches:     m.fetches,
+		Errors:      m.errors,
+		Window:      len(samples),
+		LastSuccess: m.lastSuccess,
+		Via:         m.lastVia,
+	}
+	var took, gw []time.Duration
+	failed := 0
+	for _, s := range samples {
+		switch {
+		case s.failed:
+			failed++
+		default:
+			took = append(took, s.took)
+			if s.gateway > 0 {
+				gw = append(gw, s.gateway)
+			}
+		}
+	}
+	if len(samples) > 0 {
+		metrics.ErrorRate = float64(failed) / float64(len(samples))
+	}
+	metrics.P50, metrics.P95 = percentile(took, 50), percentile(took, 95)
+	metrics.GatewayP50, metrics.GatewayP95 = percentile(gw, 50), percentile(gw, 95)
+	return metrics
+}
+
+// percentile returns the p-th percentile of ds (nearest rank), 0 if empty.
+// ds is sorted in place.
+func percentile(ds []time.Duration, p int) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	slices.Sort(ds)
+	rank := (p*len(ds) + 99) / 100
+	return ds[max(rank, 1)-1]
+}
@@ -1,54 +1,103 @@
 package gateway
 
 import (
+	"context"
 	"math/rand"
 	"time"
 
 	"github.com/lazyclaw/lazyclaw/internal/models"
 )
 
-// MockClient simulates a gateway connection for UI testing
+// MockClient simulates a gateway for UI testing (--mock). Status and health
+// are canned; logs are generated every couple of seconds.
 type MockClient struct {
-	connected bool
-	logs      chan models.LogEvent
-	done      chan struct{}
+	name    string
+	started time.Time
 }
 
 // NewMockClient creates a mock gateway client
-func NewMockClient() *MockClient {
-	return &MockClient{
-		logs: make(chan models.LogEvent, 100),
-		done: make(chan struct{}),
-	}
+func NewMockClient(name string) *MockClient {
+	return &MockClient{name: name, started: time.Now()}
 }
 
-// Connect simulates connecting to a gateway
-func (m *MockClient) Connect() interface{} {
-	m.connected = true
-
-	// Start generating mock logs
-	go m.generateMockLogs()
+// GetInstanceName returns the instance name
+func (m *MockClient) GetInstanceName() string {
+	return m.name
+}
 
-	return ConnectedMsg{
-		Scopes:          []string{"operator.read"},
-		ProtocolVersion: "1",
-		GatewayVersion:  "mock-1.0.0",
-	}
+// IsRemote returns false; the mock gateway is always local
+func (m *MockClient) IsRemote() bool {
+	return false
 }
 
-// Close closes the mock client
+// Close does nothing; log streams end with their context
 func (m *MockClient) Close() error {
-	close(m.done)
-	m.connected = false
 	return nil
 }
 
-// GetLogs returns the log channel
-func (m *MockClient) GetLogs() <-chan models.LogEvent {
-	return m.logs
+// GetFullStatus returns a canned status with a session count that grows
+// over time, so change highlighting has something to show
+func (m *MockClient) GetFullStatus() (*models.OpenClawStatus, error) {
+	now := time.Now().UnixMilli()
+	sessions := 12 + int(time.Since(m.started)/time.Minute)
+	recent := []models.Session{
+		{AgentID: "assistant", Key: "whatsapp:user_123", Kind: "direct", UpdatedAt: now - 30_000, Age: 30_000,
+			TotalTokens: 42_000, ContextTokens: 200_000, PercentUsed: 21, Model: "mock-model"},
+		{AgentID: "assistant", Key: "telegram:family", Kind: "group", UpdatedAt: now - 600_000, Age: 600_000,
+			TotalTokens: 150_000, ContextTokens: 200_000, PercentUsed: 75, Model: "mock-model"},
+	}
+	return &models.OpenClawStatus{
+		ChannelSummary: []string{"WhatsApp: linked", "Telegram: configured"},
+		Heartbeat: &models.Heartbeat{
+			DefaultAgentID: "assistant",
+			Agents:         []models.HeartbeatAgent{{AgentID: "assistant", Enabled: true, Every: "30m", EveryMs: 1_800_000}},
+		},
+		Sessions: &models.Sessions{
+			Count:    sessions,
+			Defaults: models.SessionDefault{Model: "mock-model", ContextTokens: 200_000},
+			Recent:   recent,
+			ByAgent:  []models.AgentSession{{AgentID: "assistant", Count: sessions, Recent: recent}},
+		},
+		Gateway: &models.GatewayInfo{
+			Mode:             "local",
+			URL:              "ws://127.0.0.1:18789",
+			Reachable:        true,
+			ConnectLatencyMs: 3 + rand.Intn(5),
+			Self:             models.GatewaySelf{Host: "mock", Version: "mock-1.0.0", Platform: "linux"},
+		},
+		Agents: &models.AgentsInfo{
+			DefaultID:     "assistant",
+			Agents:        []models.AgentInfo{{ID: "assistant", WorkspaceDir: "~/.openclaw/workspace", SessionsCount: sessions, LastActiveAgeMs: 30_000}},
+			TotalSessions: sessions,
+		},
+	}, nil
+}
+
+// GetHealthSnapshot returns a canned health check result
+func (m *MockClient) GetHealthSnapshot() (*models.HealthCheckResult, error) {
+	return &models.HealthCheckResult{
+		Overall:         "ok",
+		Timestamp:       time.Now().UnixMilli(),
+		Gateway:         &models.HealthGateway{Reachable: true, LatencyMs: 4, Version: "mock-1.0.0"},
+		ProbeDurationMs: 45,
+		Channels: []models.HealthChannelItem{
+			{ID: "whatsapp", Label: "WhatsApp", Status: "ok", Connected: true, AuthAgeMs: 24 * 3_600_000},
+			{ID: "telegram", Label: "Telegram", Status: "ok", Connected: true, AuthAgeMs: 48 * 3_600_000},
+		},
+	}, nil
+}
+
+// FollowLogs streams generated log events via logChan until ctx is done,
+// then closes it
+func (m *MockClient) FollowLogs(ctx context.Context, logChan chan<- models.LogEvent) error {
+	go func() {
+		defer close(logChan)
+		m.generateMockLogs(ctx, logChan)
+	}()
+	return nil
 }
 
-func (m *MockClient) generateMockLogs() {
+func (m *MockClient) generateMockLogs(ctx context.Context, logChan chan<- models.LogEvent) {
 	messages := []struct {
 		level   string
 		message string
@@ -75,19 +124,19 @@ func (m *MockClient) generateMockLogs() {
 
 	for {
 		select {
-		case <-m.done:
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			msg := messages[rand.Intn(len(messages))]
 			select {
-			case m.logs <- models.LogEvent{
+			case logChan <- models.LogEvent{
 				Timestamp: time.Now(),
 				Level:     msg.level,
 				Source:    "gateway",
 				Message:   msg.message,
 			}:
-			default:
-				// Channel full, skip
+			case <-ctx.Done():
+				return
 			}
 		}
 	}
@@ -0,0 +1,591 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// nativeSSHEnv is set in the environment of a lazyclaw started as the
+// native SSH client, for instances with ssh.client: native
+const nativeSSHEnv = "LAZYCLAW_NATIVE_SSH"
+
+// nativeExitError is the exit code of the native client when it fails
+// itself rather than the remote command, as ssh's is
+const nativeExitError = 255
+
+// NativeSSHRequested returns true if this process was started as the native
+// SSH client, and should run RunNativeSSH instead of the UI
+func NativeSSHRequested() bool {
+	return os.Getenv(nativeSSHEnv) == "1"
+}
+
+// nativeSSHCommand prepares lazyclaw itself, as the native SSH client, to
+// run with the arguments ssh would be given. Running the client as a child
+// keeps timeouts, Shutdown and output handling the same for both clients.
+func nativeSSHCommand(ctx context.Context, sshArgs []string) *exec.Cmd {
+	self, err := os.Executable()
+	if err != nil {
+		self = os.Args[0]
+	}
+	cmd := command(ctx, self, sshArgs...)
+	cmd.Env = append(os.Environ(), nativeSSHEnv+"=1")
+	return cmd
+}
+
+// nativeSSHOptions holds the subset of ssh's arguments buildSSHArgs passes
+type nativeSSHOptions struct {
+	verbose         bool
+	hostKeyChecking string   // yes, accept-new or no, as StrictHostKeyChecking
+	knownHosts      []string // Looked up in order, new keys added to the first
+	agentSocket     string   // "" for $SSH_AUTH_SOCK, "none" for no agent
+	connectTimeout  time.Duration
+	aliveInterval   time.Duration
+	aliveCountMax   int
+	port            int
+	identityFile    string
+	proxyJump       string
+	destination     string
+	command         string
+}
+
+// RunNativeSSH connects with golang.org/x/crypto/ssh and runs a command as
+// ssh would, taking the arguments buildSSHArgs produces. It returns the
+// remote command's exit code, or 255 with ssh's own messages on stderr if
+// the connection fails, so both clients' failures are classified alike.
+func RunNativeSSH(args []string) int {
+	opts, err := parseNativeSSHArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ssh: %v\n", err)
+		return nativeExitError
+	}
+
+	client, err := opts.dial()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nativeExitError
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ssh: opening session: %v\n", err)
+		return nativeExitError
+	}
+	defer session.Close()
+	session.Stdin, session.Stdout, session.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	// Stopped by a timeout or Shutdown: end the remote command with us
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		session.Signal(ssh.SIGTERM)
+		client.Close()
+	}()
+
+	done := make(chan struct{})
+	defer close(done)
+	if opts.aliveInterval > 0 {
+		go opts.keepAlive(client, done)
+	}
+
+	err = session.Run(opts.command)
+	var exitErr *ssh.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr) && exitErr.Signal() == "":
+		return exitErr.ExitStatus()
+	case errors.As(err, &exitErr):
+		fmt.Fprintf(os.Stderr, "ssh: remote command killed by signal %s\n", exitErr.Signal())
+	default:
+		fmt.Fprintf(os.Stderr, "Connection to %s closed: %v\n", opts.destination, err)
+	}
+	return nativeExitError
+}
+
+// parseNativeSSHArgs parses the arguments buildSSHArgs produces, plus the
+// -v watchConnect adds. Options the native client has no use for, such as
+// BatchMode (it never prompts) and Compression, are accepted and ignored.
+func parseNativeSSHArgs(args []string) (*nativeSSHOptions, error) {
+	opts := &nativeSSHOptions{
+		hostKeyChecking: "accept-new",
+		knownHosts:      []string{expandHome("~/.ssh/known_hosts"), expandHome("~/.ssh/known_hosts2")},
+		aliveCountMax:   3,
+		port:            22,
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-v" {
+			opts.verbose = true
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			opts.destination = arg
+			opts.command = strings.Join(args[i+1:], " ")
+			break
+		}
+		if i+1 == len(args) {
+			return nil, fmt.Errorf("option %s requires an argument", arg)
+		}
+		i++
+		value := args[i]
+		switch arg {
+		case "-o":
+			if err := opts.setOption(value); err != nil {
+				return nil, err
+			}
+		case "-p":
+			port, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("bad port %q", value)
+			}
+			opts.port = port
+		case "-i":
+			opts.identityFile = expandHome(value)
+		case "-J":
+			opts.proxyJump = value
+		default:
+			return nil, fmt.Errorf("option %s is not supported by the native client", arg)
+		}
+	}
+	if opts.destination == "" {
+		return nil, errors.New("no destination host")
+	}
+	if opts.command == "" {
+		return nil, errors.New("the native client only runs commands, not interactive shells")
+	}
+	return opts, nil
+}
+
+// setOption applies a -o Key=Value option
+func (o *nativeSSHOptions) setOption(option string) error {
+	key, value, ok := strings.Cut(option, "=")
+	if !ok {
+		return fmt.Errorf("bad option %q", option)
+	}
+	number := func() (int, error) {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("bad value for %s: %q", key, value)
+		}
+		return n, nil
+	}
+
+	switch strings.ToLower(key) {
+	case "stricthostkeychecking":
+		o.hostKeyChecking = value
+	case "userknownhostsfile":
+		files, err := splitPaths(value)
+		if err != nil {
+			return fmt.Errorf("bad value for %s: %w", key, err)
+		}
+		o.knownHosts = files
+	case "identityagent":
+		paths, err := splitPaths(value)
+		if err != nil || len(paths) != 1 {
+			return fmt.Errorf("bad value for %s: %q", key, value)
+		}
+		o.agentSocket = paths[0]
+	case "connecttimeout":
+		n, err := number()
+		if err != nil {
+			return err
+		}
+		o.connectTimeout = time.Duration(n) * time.Second
+	case "serveraliveinterval":
+		n, err := number()
+		if err != nil {
+			return err
+		}
+		o.aliveInterval = time.Duration(n) * time.Second
+	case "serveralivecountmax":
+		n, err := number()
+		if err != nil {
+			return err
+		}
+		o.aliveCountMax = n
+	}
+	return nil
+}
+
+// splitPaths splits a space-separated list of paths, each either plain or
+// double-quoted, expanding a leading ~/ in each
+func splitPaths(value string) ([]string, error) {
+	var paths []string
+	for value = strings.TrimSpace(value); value != ""; value = strings.TrimSpace(value) {
+		var path string
+		if value[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(value)
+			if err != nil {
+				return nil, err
+			}
+			path, _ = strconv.Unquote(quoted)
+			value = value[len(quoted):]
+		} else {
+			path, value, _ = strings.Cut(value, " ")
+		}
+		paths = append(paths, expandHome(path))
+	}
+	return paths, nil
+}
+
+// nativeHop is a host connected to on the way to, or as, the destination
+type nativeHop struct {
+	user string
+	host string
+	port int
+}
+
+func (h nativeHop) addr() string {
+	return net.JoinHostPort(h.host, strconv.Itoa(h.port))
+}
+
+// parseHop parses [user@]host[:port], as -J hops are written
+func parseHop(spec string, port int) nativeHop {
+	hop := nativeHop{host: spec, port: port}
+	if i := strings.LastIndex(spec, "@"); i != -1 {
+		hop.user, hop.host = spec[:i], spec[i+1:]
+	}
+	if host, p, err := net.SplitHostPort(hop.host); err == nil {
+		if n, err := strconv.Atoi(p); err == nil {
+			hop.host, hop.port = host, n
+		}
+	}
+	if hop.user == "" {
+		hop.user = localUser()
+	}
+	return hop
+}
+
+// localUser returns the local user name, which ssh logs in as by default
+func localUser() string {
+	if u, err := user.Current(); err == nil {
+		name := u.Username
+		if i := strings.LastIndex(name, `\`); i != -1 {
+			name = name[i+1:] // DOMAIN\name on Windows
+		}
+		return name
+	}
+	return os.Getenv("USER")
+}
+
+// dial connects to the destination, through each -J hop in turn
+func (o *nativeSSHOptions) dial() (*ssh.Client, error) {
+	hostKeys, err := o.hostKeys()
+	if err != nil {
+		return nil, err
+	}
+	signers := o.signers()
+
+	var hops []nativeHop
+	if o.proxyJump != "" {
+		for _, spec := range strings.Split(o.proxyJump, ",") {
+			hops = append(hops, parseHop(strings.TrimSpace(spec), 22))
+		}
+	}
+	target := parseHop(o.destination, o.port)
+	hops = append(hops, target)
+
+	var client *ssh.Client
+	for _, hop := range hops {
+		next, err := o.dialHop(client, hop, hostKeys, signers)
+		if err != nil {
+			if client != nil {
+				client.Close()
+			}
+			return nil, err
+		}
+		client = next
+	}
+	return client, nil
+}
+
+// dialHop connects to hop, directly or through the previous hop's client
+func (o *nativeSSHOptions) dialHop(via *ssh.Client, hop nativeHop, hostKeys *nativeHostKeys, signers []ssh.Signer) (*ssh.Client, error) {
+	o.debugf("debug1: Connecting to %s [%s] port %d.", hop.host, hop.host, hop.port)
+	var conn net.Conn
+	var err error
+	if via == nil {
+		conn, err = net.DialTimeout("tcp", hop.addr(), o.connectTimeout)
+	} else {
+		conn, err = via.Dial("tcp", hop.addr())
+	}
+	if err != nil {
+		return nil, dialError(hop, err)
+	}
+
+	config := &ssh.ClientConfig{
+		User:              hop.user,
+		Auth:              []ssh.AuthMethod{ssh.PublicKeys(signers...)},
+		HostKeyCallback:   hostKeys.callback,
+		HostKeyAlgorithms: hostKeys.algorithms(hop.addr()),
+	}
+	// Connections through a hop take no deadline, so a stalled handshake is
+	// ended by closing the connection instead
+	var timedOut atomic.Bool
+	if o.connectTimeout > 0 {
+		timer := time.AfterFunc(o.connectTimeout, func() {
+			timedOut.Store(true)
+			conn.Close()
+		})
+		defer timer.Stop()
+	}
+	o.debugf("debug1: Authenticating to %s:%d as '%s'", hop.host, hop.port, hop.user)
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, hop.addr(), config)
+	if err != nil {
+		conn.Close()
+		if timedOut.Load() {
+			return nil, fmt.Errorf("ssh: connect to host %s port %d: Connection timed out during banner exchange", hop.host, hop.port)
+		}
+		return nil, handshakeError(hop, err)
+	}
+	o.debugf("Authenticated to %s ([%s]:%d) using \"publickey\".", hop.host, hop.host, hop.port)
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// dialError words a failed connection as ssh does
+func dialError(hop nativeHop, err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return fmt.Errorf("ssh: Could not resolve hostname %s: %s", hop.host, dnsErr.Err)
+	}
+	var errno syscall.Errno
+	switch {
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		err = errors.New("Connection timed out")
+	case errors.As(err, &errno):
+		err = errno
+	}
+	return fmt.Errorf("ssh: connect to host %s port %d: %v", hop.host, hop.port, err)
+}
+
+// handshakeError words a failed handshake as ssh does, so host key and
+// authentication failures carry the markers transportKind looks for
+func handshakeError(hop nativeHop, err error) error {
+	var keyErr *nativeHostKeyError
+	if errors.As(err, &keyErr) {
+		return keyErr
+	}
+	if strings.Contains(err.Error(), "unable to authenticate") {
+		return fmt.Errorf("%s@%s: Permission denied (publickey).", hop.user, hop.host)
+	}
+	return fmt.Errorf("ssh: handshake with %s port %d failed: %v", hop.host, hop.port, err)
+}
+
+// signers returns the keys to offer: the agent's, then the identity file,
+// or ssh's default key files if none is set. Keys needing a passphrase are
+// skipped; the client never prompts, as ssh in batch mode does not.
+func (o *nativeSSHOptions) signers() []ssh.Signer {
+	var signers []ssh.Signer
+	socket := o.agentSocket
+	if socket == "" {
+		socket = os.Getenv("SSH_AUTH_SOCK")
+	}
+	if socket != "" && socket != "none" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			if keys, err := agent.NewClient(conn).Signers(); err == nil {
+				signers = append(signers, keys...)
+			}
+		} else {
+			o.debugf("debug1: agent %s unavailable: %v", socket, err)
+		}
+	}
+
+	files := []string{o.identityFile}
+	if o.identityFile == "" {
+		files = []string{expandHome("~/.ssh/id_ed25519"), expandHome("~/.ssh/id_ecdsa"), expandHome("~/.ssh/id_rsa")}
+	}
+	for _, file := range files {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey(pem)
+		if err != nil {
+			o.debugf("debug1: skipping key %s: %v", file, err)
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	return signers
+}
+
+// keepAlive probes the server every aliveInterval, as ServerAliveInterval
+// does, and drops the connection after aliveCountMax unanswered probes
+func (o *nativeSSHOptions) keepAlive(client *ssh.Client, done <-chan struct{}) {
+	ticker := time.NewTicker(o.aliveInterval)
+	defer ticker.Stop()
+	missed := 0
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		reply := make(chan error, 1)
+		go func() {
+			_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+			reply <- err
+		}()
+		select {
+		case err := <-reply:
+			if err == nil {
+				missed = 0
+				continue
+			}
+			missed++
+		case <-time.After(o.aliveInterval):
+			missed++
+		case <-done:
+			return
+		}
+		if missed >= o.aliveCountMax {
+			fmt.Fprintf(os.Stderr, "Timeout, server %s not responding.\n", o.destination)
+			client.Close()
+			return
+		}
+	}
+}
+
+// debugf prints a line of -v output
+func (o *nativeSSHOptions) debugf(format string, args ...any) {
+	if o.verbose {
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+	}
+}
+
+// nativeHostKeys checks host keys against known_hosts per the
+// StrictHostKeyChecking policy
+type nativeHostKeys struct {
+	policy string
+	files  []string
+	known  ssh.HostKeyCallback // nil when host keys are not checked
+}
+
+// nativeHostKeyError is a host key the policy does not trust, worded as
+// ssh words it
+type nativeHostKeyError struct {
+	msg string
+}
+
+func (e *nativeHostKeyError) Error() string {
+	return e.msg
+}
+
+// hostKeys loads the known_hosts files that exist
+func (o *nativeSSHOptions) hostKeys() (*nativeHostKeys, error) {
+	keys := &nativeHostKeys{policy: o.hostKeyChecking, files: o.knownHosts}
+	if o.hostKeyChecking == "no" {
+		return keys, nil
+	}
+	var existing []string
+	for _, file := range o.knownHosts {
+		if _, err := os.Stat(file); err == nil {
+			existing = append(existing, file)
+		}
+	}
+	known, err := knownhosts.New(existing...)
+	if err != nil {
+		return nil, fmt.Errorf("ssh: reading known hosts: %w", err)
+	}
+	keys.known = known
+	return keys, nil
+}
+
+func (k *nativeHostKeys) callback(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	if k.known == nil {
+		return nil
+	}
+	err := k.known(hostname, remote, key)
+	var keyErr *knownhosts.KeyError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &keyErr) && len(keyErr.Want) > 0:
+		return &nativeHostKeyError{msg: fmt.Sprintf(
+			"@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@\n"+
+				"@    WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED!     @\n"+
+				"@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@\n"+
+				"The %s key sent by %s is not the one in %s:%d.\n"+
+				"Host key verification failed.",
+			key.Type(), knownhosts.Normalize(hostname), keyErr.Want[0].Filename, keyErr.Want[0].Line)}
+	case errors.As(err, &keyErr) && k.policy == "accept-new":
+		return k.add(hostname, key)
+	case errors.As(err, &keyErr):
+		return &nativeHostKeyError{msg: fmt.Sprintf(
+			"No %s host key is known for %s and you have requested strict checking.\n"+
+				"Host key verification failed.", key.Type(), knownhosts.Normalize(hostname))}
+	}
+	return &nativeHostKeyError{msg: fmt.Sprintf("%v\nHost key verification failed.", err)}
+}
+
+// add trusts a host's first key, writing it to the first known_hosts file
+func (k *nativeHostKeys) add(hostname string, key ssh.PublicKey) error {
+	if len(k.files) == 0 {
+		return nil
+	}
+	file := k.files[0]
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	host := knownhosts.Normalize(hostname)
+	if _, err := fmt.Fprintln(f, knownhosts.Line([]string{host}, key)); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Warning: Permanently added '%s' (%s) to the list of known hosts.\n", host, key.Type())
+	return nil
+}
+
+// algorithms returns the host key algorithms of the keys known for addr,
+// so the server offers a key that can be checked rather than another type
+// reported as changed. nil leaves the defaults.
+func (k *nativeHostKeys) algorithms(addr string) []string {
+	if k.known == nil {
+		return nil
+	}
+	// Looking up a key no host has lists the keys known for addr
+	probe, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil
+	}
+	pub, err := ssh.NewPublicKey(probe)
+	if err != nil {
+		return nil
+	}
+	var keyErr *knownhosts.KeyError
+	if !errors.As(k.known(addr, &net.TCPAddr{IP: net.IPv4zero}, pub), &keyErr) {
+		return nil
+	}
+	var algorithms []string
+	for _, known := range keyErr.Want {
+		if known.Key.Type() == ssh.KeyAlgoRSA {
+			algorithms = append(algorithms, ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256)
+		}
+		algorithms = append(algorithms, known.Key.Type())
+	}
+	return algorithms
+}
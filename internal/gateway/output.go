@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"os/exec"
+	"sync"
+)
+
+// WatchOutput passes each line the adapter's write commands print to
+// onLine as it appears, stdout and stderr alike, until stop is called.
+// Reads, such as status polls, are not passed on. onLine is called from
+// other goroutines, one line at a time. Only the latest watcher is kept.
+func (c *CLIAdapter) WatchOutput(onLine func(string)) (stop func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watch = onLine
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.watch = nil
+	}
+}
+
+// watcher returns what a command with access passes its output to, or nil
+func (c *CLIAdapter) watcher(access Access) func(string) {
+	if access == AccessRead {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.watch
+}
+
+// captureOutput runs cmd, returning what it printed to stdout, or to both
+// stdout and stderr if combined, as cmd.Output and cmd.CombinedOutput do.
+// Each line is also passed to onLine, if set, as it appears.
+func captureOutput(cmd *exec.Cmd, combined bool, onLine func(string)) ([]byte, error) {
+	if onLine == nil {
+		if combined {
+			return cmd.CombinedOutput()
+		}
+		return cmd.Output()
+	}
+	var mu sync.Mutex
+	stdout := &lineWriter{mu: &mu, onLine: onLine}
+	stderr := stdout
+	if !combined {
+		stderr = &lineWriter{mu: &mu, onLine: onLine}
+	}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	err := cmd.Run()
+	stdout.flush()
+	stderr.flush()
+	if exitErr, ok := err.(*exec.ExitError); ok && !combined {
+		exitErr.Stderr = stderr.written.Bytes()
+	}
+	return stdout.written.Bytes(), err
+}
@@ -0,0 +1,329 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// PairingEvent is something the pairing command printed: a line of text, or
+// a QR code drawn over several lines
+type PairingEvent struct {
+	Line string
+	QR   [][]bool // Dark modules, row by row, without the quiet zone
+}
+
+// PairChannel runs the pairing command of channel (e.g. "whatsapp") until it
+// exits, ctx is done or the instance is interrupted, passing each line it
+// prints to onEvent. QR codes it draws in the terminal are decoded and passed
+// on whole instead of their lines; a fresh one is drawn whenever the last
+// expires. onEvent is called from another goroutine, one event at a time.
+func (c *CLIAdapter) PairChannel(ctx context.Context, channel string, onEvent func(PairingEvent)) error {
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	defer context.AfterFunc(c.workContext(), stop)()
+
+	var art qrArt
+	flush := func() {
+		if qr := art.decode(); qr != nil {
+			onEvent(PairingEvent{QR: qr})
+		} else {
+			for _, line := range art.lines {
+				onEvent(PairingEvent{Line: stripANSI(line)})
+			}
+		}
+		art = qrArt{}
+	}
+	args := []string{"channels", "login", "--channel", channel}
+	err := c.runLive(ctx, args, false, 0, func(line string) {
+		if art.add(line) {
+			return
+		}
+		flush()
+		onEvent(PairingEvent{Line: stripANSI(line)})
+	})
+	flush()
+	if err != nil {
+		return fmt.Errorf("pairing %s failed: %w", channel, err)
+	}
+	return nil
+}
+
+// Colour classes of QR art cells. Which of the two is dark depends on the
+// terminal, so it is worked out from the drawing itself.
+const (
+	artPaper int8 = iota // Default background, or an explicit light colour
+	artInk               // Default foreground, or an explicit dark colour
+)
+
+// qrArt collects consecutive lines of a QR code drawn in the terminal with
+// block characters (█▀▄) or coloured spaces, as qrcode-terminal and the like
+// print it
+type qrArt struct {
+	lines      []string
+	rows       [][]int8
+	halfBlocks bool // Each line draws two rows of modules
+}
+
+// add takes line as the next line of the drawing, returning false if it is
+// not part of one
+func (q *qrArt) add(line string) bool {
+	top, bottom, half, ok := parseArtLine(line)
+	if !ok {
+		return false
+	}
+	q.lines = append(q.lines, line)
+	q.rows = append(q.rows, top, bottom)
+	q.halfBlocks = q.halfBlocks || half
+	return true
+}
+
+// decode returns the dark modules of the QR code drawn, or nil if the lines
+// do not draw one
+func (q *qrArt) decode() [][]bool {
+	if len(q.rows) == 0 {
+		return nil
+	}
+	rows := q.rows
+	if !q.halfBlocks {
+		// Full blocks draw one row per line; drop the duplicate bottom halves
+		rows = make([][]int8, 0, len(q.rows)/2)
+		for i := 0; i < len(q.rows); i += 2 {
+			rows = append(rows, q.rows[i])
+		}
+	}
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	grid := make([][]int8, len(rows))
+	for i, row := range rows {
+		grid[i] = make([]int8, width) // Short lines end in paper
+		copy(grid[i], row)
+	}
+
+	// Which class is dark depends on the terminal the drawing was made for;
+	// only one way round has finder patterns in the corners
+	for _, dark := range []int8{artInk, artPaper} {
+		modules := make([][]bool, len(grid))
+		for i, row := range grid {
+			modules[i] = make([]bool, width)
+			for j, cell := range row {
+				modules[i][j] = cell == dark
+			}
+		}
+		modules = trimQuietZone(modules)
+		if !q.halfBlocks {
+			modules = collapseColumns(modules)
+		}
+		if validQR(modules) {
+			return modules
+		}
+	}
+	return nil
+}
+
+// parseArtLine splits a line of QR art into the colour classes of the top
+// and bottom halves of its cells. half is true if the line uses half blocks,
+// and ok false if it is not QR art.
+func parseArtLine(line string) (top, bottom []int8, half, ok bool) {
+	fg, bg := artInk, artPaper
+	coloured := false
+	blocks := 0
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			params, n := parseCSI(line[i:])
+			if n == 0 {
+				return nil, nil, false, false
+			}
+			if strings.HasSuffix(line[i:i+n], "m") {
+				fg, bg = applySGR(params, fg, bg)
+				coloured = true
+			}
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		switch r {
+		case ' ':
+			top, bottom = append(top, bg), append(bottom, bg)
+		case '█':
+			top, bottom = append(top, fg), append(bottom, fg)
+			blocks++
+		case '▀':
+			top, bottom = append(top, fg), append(bottom, bg)
+			blocks++
+			half = true
+		case '▄':
+			top, bottom = append(top, bg), append(bottom, fg)
+			blocks++
+			half = true
+		default:
+			return nil, nil, false, false
+		}
+	}
+	// Plain spaces draw nothing; a QR code is at least 21 modules wide
+	if len(top) < 21 || blocks == 0 && !coloured {
+		return nil, nil, false, false
+	}
+	return top, bottom, half, true
+}
+
+// parseCSI returns the parameters and length of the escape sequence s starts
+// with, or a length of 0 if it is not a complete one
+func parseCSI(s string) (string, int) {
+	if len(s) < 2 || s[1] != '[' {
+		return "", 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return s[2:i], i + 1
+		}
+	}
+	return "", 0
+}
+
+// applySGR applies the colour parameters of an SGR sequence to the current
+// foreground and background classes
+func applySGR(params string, fg, bg int8) (int8, int8) {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, _ := strconv.Atoi(codes[i])
+		switch {
+		case code == 0:
+			fg, bg = artInk, artPaper
+		case code == 7:
+			fg, bg = bg, fg
+		case code == 39:
+			fg = artInk
+		case code == 49:
+			bg = artPaper
+		case code >= 30 && code <= 37, code >= 90 && code <= 97:
+			fg = colourClass(code % 10)
+		case code >= 40 && code <= 47, code >= 100 && code <= 107:
+			bg = colourClass(code % 10)
+		case (code == 38 || code == 48) && i+2 < len(codes) && codes[i+1] == "5":
+			// 256-colour: 0 and 232-243 are dark, anything else light
+			n, _ := strconv.Atoi(codes[i+2])
+			class := artPaper
+			if n == 0 || n >= 232 && n < 244 {
+				class = artInk
+			}
+			if code == 38 {
+				fg = class
+			} else {
+				bg = class
+			}
+			i += 2
+		}
+	}
+	return fg, bg
+}
+
+// stripANSI removes the escape sequences from line
+func stripANSI(line string) string {
+	if !strings.Contains(line, "\x1b") {
+		return line
+	}
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' {
+			if _, n := parseCSI(line[i:]); n > 0 {
+				i += n
+				continue
+			}
+		}
+		b.WriteByte(line[i])
+		i++
+	}
+	return b.String()
+}
+
+// colourClass classes the basic colour n (0 black .. 7 white): black is ink,
+// the rest paper
+func colourClass(n int) int8 {
+	if n == 0 {
+		return artInk
+	}
+	return artPaper
+}
+
+// trimQuietZone drops the light rows and columns around a QR code
+func trimQuietZone(modules [][]bool) [][]bool {
+	top, bottom := 0, len(modules)
+	for top < bottom && !anyDark(modules[top]) {
+		top++
+	}
+	for bottom > top && !anyDark(modules[bottom-1]) {
+		bottom--
+	}
+	modules = modules[top:bottom]
+	if len(modules) == 0 {
+		return nil
+	}
+	left, right := len(modules[0]), 0
+	for _, row := range modules {
+		for j, dark := range row {
+			if dark {
+				left, right = min(left, j), max(right, j+1)
+			}
+		}
+	}
+	for i := range modules {
+		modules[i] = modules[i][left:right]
+	}
+	return modules
+}
+
+func anyDark(row []bool) bool {
+	for _, dark := range row {
+		if dark {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseColumns merges the pairs of columns of a code drawn two characters
+// per module, as full blocks and coloured spaces keep it square
+func collapseColumns(modules [][]bool) [][]bool {
+	if len(modules) == 0 || len(modules[0])%2 != 0 || len(modules[0]) == len(modules) {
+		return modules
+	}
+	collapsed := make([][]bool, len(modules))
+	for i, row := range modules {
+		for j := 0; j < len(row); j += 2 {
+			if row[j] != row[j+1] {
+				return modules
+			}
+			collapsed[i] = append(collapsed[i], row[j])
+		}
+	}
+	return collapsed
+}
+
+// validQR returns true if modules are the size of a QR code, with finder
+// patterns in three corners
+func validQR(modules [][]bool) bool {
+	n := len(modules)
+	if n < 21 || (n-17)%4 != 0 {
+		return false
+	}
+	for _, row := range modules {
+		if len(row) != n {
+			return false
+		}
+	}
+	for _, corner := range [][2]int{{0, 0}, {0, n - 7}, {n - 7, 0}} {
+		for k := range 7 {
+			r, c := corner[0], corner[1]
+			if !modules[r][c+k] || !modules[r+6][c+k] || !modules[r+k][c] || !modules[r+k][c+6] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package gateway
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is the number of CLI commands run at once when
+// max_concurrent_commands is unset
+const DefaultConcurrency = 4
+
+// WorkerPool bounds how many short-lived CLI and SSH commands run at once.
+// Callers beyond the limit wait in FIFO order. Long-running streams (log and
+// event following) do not take a slot.
+type WorkerPool struct {
+	mu      sync.Mutex
+	size    int
+	running int
+	waiting []chan struct{}
+
+	completed uint64
+	totalWait time.Duration
+	maxWait   time.Duration
+}
+
+// PoolStats is a snapshot of a WorkerPool's load
+type PoolStats struct {
+	Size      int
+	Running   int
+	Queued    int
+	Completed uint64
+	AvgWait   time.Duration
+	MaxWait   time.Duration
+}
+
+// NewWorkerPool creates a pool running at most size commands at once
+func NewWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		size = DefaultConcurrency
+	}
+	return &WorkerPool{size: size}
+}
+
+// defaultPool is shared by every adapter so the limit spans all instances
+var defaultPool = NewWorkerPool(DefaultConcurrency)
+
+// SetConcurrency changes the shared pool's limit; size <= 0 restores the default
+func SetConcurrency(size int) {
+	defaultPool.Resize(size)
+}
+
+// Stats returns a snapshot of the shared pool
+func Stats() PoolStats {
+	return defaultPool.Stats()
+}
+
+// Resize changes the limit, starting queued commands if it grew
+func (p *WorkerPool) Resize(size int) {
+	if size <= 0 {
+		size = DefaultConcurrency
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.size = size
+	p.wake()
+}
+
+// Do runs fn once a slot is free
+func (p *WorkerPool) Do(fn func()) {
+	p.acquire()
+	defer p.release()
+	fn()
+}
+
+func (p *WorkerPool) acquire() {
+	start := time.Now()
+	p.mu.Lock()
+	if p.running < p.size && len(p.waiting) == 0 {
+		p.running++
+		p.mu.Unlock()
+		return
+	}
+	ready := make(chan struct{})
+	p.waiting = append(p.waiting, ready)
+	p.mu.Unlock()
+
+	<-ready
+
+	wait := time.Since(start)
+	p.mu.Lock()
+	p.totalWait += wait
+	p.maxWait = max(p.maxWait, wait)
+	p.mu.Unlock()
+}
+
+func (p *WorkerPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running--
+	p.completed++
+	p.wake()
+}
+
+// wake hands free slots to queued callers; p.mu must be held
+func (p *WorkerPool) wake() {
+	for p.running < p.size && len(p.waiting) > 0 {
+		p.running++
+		close(p.waiting[0])
+		p.waiting = p.waiting[1:]
+	}
+}
+
+// Stats returns a snapshot of the pool's load
+func (p *WorkerPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := PoolStats{
+		Size:      p.size,
+		Running:   p.running,
+		Queued:    len(p.waiting),
+		Completed: p.completed,
+		MaxWait:   p.maxWait,
+	}
+	if p.completed > 0 {
+		stats.AvgWait = p.totalWait / time.Duration(p.completed)
+	}
+	return stats
+}
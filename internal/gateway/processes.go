@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// psCommand lists processes in a format both GNU and BSD ps understand
+const psCommand = "ps -eo pid=,pcpu=,rss=,etime=,args="
+
+// ListProcesses lists openclaw-related processes on the instance's host
+func (c *CLIAdapter) ListProcesses() ([]models.ProcessInfo, error) {
+	var output string
+	if c.IsRemote() {
+		out, err := c.runRemoteShell(psCommand, AccessRead)
+		if err != nil {
+			return nil, err
+		}
+		output = out
+	} else {
+		fields := strings.Fields(psCommand)
+		var out []byte
+		var err error
+		start := time.Now()
+		runChild(func() { out, err = command(c.workContext(), fields[0], fields[1:]...).Output() })
+		c.audit(psCommand, start, err)
+		if err != nil {
+			return nil, fmt.Errorf("ps failed: %w", err)
+		}
+		output = string(out)
+	}
+
+	var procs []models.ProcessInfo
+	for _, line := range strings.Split(output, "\n") {
+		proc, ok := parsePSLine(line)
+		if !ok {
+			continue
+		}
+		if isOpenClawProcess(proc.Command) {
+			procs = append(procs, proc)
+		}
+	}
+	return procs, nil
+}
+
+// isOpenClawProcess matches commands whose executable, or script for
+// interpreters such as node, is part of openclaw. Matching only the leading
+// words skips shells and editors that merely mention openclaw in arguments.
+func isOpenClawProcess(command string) bool {
+	words := strings.Fields(strings.ToLower(command))
+	for i := 0; i < len(words) && i < 2; i++ {
+		if strings.Contains(words[i], "openclaw") {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePSLine parses one line of psCommand output
+func parsePSLine(line string) (models.ProcessInfo, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 {
+		return models.ProcessInfo{}, false
+	}
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return models.ProcessInfo{}, false
+	}
+	proc := models.ProcessInfo{PID: pid, Command: strings.Join(fields[4:], " ")}
+	proc.CPU, _ = strconv.ParseFloat(fields[1], 64)
+	proc.RSSKB, _ = strconv.ParseInt(fields[2], 10, 64)
+	proc.Uptime = parseElapsed(fields[3])
+	return proc, true
+}
+
+// parseElapsed parses ps etime values: [[dd-]hh:]mm:ss
+func parseElapsed(etime string) time.Duration {
+	var days int
+	if d, rest, ok := strings.Cut(etime, "-"); ok {
+		days, _ = strconv.Atoi(d)
+		etime = rest
+	}
+	var total time.Duration
+	for _, part := range strings.Split(etime, ":") {
+		n, _ := strconv.Atoi(part)
+		total = total*60 + time.Duration(n)
+	}
+	return total*time.Second + time.Duration(days)*24*time.Hour
+}
+
+// SignalProcess sends a signal ("TERM" or "KILL") to a process on the
+// instance's host, under the instance's escalation if set
+func (c *CLIAdapter) SignalProcess(pid int, signal string) error {
+	if signal != "TERM" && signal != "KILL" {
+		return fmt.Errorf("unsupported signal %q", signal)
+	}
+	if err := c.authorize([]string{"kill", "-" + signal, strconv.Itoa(pid)}); err != nil {
+		return err
+	}
+	if c.IsRemote() {
+		_, err := c.runRemoteShell(c.escalate(fmt.Sprintf("kill -%s %d", signal, pid)), AccessWrite)
+		return c.escalationError(err)
+	}
+	var out []byte
+	var err error
+	start := time.Now()
+	cmd := c.escalatedCommand(c.workContext(), "kill", "-"+signal, strconv.Itoa(pid))
+	watch := c.watcher(AccessWrite)
+	runChild(func() { out, err = captureOutput(cmd, true, watch) })
+	c.audit(strings.Join(cmd.Args, " "), start, err)
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		return c.escalationError(commandFailure(err, msg, fmt.Errorf("kill failed: %s", msg)))
+	}
+	return nil
+}
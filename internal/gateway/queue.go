@@ -0,0 +1,90 @@
+package gateway
+
+import "sync"
+
+// request is a fetch waiting in, or being run by, an adapter's request
+// queue. done is closed once its result is known.
+type request struct {
+	key   string
+	fetch func() (any, error)
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// requestQueue runs an adapter's fetches one at a time, in order, on a
+// single worker goroutine. A fetch asked for while an identical one is
+// queued or running joins it instead of running again, so refresh ticks,
+// reconnects and instance switches landing together cost one command.
+type requestQueue struct {
+	mu      sync.Mutex
+	pending map[string]*request // Queued or running, by key
+	queue   []*request
+	working bool // The worker goroutine is running
+}
+
+// do runs fetch on the worker, or waits for the identical request already
+// pending under key, and returns its result
+func (q *requestQueue) do(key string, fetch func() (any, error)) (any, error) {
+	q.mu.Lock()
+	r := q.pending[key]
+	if r == nil {
+		r = &request{key: key, fetch: fetch, done: make(chan struct{})}
+		if q.pending == nil {
+			q.pending = make(map[string]*request)
+		}
+		q.pending[key] = r
+		q.queue = append(q.queue, r)
+		if !q.working {
+			q.working = true
+			go q.work()
+		}
+	}
+	q.mu.Unlock()
+
+	<-r.done
+	return r.value, r.err
+}
+
+// work runs queued requests until none are left. It exits when idle so
+// adapters dropped on a config reload leave nothing behind.
+func (q *requestQueue) work() {
+	for {
+		q.mu.Lock()
+		if len(q.queue) == 0 {
+			q.working = false
+			q.mu.Unlock()
+			return
+		}
+		r := q.queue[0]
+		q.queue = q.queue[1:]
+		q.mu.Unlock()
+
+		r.value, r.err = r.fetch()
+
+		// Requests made from now on must run again to see fresh data
+		q.mu.Lock()
+		delete(q.pending, r.key)
+		q.mu.Unlock()
+		close(r.done)
+	}
+}
+
+// queued runs fetch, the getter for args, on the adapter's request queue.
+// A fetch whose output a pending Prefetch will deliver runs right away
+// instead: it starts no command of its own, and waiting its turn could let
+// the prefetched output be discarded first.
+func (c *CLIAdapter) queued(args []string, fetch func() (any, error)) (any, error) {
+	if c.prefetched(args) {
+		return fetch()
+	}
+	return c.requests.do(batchKey(args), fetch)
+}
+
+// Queued returns how many fetches are waiting for or running on the
+// adapter's worker
+func (c *CLIAdapter) Queued() int {
+	c.requests.mu.Lock()
+	defer c.requests.mu.Unlock()
+	return len(c.requests.pending)
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// RunRemediation runs script, the remediation a security audit finding
+// suggests, in the shell of the instance's host and returns what it printed.
+// A script starting with openclaw runs the instance's binary. Every script
+// is authorized as a write, as the shell may run anything after openclaw.
+func (c *CLIAdapter) RunRemediation(script string) (string, error) {
+	script = strings.TrimSpace(script)
+	fields := strings.Fields(script)
+	if len(fields) == 0 {
+		return "", errors.New("empty remediation")
+	}
+	if err := c.authorize([]string{"sh", "-c", script}); err != nil {
+		return "", err
+	}
+
+	var output string
+	var err error
+	if c.IsRemote() {
+		if fields[0] == "openclaw" {
+			script = c.quoteRemoteArg(c.getBinary()) + strings.TrimPrefix(script, "openclaw")
+		}
+		output, err = c.runRemoteShell(script, AccessWrite)
+	} else {
+		if fields[0] == "openclaw" {
+			script = shellQuote(c.localBinary()) + strings.TrimPrefix(script, "openclaw")
+		}
+		output, err = c.runLocalShell(script)
+	}
+	if err != nil {
+		return "", fmt.Errorf("remediation failed: %w", err)
+	}
+	return output, nil
+}
+
+// runLocalShell runs script in a local POSIX shell, bounded by Timeout
+func (c *CLIAdapter) runLocalShell(script string) (output string, err error) {
+	if runtime.GOOS == "windows" {
+		return "", errors.New("running shell scripts locally needs a POSIX shell")
+	}
+	defer func(start time.Time) { c.audit(script, start, err) }(time.Now())
+	d := newDeadline(c.workContext(), "", c.Timeout)
+	cmd := command(d.ctx, "sh", "-c", script)
+	cmd.Env = c.environ()
+	watch := c.watcher(AccessWrite)
+
+	var out []byte
+	var runErr error
+	runChild(func() {
+		d.start()
+		out, runErr = captureOutput(cmd, true, watch)
+	})
+	if err := d.finish(); err != nil {
+		return "", err
+	}
+	if runErr != nil {
+		msg := strings.TrimSpace(string(out))
+		return "", commandFailure(runErr, msg, fmt.Errorf("command failed: %s", msg))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,181 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// Delays before reconnecting a resilient log stream: doubled after each
+// failed attempt up to the cap, for as long as the stream is followed
+const (
+	resumeBackoff    = time.Second
+	resumeMaxBackoff = 30 * time.Second
+)
+
+// resumeTail is how many recent lines a reconnected stream asks for again,
+// to cover what was logged while the link was down
+const resumeTail = 200
+
+// Keepalives of the resilient transport, unless ssh.server_alive_* are set:
+// a dead link is noticed within about 45s instead of stalling the stream
+const (
+	resilientAliveInterval = 15
+	resilientAliveCountMax = 3
+)
+
+// stderrSource is the source of log events read from the logs command's
+// stderr, such as ssh's connection errors
+const stderrSource = "openclaw-cli"
+
+// resilient returns true if log following reconnects when the ssh link
+// drops (ssh.transport: resilient)
+func (c *CLIAdapter) resilient() bool {
+	return c.SSHConfig != nil && c.SSHConfig.Host != "" && !c.isK8s() &&
+		c.SSHConfig.Transport == models.TransportResilient
+}
+
+// followResilient follows the logs over ssh, reconnecting whenever the link
+// drops until ctx is done. A reconnected stream starts with the latest
+// resumeTail lines, of which those already forwarded are dropped, so lines
+// logged during the outage are not lost. Notices of the drop and resumption
+// are forwarded as log events from lazyclaw. logChan is closed once ctx is
+// done or openclaw itself ends the stream.
+func (c *CLIAdapter) followResilient(ctx context.Context, logChan chan<- models.LogEvent) error {
+	stream, exited, err := c.openLogStream(ctx, logsFollowArgs)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(logChan)
+		seen := newRecentLines(resumeTail)
+		resuming, reconnected := false, false
+		failures := 0
+		for {
+			for event := range stream {
+				// Output, unlike an error, shows the link is back
+				live := event.Source != stderrSource
+				if reconnected && live {
+					reconnected = false
+					if !c.sendNotice(ctx, logChan, "Log stream resumed") {
+						return
+					}
+				}
+				// The resumed tail repeats lines already forwarded, up to
+				// the first one that is new
+				if resuming && seen.has(event.Raw) {
+					continue
+				}
+				resuming = false
+				seen.add(event.Raw)
+				if live {
+					failures = 0
+				}
+				select {
+				case logChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			err := <-exited
+			if ctx.Err() != nil || !linkDropped(err) {
+				return
+			}
+
+			delay := min(resumeBackoff<<min(failures, 8), resumeMaxBackoff)
+			failures++
+			if !c.sendNotice(ctx, logChan, fmt.Sprintf("Log stream lost (%v); reconnecting in %s", err, delay)) {
+				return
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+
+			args := logsFollowArgs
+			resuming = c.CachedVersion() != "" && c.Supports(CapLogsTail)
+			if resuming {
+				args = append(slices.Clone(logsFollowArgs), "--tail", strconv.Itoa(resumeTail))
+			}
+			if stream, exited, err = c.openLogStream(ctx, args); err != nil {
+				c.sendNotice(ctx, logChan, fmt.Sprintf("Log stream lost: %v", err))
+				return
+			}
+			reconnected = true
+		}
+	}()
+	return nil
+}
+
+// openLogStream starts one run of the logs command args, returning its
+// events and, once they end, its exit status
+func (c *CLIAdapter) openLogStream(ctx context.Context, args []string) (<-chan models.LogEvent, <-chan error, error) {
+	stream := make(chan models.LogEvent, 64)
+	exited := make(chan error, 1)
+	if err := c.streamLogs(ctx, args, stream, func(err error) { exited <- err }); err != nil {
+		return nil, nil, err
+	}
+	return stream, exited, nil
+}
+
+// sendNotice forwards a notice about the stream itself, returning false if
+// ctx is done
+func (c *CLIAdapter) sendNotice(ctx context.Context, logChan chan<- models.LogEvent, message string) bool {
+	event := models.LogEvent{
+		Timestamp: time.Now(),
+		Level:     "warn",
+		Source:    "lazyclaw",
+		Message:   message,
+		Raw:       message,
+	}
+	select {
+	case logChan <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// linkDropped returns true if ssh exited with its own failure status 255,
+// as when keepalives go unanswered, rather than passing on openclaw's
+func linkDropped(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == 255
+}
+
+// recentLines remembers the last lines forwarded, to recognize them when a
+// resumed stream repeats them
+type recentLines struct {
+	lines  []string
+	next   int
+	counts map[string]int
+}
+
+func newRecentLines(size int) *recentLines {
+	return &recentLines{lines: make([]string, 0, size), counts: make(map[string]int)}
+}
+
+func (r *recentLines) has(line string) bool {
+	return r.counts[line] > 0
+}
+
+func (r *recentLines) add(line string) {
+	if len(r.lines) < cap(r.lines) {
+		r.lines = append(r.lines, line)
+	} else {
+		old := r.lines[r.next]
+		if r.counts[old]--; r.counts[old] == 0 {
+			delete(r.counts, old)
+		}
+		r.lines[r.next] = line
+		r.next = (r.next + 1) % len(r.lines)
+	}
+	r.counts[line]++
+}
@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy sets how a status or health fetch that fails on a transient
+// connection error, such as an SSH blip, is retried. The zero value makes a
+// single attempt.
+type RetryPolicy struct {
+	Attempts   int           // Tries per fetch, including the first
+	Backoff    time.Duration // Delay before the first retry, doubled for each further one
+	MaxBackoff time.Duration // Cap on the delay (0 = none)
+	Jitter     float64       // Random spread of each delay, as a fraction of it
+}
+
+// delay returns how long to wait before the given retry, counted from 1
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.Backoff << (retry - 1)
+	if d < p.Backoff || p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	return max(d, 0)
+}
+
+// RetryState describes a fetch being retried after a failed attempt
+type RetryState struct {
+	Instance string
+	Command  string // "status" or "health"
+	Attempt  int    // The attempt under way, from 2
+	Attempts int
+	Err      error // Why the previous attempt failed
+}
+
+// retryable reports whether err is a failure to reach the host or cluster,
+// rather than of the command run there. Timeouts are not retried: another
+// attempt would likely time out as well.
+func retryable(err error) bool {
+	var adapterErr *AdapterError
+	return errors.As(err, &adapterErr) && adapterErr.Kind == ErrorConnection
+}
+
+// withRetry runs fetch, the openclaw command name, under the adapter's retry
+// policy. While a retry is pending or running, Retrying reports it and
+// OnRetry is called as each retry begins.
+func (c *CLIAdapter) withRetry(name string, fetch func() error) error {
+	work := c.workContext()
+	err := fetch()
+	for attempt := 2; attempt <= c.Retry.Attempts && retryable(err); attempt++ {
+		state := RetryState{
+			Instance: c.InstanceName,
+			Command:  name,
+			Attempt:  attempt,
+			Attempts: c.Retry.Attempts,
+			Err:      err,
+		}
+		c.setRetrying(name, &state)
+		if c.OnRetry != nil {
+			c.OnRetry(state)
+		}
+		select {
+		case <-time.After(c.Retry.delay(attempt - 1)):
+		case <-shutdownCtx.Done():
+			c.setRetrying(name, nil)
+			return err
+		case <-work.Done():
+			c.setRetrying(name, nil)
+			return ErrInterrupted
+		}
+		err = fetch()
+	}
+	c.setRetrying(name, nil)
+	return err
+}
+
+func (c *CLIAdapter) setRetrying(name string, state *RetryState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if state == nil {
+		delete(c.retrying, name)
+		return
+	}
+	if c.retrying == nil {
+		c.retrying = make(map[string]*RetryState)
+	}
+	c.retrying[name] = state
+}
+
+// Retrying returns the status fetch being retried, else the health fetch,
+// or nil if neither is
+func (c *CLIAdapter) Retrying() *RetryState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, name := range []string{"status", "health"} {
+		if state := c.retrying[name]; state != nil {
+			s := *state
+			return &s
+		}
+	}
+	return nil
+}
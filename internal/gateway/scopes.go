@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// Access is what a CLI invocation does to the gateway
+type Access int
+
+const (
+	AccessRead  Access = iota // Only reports state
+	AccessWrite               // Changes the gateway, its config or its host
+)
+
+func (a Access) String() string {
+	if a == AccessRead {
+		return "read"
+	}
+	return "write"
+}
+
+// readCommands lists the argument prefixes of the openclaw commands that only
+// read. Anything else, including commands lazyclaw does not know, is a write.
+var readCommands = [][]string{
+	{"--version"},
+	{"status"},
+	{"health"},
+	{"channels", "--json"},
+	{"channels", "status"},
+	{"logs"},
+	{"events"},
+	{"memory", "search"},
+	{"memory", "files"},
+	{"memory", "status"},
+	{"config", "show"},
+	{"update", "changelog"},
+	{"doctor", "--json"}, // Not doctor --fix, which repairs
+}
+
+// Classify reports whether the openclaw command run with args reads or
+// writes
+func Classify(args []string) Access {
+	for _, prefix := range readCommands {
+		if len(args) >= len(prefix) && slices.Equal(args[:len(prefix)], prefix) {
+			return AccessRead
+		}
+	}
+	return AccessWrite
+}
+
+// authorize refuses a write command unless the adapter's Scopes grant
+// operator.write. Reads are always allowed. A DryRun adapter records the
+// command and refuses it.
+func (c *CLIAdapter) authorize(args []string) error {
+	if c.dryRun != nil {
+		*c.dryRun = append(*c.dryRun, describeCommand(args))
+		return errDryRun
+	}
+	if c.Scopes == nil || Classify(args) == AccessRead || slices.Contains(c.Scopes, models.ScopeWrite) {
+		return nil
+	}
+	return &AdapterError{
+		Kind:     ErrorScopeDenied,
+		ExitCode: -1,
+		Err: fmt.Errorf("%q refused: instance %q is not granted %s (list it in the instance's scopes, with security.allow_write_scopes: true)",
+			strings.Join(args, " "), c.InstanceName, models.ScopeWrite),
+	}
+}
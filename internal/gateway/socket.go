@@ -0,0 +1,234 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// socketName is the control socket's file name in the openclaw home
+// directory
+const socketName = "gateway.sock"
+
+// SocketAdapter talks to a gateway on this machine over its control socket:
+// the gateway protocol's JSON frames, one per line, on a Unix socket. No
+// handshake is needed, as only local users who can open the socket reach
+// it. Each request gets its own connection, which is still far cheaper than
+// starting the CLI.
+type SocketAdapter struct {
+	// Path of the control socket
+	Path string
+
+	// Instance name for display
+	InstanceName string
+
+	// Timeout bounds each request (0 = no limit). Requests that exceed it
+	// fail with a *TimeoutError.
+	Timeout time.Duration
+
+	// MaxRecentSessions caps the recent sessions decoded from status, per
+	// list (0 = DefaultMaxRecentSessions)
+	MaxRecentSessions int
+}
+
+// NewSocketAdapter creates an adapter for the control socket at path
+func NewSocketAdapter(name, path string) *SocketAdapter {
+	return &SocketAdapter{InstanceName: name, Path: path}
+}
+
+// DetectSocket returns the control socket of a local gateway, or "" if none
+// is found. It is looked for in home, else in $OPENCLAW_HOME or ~/.openclaw.
+func DetectSocket(home string) string {
+	if home == "" {
+		home = os.Getenv("OPENCLAW_HOME")
+	}
+	if home == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		home = filepath.Join(userHome, ".openclaw")
+	}
+	path := filepath.Join(home, socketName)
+	info, err := os.Stat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return ""
+	}
+	return path
+}
+
+// GetInstanceName returns the instance name
+func (s *SocketAdapter) GetInstanceName() string {
+	return s.InstanceName
+}
+
+// IsRemote returns false; the socket is only reachable locally
+func (s *SocketAdapter) IsRemote() bool {
+	return false
+}
+
+// Close does nothing; connections last one request, or a log stream
+func (s *SocketAdapter) Close() error {
+	return nil
+}
+
+// dial connects to the socket, bounded by ctx
+func (s *SocketAdapter) dial(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("control socket unavailable: %w", err)
+	}
+	return conn, nil
+}
+
+// writeRequest writes a request frame
+func writeRequest(conn net.Conn, id, method string, params any) error {
+	data, err := json.Marshal(wsFrame{Type: "req", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(append(data, '\n'))
+	return err
+}
+
+// awaitResponse reads frames until the response to request id, skipping
+// events and malformed lines
+func awaitResponse(r *bufio.Reader, id, method string) (json.RawMessage, error) {
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var f wsFrame
+			if json.Unmarshal(line, &f) == nil && f.Type == "res" && f.ID == id {
+				if !f.OK {
+					if f.Error != nil {
+						return nil, fmt.Errorf("%s request failed: %w", method, f.Error)
+					}
+					return nil, fmt.Errorf("%s request failed", method)
+				}
+				return f.Payload, nil
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s request failed: %w", method, err)
+		}
+	}
+}
+
+// call sends one request on a fresh connection and returns its response
+// payload, giving up once ctx is done
+func (s *SocketAdapter) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	if err := writeRequest(conn, "1", method, params); err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", method, err)
+	}
+	payload, err := awaitResponse(bufio.NewReader(conn), "1", method)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, &TimeoutError{Command: method, After: s.Timeout}
+	}
+	return payload, err
+}
+
+// GetFullStatus requests the gateway status, as `openclaw status --json`
+// reports it
+func (s *SocketAdapter) GetFullStatus() (*models.OpenClawStatus, error) {
+	maxRecent := s.MaxRecentSessions
+	if maxRecent <= 0 {
+		maxRecent = DefaultMaxRecentSessions
+	}
+	return s.getStatus(context.Background(), nil, maxRecent)
+}
+
+// getStatus requests the gateway status without the exclude sections
+func (s *SocketAdapter) getStatus(ctx context.Context, exclude []string, maxRecent int) (*models.OpenClawStatus, error) {
+	var params any
+	if len(exclude) > 0 {
+		params = map[string]any{"exclude": exclude}
+	}
+	payload, err := s.call(ctx, "status", params)
+	if err != nil {
+		return nil, err
+	}
+	status, err := decodeStatus(bytes.NewReader(payload), maxRecent)
+	if err != nil {
+		return nil, parseError("status", err)
+	}
+	return status, nil
+}
+
+// GetHealthSnapshot requests the gateway health check result
+func (s *SocketAdapter) GetHealthSnapshot() (*models.HealthCheckResult, error) {
+	return s.getHealth(context.Background())
+}
+
+func (s *SocketAdapter) getHealth(ctx context.Context) (*models.HealthCheckResult, error) {
+	payload, err := s.call(ctx, "health", nil)
+	if err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
+	}
+	return parseHealth(string(payload)), nil
+}
+
+// FollowLogs subscribes to the gateway's log events and streams them via
+// logChan until ctx is done or the gateway closes the socket; logChan is
+// then closed
+func (s *SocketAdapter) FollowLogs(ctx context.Context, logChan chan<- models.LogEvent) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	if err := writeRequest(conn, "1", "logs.subscribe", nil); err != nil {
+		conn.Close()
+		return fmt.Errorf("logs.subscribe request failed: %w", err)
+	}
+	r := bufio.NewReader(conn)
+	if _, err := awaitResponse(r, "1", "logs.subscribe"); err != nil {
+		conn.Close()
+		return err
+	}
+
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	go func() {
+		defer close(logChan)
+		defer stop()
+		defer conn.Close()
+		for {
+			line, err := r.ReadBytes('\n')
+			var f wsFrame
+			if json.Unmarshal(line, &f) == nil && f.Type == "event" && f.Event == "log" {
+				if event, ok := decodeLogPayload(f.Payload); ok {
+					select {
+					case logChan <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return nil
+}
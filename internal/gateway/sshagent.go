@@ -0,0 +1,173 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// askpassScript hands ssh-add the passphrase from its environment, once: a
+// wrong passphrase makes ssh-add ask again, and the empty answer that
+// follows makes it give up instead of looping
+const askpassScript = `#!/bin/sh
+[ -e "$LAZYCLAW_ASKPASS_USED" ] && exit 1
+: > "$LAZYCLAW_ASKPASS_USED"
+printf '%s\n' "$LAZYCLAW_ASKPASS"
+`
+
+// ErrBadPassphrase is returned by UnlockKey when the passphrase is wrong
+var ErrBadPassphrase = errors.New("incorrect passphrase")
+
+// privateAgent is an ssh-agent started by lazyclaw to hold keys unlocked in
+// the TUI. It is ended by Shutdown, taking the keys with it.
+var privateAgent struct {
+	once    sync.Once
+	dir     string // Private directory holding the socket and askpass script
+	socket  string
+	askpass string
+	err     error
+}
+
+// startPrivateAgent starts the private agent on first use
+func startPrivateAgent() (socket, askpass string, err error) {
+	p := &privateAgent
+	p.once.Do(func() {
+		if p.dir, p.err = os.MkdirTemp("", "lazyclaw-agent-"); p.err != nil {
+			return
+		}
+		p.askpass = filepath.Join(p.dir, "askpass")
+		if p.err = os.WriteFile(p.askpass, []byte(askpassScript), 0700); p.err != nil {
+			return
+		}
+		p.socket = filepath.Join(p.dir, "agent.sock")
+		cmd := command(shutdownCtx, "ssh-agent", "-D", "-a", p.socket)
+		if p.err = cmd.Start(); p.err != nil {
+			p.err = fmt.Errorf("ssh-agent failed to start: %w", p.err)
+			return
+		}
+		go func() {
+			_ = cmd.Wait()
+			os.RemoveAll(p.dir)
+		}()
+		for i := 0; i < 50; i++ {
+			if _, err := os.Stat(p.socket); err == nil {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		p.err = errors.New("ssh-agent did not create its socket")
+	})
+	return p.socket, p.askpass, p.err
+}
+
+// agentArgs returns the ssh options selecting the agent: lazyclaw's own once
+// a key has been unlocked for this instance, else ssh.agent_socket if set,
+// else ssh's default ($SSH_AUTH_SOCK)
+func (c *CLIAdapter) agentArgs() []string {
+	c.mu.RLock()
+	socket := c.agentSocket
+	c.mu.RUnlock()
+	if socket == "" {
+		socket = c.SSHConfig.AgentSocket
+	}
+	if socket == "" {
+		return nil
+	}
+	if socket == "none" {
+		return []string{"-o", "IdentityAgent=none"}
+	}
+	return []string{"-o", fmt.Sprintf("IdentityAgent=%q", socket)}
+}
+
+// identityPath returns the instance's identity file with ~ expanded, as ssh
+// would, or "" if it has none
+func (c *CLIAdapter) identityPath() string {
+	if c.SSHConfig == nil || c.SSHConfig.IdentityFile == "" {
+		return ""
+	}
+	return expandHome(c.SSHConfig.IdentityFile)
+}
+
+// expandHome expands a leading ~/ in path to the user's home directory
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// KeyNeedsPassphrase reports whether the instance's identity file is
+// protected by a passphrase. ssh runs in batch mode and cannot ask for it,
+// so such a key is skipped unless it has been unlocked into an agent.
+func (c *CLIAdapter) KeyNeedsPassphrase() bool {
+	path := c.identityPath()
+	if path == "" {
+		return false
+	}
+	// An empty passphrase opens unprotected keys only
+	_, err := c.runHelper(nil, "ssh-keygen", "-y", "-P", "", "-f", path)
+	return err != nil && strings.Contains(err.Error(), "passphrase")
+}
+
+// UnlockKey decrypts the instance's identity file with passphrase into
+// lazyclaw's private ssh-agent, which this instance's ssh commands then use.
+// The passphrase reaches ssh-add through its environment, never its
+// arguments or the disk.
+func (c *CLIAdapter) UnlockKey(passphrase string) error {
+	path := c.identityPath()
+	if path == "" {
+		return errors.New("instance has no identity_file")
+	}
+	if runtime.GOOS == "windows" {
+		// The private agent listens on a Unix socket and asks through a
+		// shell script
+		return errors.New("unlocking keys is not supported on Windows; add the key to the ssh-agent service with ssh-add")
+	}
+	socket, askpass, err := startPrivateAgent()
+	if err != nil {
+		return err
+	}
+
+	used := filepath.Join(privateAgent.dir, fmt.Sprintf("used-%d", time.Now().UnixNano()))
+	defer os.Remove(used)
+	d := newDeadline(c.workContext(), "ssh-add", c.Timeout)
+	cmd := command(d.ctx, "ssh-add", path)
+	cmd.Env = append(os.Environ(),
+		"SSH_AUTH_SOCK="+socket,
+		"SSH_ASKPASS="+askpass,
+		"SSH_ASKPASS_REQUIRE=force",
+		"DISPLAY=:0", // Older ssh-add only uses SSH_ASKPASS with a display set
+		"LAZYCLAW_ASKPASS="+passphrase,
+		"LAZYCLAW_ASKPASS_USED="+used,
+	)
+	var output []byte
+	runChild(func() {
+		d.start()
+		output, err = cmd.CombinedOutput()
+	})
+	if timeoutErr := d.finish(); timeoutErr != nil {
+		return timeoutErr
+	}
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			if msg := strings.TrimSpace(string(output)); msg != "" && !strings.Contains(msg, "passphrase") {
+				return fmt.Errorf("ssh-add failed: %s", msg)
+			}
+			return ErrBadPassphrase
+		}
+		return fmt.Errorf("ssh-add failed: %w", err)
+	}
+
+	c.mu.Lock()
+	c.agentSocket = socket
+	c.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,266 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// DefaultMaxRecentSessions caps each recent-session list decoded from status.
+// Gateways with thousands of sessions report them all; Sessions.Count keeps
+// the real total.
+const DefaultMaxRecentSessions = 500
+
+func (c *CLIAdapter) maxRecentSessions() int {
+	if c.MaxRecentSessions > 0 {
+		return c.MaxRecentSessions
+	}
+	return DefaultMaxRecentSessions
+}
+
+// MaxRawStatusBytes caps the raw status JSON kept for a partial parse
+const MaxRawStatusBytes = 1 << 20
+
+// decodeStatus decodes `openclaw status --json` from r one section at a
+// time. Session lists are decoded element by element and only the first
+// maxRecent are kept, so memory stays bounded however many sessions the
+// gateway reports.
+//
+// A value of an unexpected type (a string where a number belongs, an array
+// where an object does) is skipped rather than failing the whole status: the
+// rest decodes as usual, the skipped fields are listed in ParseErrors, and
+// the raw JSON, up to MaxRawStatusBytes, is attached. Only malformed JSON
+// is an error.
+func decodeStatus(r io.Reader, maxRecent int) (*models.OpenClawStatus, error) {
+	raw := &cappedBuffer{limit: MaxRawStatusBytes}
+	dec := json.NewDecoder(io.TeeReader(r, raw))
+	var status models.OpenClawStatus
+	err := decodeObject(dec, func(key string) error {
+		if key == "sessions" {
+			sessions, err := decodeSessions(dec, maxRecent, &status.ParseErrors)
+			status.Sessions = sessions
+			return tolerate(&status.ParseErrors, key, err)
+		}
+		return tolerate(&status.ParseErrors, key, decodeField(dec, &status, key))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(status.ParseErrors) > 0 {
+		status.Raw = raw.String()
+	}
+	return &status, nil
+}
+
+func decodeSessions(dec *json.Decoder, maxRecent int, skipped *[]string) (*models.Sessions, error) {
+	var sessions models.Sessions
+	err := decodeObject(dec, func(key string) error {
+		switch key {
+		case "recent":
+			recent, err := decodeSessionList(dec, maxRecent, skipped, "sessions.recent")
+			sessions.Recent = recent
+			return tolerate(skipped, "sessions.recent", err)
+		case "byAgent":
+			return tolerate(skipped, "sessions.byAgent", decodeArray(dec, func() error {
+				var group models.AgentSession
+				err := decodeObject(dec, func(key string) error {
+					if key == "recent" {
+						recent, err := decodeSessionList(dec, maxRecent, skipped, "sessions.byAgent.recent")
+						group.Recent = recent
+						return tolerate(skipped, "sessions.byAgent.recent", err)
+					}
+					return tolerate(skipped, "sessions.byAgent."+key, decodeField(dec, &group, key))
+				})
+				sessions.ByAgent = append(sessions.ByAgent, group)
+				return tolerate(skipped, "sessions.byAgent", err)
+			}))
+		}
+		return tolerate(skipped, "sessions."+key, decodeField(dec, &sessions, key))
+	})
+	return &sessions, err
+}
+
+// decodeSessionList decodes up to limit sessions of an array, skipping the rest
+func decodeSessionList(dec *json.Decoder, limit int, skipped *[]string, path string) ([]models.Session, error) {
+	var list []models.Session
+	err := decodeArray(dec, func() error {
+		if len(list) >= limit {
+			return skipValue(dec)
+		}
+		var sess models.Session
+		if err := tolerate(skipped, path, dec.Decode(&sess)); err != nil {
+			return err
+		}
+		list = append(list, sess)
+		return nil
+	})
+	return list, err
+}
+
+// shapeError is a value that is not the object or array expected. The value
+// has been consumed, so decoding can go on past it.
+type shapeError struct {
+	want string
+	got  any
+}
+
+func (e *shapeError) Error() string {
+	return fmt.Sprintf("expected %s, got %v", e.want, e.got)
+}
+
+// tolerate records err in skipped, under path, and returns nil if it is a
+// value of the wrong type, which the decoder has already moved past; other
+// errors are returned
+func tolerate(skipped *[]string, path string, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var shapeErr *shapeError
+	switch {
+	case errors.As(err, &typeErr):
+		if typeErr.Field != "" {
+			path += "." + typeErr.Field
+		}
+		err = fmt.Errorf("%s: %s for %s", path, typeErr.Value, typeErr.Type)
+	case errors.As(err, &shapeErr):
+		err = fmt.Errorf("%s: %w", path, shapeErr)
+	default:
+		return err
+	}
+	if !slices.Contains(*skipped, err.Error()) {
+		*skipped = append(*skipped, err.Error())
+	}
+	return nil
+}
+
+// cappedBuffer keeps the first limit bytes written to it and discards the
+// rest
+type cappedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// decodeObject walks a JSON object (or null), calling field for each key with
+// the decoder positioned at its value; field must consume the value
+func decodeObject(dec *json.Decoder, field func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil || tok == nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return wrongShape(dec, "object", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := field(tok.(string)); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// decodeArray walks a JSON array (or null), calling elem with the decoder
+// positioned at each element; elem must consume it
+func decodeArray(dec *json.Decoder, elem func() error) error {
+	tok, err := dec.Token()
+	if err != nil || tok == nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return wrongShape(dec, "array", tok)
+	}
+	for dec.More() {
+		if err := elem(); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// wrongShape consumes the rest of a value that began with tok instead of the
+// object or array wanted, and describes it
+func wrongShape(dec *json.Decoder, want string, tok json.Token) error {
+	got := tok
+	if delim, ok := tok.(json.Delim); ok {
+		got = map[json.Delim]string{'{': "an object", '[': "an array"}[delim]
+		if err := skipRest(dec, 1); err != nil {
+			return err
+		}
+	}
+	return &shapeError{want: want, got: got}
+}
+
+// skipValue consumes the next value token by token without building it
+func skipValue(dec *json.Decoder) error {
+	return skipRest(dec, 0)
+}
+
+// skipRest consumes tokens until depth nested objects and arrays are closed,
+// or a whole value if depth is 0
+func skipRest(dec *json.Decoder, depth int) error {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// decodeField decodes the next value into the field of the struct pointed to
+// by v whose JSON name is key; unknown keys are skipped
+func decodeField(dec *json.Decoder, v any, key string) error {
+	target := reflect.ValueOf(v).Elem()
+	idx, ok := jsonFields(target.Type())[key]
+	if !ok {
+		return skipValue(dec)
+	}
+	return dec.Decode(target.Field(idx).Addr().Interface())
+}
+
+var jsonFieldCache sync.Map // reflect.Type -> map[string]int
+
+// jsonFields maps a struct type's JSON field names to field indexes
+func jsonFields(t reflect.Type) map[string]int {
+	if cached, ok := jsonFieldCache.Load(t); ok {
+		return cached.(map[string]int)
+	}
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		if name != "-" {
+			fields[name] = i
+		}
+	}
+	jsonFieldCache.Store(t, fields)
+	return fields
+}
@@ -0,0 +1,140 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// tailscaleTimeout bounds `tailscale status`, which only asks the local daemon
+const tailscaleTimeout = 10 * time.Second
+
+// TailscaleDiscovery sets how DiscoverTailscale looks for gateways on the
+// tailnet
+type TailscaleDiscovery struct {
+	Tag            string        // Only probe peers with this ACL tag ("" = every peer)
+	User           string        // SSH user for the probes
+	KnownHostsFile string        // Where host keys of probed peers are checked and recorded
+	Timeout        time.Duration // Bounds each probe
+}
+
+// TailscalePeer is a tailnet peer found by DiscoverTailscale
+type TailscalePeer struct {
+	Name    string // Machine name, e.g. "gw-1"
+	Host    string // MagicDNS name, e.g. "gw-1.tail1234.ts.net"
+	IP      string // First Tailscale IP
+	OS      string
+	Online  bool
+	Version string // openclaw release found on the peer, "" if none was
+	Err     error  // Why the probe found no openclaw, if it ran
+}
+
+// tailscaleStatus is the part of `tailscale status --json` discovery reads
+type tailscaleStatus struct {
+	Peer map[string]struct {
+		HostName     string   `json:"HostName"`
+		DNSName      string   `json:"DNSName"`
+		TailscaleIPs []string `json:"TailscaleIPs"`
+		OS           string   `json:"OS"`
+		Online       bool     `json:"Online"`
+		Tags         []string `json:"Tags"`
+	} `json:"Peer"`
+}
+
+// DiscoverTailscale lists the peers of the local tailnet and probes each one
+// online over SSH for openclaw, in parallel. Peers are returned sorted by
+// name; those running openclaw have Version set.
+func DiscoverTailscale(opts TailscaleDiscovery) ([]TailscalePeer, error) {
+	output, err := runTailscale("status", "--json")
+	if err != nil {
+		return nil, err
+	}
+	var status tailscaleStatus
+	if err := json.Unmarshal([]byte(output), &status); err != nil {
+		return nil, parseError("tailscale status", err)
+	}
+
+	var peers []TailscalePeer
+	for _, p := range status.Peer {
+		if opts.Tag != "" && !slices.Contains(p.Tags, opts.Tag) {
+			continue
+		}
+		peer := TailscalePeer{
+			Name:   p.HostName,
+			Host:   strings.TrimSuffix(p.DNSName, "."),
+			OS:     p.OS,
+			Online: p.Online,
+		}
+		if len(p.TailscaleIPs) > 0 {
+			peer.IP = p.TailscaleIPs[0]
+		}
+		if peer.Host == "" {
+			peer.Host = peer.IP
+		}
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Name < peers[j].Name })
+
+	var wg sync.WaitGroup
+	for i := range peers {
+		if !peers[i].Online || peers[i].Host == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(peer *TailscalePeer) {
+			defer wg.Done()
+			peer.Version, peer.Err = probeOpenClaw(peer.Host, opts)
+		}(&peers[i])
+	}
+	wg.Wait()
+	return peers, nil
+}
+
+// probeOpenClaw asks host for its openclaw version over SSH, without
+// prompting for anything
+func probeOpenClaw(host string, opts TailscaleDiscovery) (string, error) {
+	probe := NewSSHCLIAdapter(host, &models.SSHConfig{
+		Host:           host,
+		User:           opts.User,
+		ConnectTimeout: int(max(opts.Timeout/time.Second, 1)),
+	}, "")
+	probe.KnownHostsFile = opts.KnownHostsFile
+	probe.Timeout = opts.Timeout
+	return probe.Version()
+}
+
+// runTailscale runs the local tailscale CLI
+func runTailscale(args ...string) (string, error) {
+	d := newDeadline(shutdownCtx, "tailscale "+args[0], tailscaleTimeout)
+	cmd := command(d.ctx, "tailscale", args...)
+	var output []byte
+	var err error
+	runChild(func() {
+		d.start()
+		output, err = cmd.Output()
+	})
+	if err := d.finish(); err != nil {
+		return "", err
+	}
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			msg := strings.TrimSpace(string(exitErr.Stderr))
+			return "", commandFailure(err, msg, fmt.Errorf("tailscale %s failed: %s", args[0], msg))
+		}
+		return "", commandFailure(err, "", fmt.Errorf("tailscale failed: %w", err))
+	}
+	return string(output), nil
+}
+
+// CheckTailscaleAvailable checks if the tailscale CLI is available
+func CheckTailscaleAvailable() bool {
+	_, err := exec.LookPath("tailscale")
+	return err == nil
+}
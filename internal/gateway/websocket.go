@@ -0,0 +1,260 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// wsAcceptGUID is appended to the handshake key to derive the accept key
+// (RFC 6455 section 1.3)
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// wsMaxMessage bounds one message; status payloads of very large gateways
+// are well under it
+const wsMaxMessage = 64 << 20
+
+// WebSocket frame opcodes
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// wsConn is a minimal WebSocket client connection: JSON text messages, no
+// extensions or compression, which is all the gateway protocol uses. The
+// standard library has no WebSocket client.
+type wsConn struct {
+	conn net.Conn
+	br   *bufio.Reader
+	wmu  sync.Mutex // Serializes frame writes, including pongs sent by the reader
+}
+
+// dialWebSocket opens a WebSocket connection to a ws:// or wss:// URL. ctx
+// bounds the TCP/TLS connection and the HTTP upgrade only.
+func dialWebSocket(ctx context.Context, rawURL string) (*wsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway URL: %w", err)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "wss" {
+			port = "443"
+		}
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{}
+	var conn net.Conn
+	switch u.Scheme {
+	case "ws":
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	case "wss":
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: u.Hostname()}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	default:
+		return nil, fmt.Errorf("unsupported gateway URL scheme %q (use ws:// or wss://)", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gateway connection failed: %w", err)
+	}
+
+	// Abort the blocking handshake I/O if ctx ends first
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	br, err := wsHandshake(conn, u)
+	if !stop() {
+		err = ctx.Err()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return &wsConn{conn: conn, br: br}, nil
+}
+
+// wsHandshake sends the HTTP upgrade request and checks the server accepted it
+func wsHandshake(conn net.Conn, u *url.URL) (*bufio.Reader, error) {
+	var nonce [16]byte
+	_, _ = rand.Read(nonce[:])
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{Path: u.Path, RawQuery: u.RawQuery},
+		Host:   u.Host,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     {key},
+			"Sec-WebSocket-Version": {"13"},
+		},
+	}
+	if req.URL.Path == "" {
+		req.URL.Path = "/"
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("WebSocket handshake failed: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, fmt.Errorf("WebSocket handshake failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("WebSocket handshake failed: %s", resp.Status)
+	}
+	sum := sha1.Sum([]byte(key + wsAcceptGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		return nil, errors.New("WebSocket handshake failed: bad Sec-WebSocket-Accept")
+	}
+	return br, nil
+}
+
+// readMessage returns the next text message, answering pings on the way
+func (c *wsConn) readMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			_ = c.writeFrame(wsOpClose, payload)
+			return nil, wsCloseError(payload)
+		}
+		msg = append(msg, payload...)
+		if len(msg) > wsMaxMessage {
+			return nil, errors.New("gateway message too large")
+		}
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+// readFrame reads one frame, unmasking its payload if needed
+func (c *wsConn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err = io.ReadFull(c.br, hdr[:]); err != nil {
+		return
+	}
+	fin = hdr[0]&0x80 != 0
+	op = hdr[0] & 0x0f
+	masked := hdr[1]&0x80 != 0
+
+	n := uint64(hdr[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if n > wsMaxMessage {
+		err = errors.New("gateway message too large")
+		return
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, n)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return
+}
+
+// writeText sends a text message in a single frame
+func (c *wsConn) writeText(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+// writeFrame sends a final frame. Client frames are always masked.
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	buf := make([]byte, 0, 14+len(payload))
+	buf = append(buf, 0x80|op)
+	switch n := len(payload); {
+	case n < 126:
+		buf = append(buf, 0x80|byte(n))
+	case n <= 0xffff:
+		buf = append(buf, 0x80|126)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(n))
+	default:
+		buf = append(buf, 0x80|127)
+		buf = binary.BigEndian.AppendUint64(buf, uint64(n))
+	}
+	var mask [4]byte
+	_, _ = rand.Read(mask[:])
+	buf = append(buf, mask[:]...)
+	for i, b := range payload {
+		buf = append(buf, b^mask[i%4])
+	}
+	_, err := c.conn.Write(buf)
+	return err
+}
+
+// close sends a normal closure frame and closes the connection; a reader
+// blocked in readMessage returns an error
+func (c *wsConn) close() error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(shutdownGrace))
+	_ = c.writeFrame(wsOpClose, binary.BigEndian.AppendUint16(nil, 1000))
+	return c.conn.Close()
+}
+
+// wsCloseError describes a close frame sent by the gateway
+func wsCloseError(payload []byte) error {
+	if len(payload) < 2 {
+		return errors.New("connection closed by gateway")
+	}
+	code := binary.BigEndian.Uint16(payload)
+	if reason := string(payload[2:]); reason != "" {
+		return fmt.Errorf("connection closed by gateway: %s (%d)", reason, code)
+	}
+	return fmt.Errorf("connection closed by gateway (%d)", code)
+}
@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// localBinary returns the openclaw binary local commands run
+func (c *CLIAdapter) localBinary() string {
+	if c.BinaryPath != "" {
+		return expandWindowsVars(c.BinaryPath)
+	}
+	return findLocalBinary()
+}
+
+// findLocalBinary returns the openclaw found locally. On Windows, where a
+// terminal's PATH often lacks it, npm's and the installer's locations are
+// also looked in.
+func findLocalBinary() string {
+	if runtime.GOOS != "windows" {
+		return "openclaw"
+	}
+	// LookPath tries openclaw.exe, openclaw.cmd and the rest of PATHEXT
+	if path, err := exec.LookPath("openclaw"); err == nil {
+		return path
+	}
+	var candidates []string
+	if appData := os.Getenv("APPDATA"); appData != "" {
+		candidates = append(candidates, filepath.Join(appData, "npm", "openclaw.cmd"))
+	}
+	if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+		candidates = append(candidates, filepath.Join(localAppData, "Programs", "openclaw", "openclaw.exe"))
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return "openclaw"
+}
+
+// windowsVarPattern matches %NAME% references in Windows paths
+var windowsVarPattern = regexp.MustCompile(`%(\w+)%`)
+
+// expandWindowsVars expands %NAME% references, such as %APPDATA%, in a
+// local path on Windows. Unset variables are left as written.
+func expandWindowsVars(path string) string {
+	if runtime.GOOS != "windows" {
+		return path
+	}
+	return windowsVarPattern.ReplaceAllStringFunc(path, func(ref string) string {
+		if value, ok := os.LookupEnv(ref[1 : len(ref)-1]); ok {
+			return value
+		}
+		return ref
+	})
+}
+
+// remoteShell returns the shell remote commands run in: ssh.shell, or bash.
+// Pods always get sh, which takes the same syntax.
+func (c *CLIAdapter) remoteShell() string {
+	if c.isK8s() || c.SSHConfig == nil || c.SSHConfig.Shell == "" {
+		return models.RemoteShellBash
+	}
+	return c.SSHConfig.Shell
+}
+
+// posixRemote reports whether remote commands run in a POSIX shell. The
+// scripts lazyclaw runs beyond openclaw itself, such as batched fetches and
+// process and file listings, need one.
+func (c *CLIAdapter) posixRemote() bool {
+	return c.remoteShell() == models.RemoteShellBash
+}
+
+// needsPOSIXShell is returned for a remote script a Windows host's shell
+// cannot run
+func (c *CLIAdapter) needsPOSIXShell() error {
+	return fmt.Errorf("needs a POSIX shell on the host (ssh.shell is %s): %w", c.remoteShell(), ErrUnsupported)
+}
+
+// quoteRemoteArg quotes arg for the remote shell if it needs it
+func (c *CLIAdapter) quoteRemoteArg(arg string) string {
+	switch c.remoteShell() {
+	case models.RemoteShellPowerShell:
+		// Unquoted, commas and @ mean something to PowerShell
+		return "'" + strings.ReplaceAll(arg, "'", "''") + "'"
+	case models.RemoteShellCmd:
+		if !needsQuoting(arg) {
+			return arg
+		}
+		return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+	}
+	if !needsQuoting(arg) {
+		return arg
+	}
+	return shellQuote(arg)
+}
+
+// wrapRemote returns the command line the remote host runs script with.
+// bash runs as a login shell so the remote user's PATH (e.g. linuxbrew,
+// nvm) is loaded; non-interactive SSH doesn't source .bashrc/.profile.
+func (c *CLIAdapter) wrapRemote(script string) string {
+	switch c.remoteShell() {
+	case models.RemoteShellPowerShell:
+		// Encoded, the script passes through whatever shell sshd starts
+		// without being reinterpreted
+		encoded := utf16.Encode([]rune(script))
+		raw := make([]byte, 0, 2*len(encoded))
+		for _, u := range encoded {
+			raw = append(raw, byte(u), byte(u>>8))
+		}
+		return "powershell -NoProfile -NonInteractive -EncodedCommand " + base64.StdEncoding.EncodeToString(raw)
+	case models.RemoteShellCmd:
+		return `cmd /d /s /c "` + script + `"`
+	}
+	return "bash -lc " + shellQuote(script)
+}
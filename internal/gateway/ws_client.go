@@ -0,0 +1,539 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// Gateway protocol versions this client speaks
+const (
+	wsProtocolMin = 1
+	wsProtocolMax = 3
+)
+
+// wsLogBuffer is how many log events are queued per subscriber before
+// further ones are dropped; the reader never blocks on a slow subscriber
+const wsLogBuffer = 256
+
+// WSClient talks to an OpenClaw gateway directly over its WebSocket
+// protocol instead of through the openclaw CLI. Requests and responses are
+// JSON text frames matched by id; the gateway also pushes events, such as
+// log lines to subscribers.
+//
+// The connection is opened by the first request, and opened again by the
+// next one after it is lost; Connect opens it up front.
+//
+// Only token auth is supported. Gateways requiring device pairing must be
+// reached from localhost (directly or through an SSH tunnel), which
+// OpenClaw approves automatically.
+type WSClient struct {
+	// Gateway URL, e.g. ws://127.0.0.1:18789 or wss://gw.example.com
+	URL string
+
+	// Sent as connect.params.auth.token (empty = none)
+	Token string
+
+	// Operator scopes requested in the handshake. Callers should request
+	// write scopes only when the user has allowed them.
+	Scopes []string
+
+	// Instance name for display
+	InstanceName string
+
+	// Timeout bounds the handshake and each request (0 = no limit).
+	// Requests that exceed it fail with a *TimeoutError.
+	Timeout time.Duration
+
+	dialMu  sync.Mutex // Held while a request opens the connection
+	mu      sync.Mutex
+	conn    *wsConn
+	done    chan struct{} // Closed when the connection is lost or closed
+	nextID  int
+	pending map[string]chan wsFrame
+	logSubs map[*wsLogSub]struct{}
+	state   models.ConnectionState
+}
+
+// wsFrame is a gateway protocol message: a request, its response, or an
+// event pushed by the gateway
+type wsFrame struct {
+	Type    string          `json:"type"` // "req", "res" or "event"
+	ID      string          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	OK      bool            `json:"ok,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Error   *WSError        `json:"error,omitempty"`
+	Event   string          `json:"event,omitempty"`
+}
+
+// WSError is an error response from the gateway, such as a rejected token
+type WSError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *WSError) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+// wsLogSub forwards log events to one FollowLogs caller
+type wsLogSub struct {
+	in chan models.LogEvent
+}
+
+// NewWSClient creates a client for the gateway at url
+func NewWSClient(name, url, token string, scopes []string) *WSClient {
+	return &WSClient{
+		InstanceName: name,
+		URL:          url,
+		Token:        token,
+		Scopes:       scopes,
+	}
+}
+
+// GetInstanceName returns the instance name
+func (c *WSClient) GetInstanceName() string {
+	return c.InstanceName
+}
+
+// IsRemote returns true unless the gateway URL points at this machine
+func (c *WSClient) IsRemote() bool {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return true
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// ConnectionState returns the state of the current or last connection
+func (c *WSClient) ConnectionState() models.ConnectionState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// withTimeout derives a context bounded by the client's Timeout
+func (c *WSClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.Timeout)
+}
+
+// timeoutError reports a context that ended as a *TimeoutError for method if
+// it was the client's own deadline that expired
+func (c *WSClient) timeoutError(ctx context.Context, method string) error {
+	if c.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &TimeoutError{Command: method, After: c.Timeout}
+	}
+	return ctx.Err()
+}
+
+// Connect opens the connection and performs the handshake: the gateway sends
+// a connect.challenge event, the client answers with a connect request
+// carrying its role, scopes and token, and the gateway accepts or rejects it.
+func (c *WSClient) Connect(ctx context.Context) (ConnectedMsg, error) {
+	msg, err := c.connect(ctx)
+	c.mu.Lock()
+	if err != nil {
+		c.state.Connected = false
+		c.state.LastError = err.Error()
+	}
+	c.mu.Unlock()
+	return msg, err
+}
+
+func (c *WSClient) connect(ctx context.Context) (ConnectedMsg, error) {
+	c.Close()
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	conn, err := dialWebSocket(ctx, c.URL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ConnectedMsg{}, c.timeoutError(ctx, "connect")
+		}
+		return ConnectedMsg{}, err
+	}
+	// The handshake is read before the read loop starts; abort it with ctx
+	stop := context.AfterFunc(ctx, func() { _ = conn.conn.SetDeadline(time.Unix(1, 0)) })
+	hello, err := c.handshake(conn)
+	if !stop() {
+		err = c.timeoutError(ctx, "connect")
+	}
+	if err != nil {
+		conn.close()
+		return ConnectedMsg{}, err
+	}
+	_ = conn.conn.SetDeadline(time.Time{})
+
+	msg := ConnectedMsg{
+		Scopes:          hello.Auth.Scopes,
+		ProtocolVersion: strconv.Itoa(hello.Protocol),
+		GatewayVersion:  hello.Server.Version,
+	}
+	if msg.Scopes == nil {
+		// The gateway granted what was asked for
+		msg.Scopes = c.Scopes
+	}
+
+	done := make(chan struct{})
+	c.mu.Lock()
+	c.conn = conn
+	c.done = done
+	c.pending = make(map[string]chan wsFrame)
+	c.state = models.ConnectionState{
+		Connected:       true,
+		LastHandshake:   time.Now(),
+		Scopes:          msg.Scopes,
+		ProtocolVersion: msg.ProtocolVersion,
+		GatewayVersion:  msg.GatewayVersion,
+	}
+	c.mu.Unlock()
+	go c.readLoop(conn, done)
+	return msg, nil
+}
+
+// ensureConnected opens the connection unless it is open. Requests made
+// while it opens wait for it rather than opening another.
+func (c *WSClient) ensureConnected(ctx context.Context) error {
+	c.dialMu.Lock()
+	defer c.dialMu.Unlock()
+	c.mu.Lock()
+	open := c.conn != nil
+	c.mu.Unlock()
+	if open {
+		return nil
+	}
+	_, err := c.Connect(ctx)
+	return err
+}
+
+// wsHello is the payload of an accepted connect request
+type wsHello struct {
+	Protocol int `json:"protocol"`
+	Server   struct {
+		Version string `json:"version"`
+	} `json:"server"`
+	Auth struct {
+		Scopes []string `json:"scopes"`
+	} `json:"auth"`
+}
+
+// handshake runs the connect exchange on a fresh connection
+func (c *WSClient) handshake(conn *wsConn) (wsHello, error) {
+	var hello wsHello
+	challenge, err := readFrame(conn)
+	if err != nil {
+		return hello, fmt.Errorf("gateway handshake failed: %w", err)
+	}
+	if challenge.Type != "event" || challenge.Event != "connect.challenge" {
+		return hello, fmt.Errorf("gateway handshake failed: expected connect.challenge, got %s %s", challenge.Type, challenge.Event)
+	}
+
+	params := map[string]any{
+		"minProtocol": wsProtocolMin,
+		"maxProtocol": wsProtocolMax,
+		"role":        "operator",
+		"scopes":      c.Scopes,
+		"client": map[string]string{
+			"id":       "lazyclaw",
+			"platform": runtime.GOOS,
+			"mode":     "operator",
+		},
+	}
+	if c.Token != "" {
+		params["auth"] = map[string]string{"token": c.Token}
+	}
+	data, _ := json.Marshal(wsFrame{Type: "req", ID: "connect", Method: "connect", Params: params})
+	if err := conn.writeText(data); err != nil {
+		return hello, fmt.Errorf("gateway handshake failed: %w", err)
+	}
+
+	resp, err := readFrame(conn)
+	if err != nil {
+		return hello, fmt.Errorf("gateway handshake failed: %w", err)
+	}
+	if resp.Type != "res" || resp.ID != "connect" {
+		return hello, fmt.Errorf("gateway handshake failed: unexpected %s frame", resp.Type)
+	}
+	if !resp.OK {
+		if resp.Error != nil {
+			return hello, fmt.Errorf("gateway rejected connection: %w", resp.Error)
+		}
+		return hello, errors.New("gateway rejected connection")
+	}
+	if err := json.Unmarshal(resp.Payload, &hello); err != nil {
+		return hello, fmt.Errorf("failed to parse gateway hello: %w", err)
+	}
+	return hello, nil
+}
+
+// readFrame reads and decodes the next protocol message
+func readFrame(conn *wsConn) (wsFrame, error) {
+	var f wsFrame
+	data, err := conn.readMessage()
+	if err != nil {
+		return f, err
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("invalid gateway message: %w", err)
+	}
+	return f, nil
+}
+
+// readLoop routes responses to waiting requests and events to subscribers
+// until the connection ends
+func (c *WSClient) readLoop(conn *wsConn, done chan struct{}) {
+	for {
+		data, err := conn.readMessage()
+		if err != nil {
+			c.lost(conn, done, err)
+			return
+		}
+		var f wsFrame
+		if err := json.Unmarshal(data, &f); err != nil {
+			// Skip a frame that does not decode, whatever is wrong with it
+			continue
+		}
+		switch f.Type {
+		case "res":
+			c.mu.Lock()
+			ch := c.pending[f.ID]
+			delete(c.pending, f.ID)
+			c.mu.Unlock()
+			if ch != nil {
+				ch <- f
+			}
+		case "event":
+			if f.Event == "log" {
+				c.dispatchLog(f.Payload)
+			}
+		}
+	}
+}
+
+// lost records why the connection ended and releases everything waiting on
+// it. A connection replaced or closed by the caller is not an error.
+func (c *WSClient) lost(conn *wsConn, done chan struct{}, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == conn {
+		c.conn = nil
+		c.state.Connected = false
+		c.state.LastError = err.Error()
+		conn.close()
+	}
+	close(done)
+}
+
+// Call sends a request, opening the connection first if needed, and
+// decodes its response payload into out, which may be nil
+func (c *WSClient) Call(ctx context.Context, method string, params, out any) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	conn, done := c.conn, c.done
+	if conn == nil {
+		c.mu.Unlock()
+		return errors.New("not connected to gateway")
+	}
+	c.nextID++
+	id := strconv.Itoa(c.nextID)
+	ch := make(chan wsFrame, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	release := func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}
+	data, err := json.Marshal(wsFrame{Type: "req", ID: id, Method: method, Params: params})
+	if err != nil {
+		release()
+		return err
+	}
+	if err := conn.writeText(data); err != nil {
+		release()
+		return fmt.Errorf("%s request failed: %w", method, err)
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	select {
+	case f := <-ch:
+		if !f.OK {
+			if f.Error != nil {
+				return fmt.Errorf("%s request failed: %w", method, f.Error)
+			}
+			return fmt.Errorf("%s request failed", method)
+		}
+		if out == nil {
+			return nil
+		}
+		if err := json.Unmarshal(f.Payload, out); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", method, err)
+		}
+		return nil
+	case <-done:
+		return fmt.Errorf("%s request failed: %s", method, c.ConnectionState().LastError)
+	case <-ctx.Done():
+		release()
+		return c.timeoutError(ctx, method)
+	}
+}
+
+// GetFullStatus requests the gateway status, as `openclaw status --json`
+// reports it
+func (c *WSClient) GetFullStatus() (*models.OpenClawStatus, error) {
+	var status models.OpenClawStatus
+	if err := c.Call(context.Background(), "status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetHealthSnapshot requests the gateway health check result
+func (c *WSClient) GetHealthSnapshot() (*models.HealthCheckResult, error) {
+	var result models.HealthCheckResult
+	if err := c.Call(context.Background(), "health", nil, &result); err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
+	}
+	return &result, nil
+}
+
+// FollowLogs subscribes to the gateway's log events and streams them via
+// logChan until ctx is done or the connection is lost; logChan is then
+// closed
+func (c *WSClient) FollowLogs(ctx context.Context, logChan chan<- models.LogEvent) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	done := c.done
+	if c.conn == nil {
+		c.mu.Unlock()
+		return errors.New("not connected to gateway")
+	}
+	sub := &wsLogSub{in: make(chan models.LogEvent, wsLogBuffer)}
+	if c.logSubs == nil {
+		c.logSubs = make(map[*wsLogSub]struct{})
+	}
+	c.logSubs[sub] = struct{}{}
+	c.mu.Unlock()
+
+	unsubscribe := func() {
+		c.mu.Lock()
+		delete(c.logSubs, sub)
+		c.mu.Unlock()
+	}
+	if err := c.Call(ctx, "logs.subscribe", nil, nil); err != nil {
+		unsubscribe()
+		return err
+	}
+
+	go func() {
+		defer close(logChan)
+		defer unsubscribe()
+		for {
+			select {
+			case event := <-sub.in:
+				select {
+				case logChan <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// wsLogPayload is the payload of a log event
+type wsLogPayload struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Subsystem string `json:"subsystem"`
+	Message   string `json:"message"`
+	Line      string `json:"line"` // The raw line, when the gateway sends it
+}
+
+// dispatchLog hands a log event to every subscriber with room for it
+func (c *WSClient) dispatchLog(payload json.RawMessage) {
+	event, ok := decodeLogPayload(payload)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for sub := range c.logSubs {
+		select {
+		case sub.in <- event:
+		default:
+		}
+	}
+}
+
+// decodeLogPayload converts the payload of a log event
+func decodeLogPayload(payload json.RawMessage) (models.LogEvent, bool) {
+	var p wsLogPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return models.LogEvent{}, false
+	}
+	event := models.LogEvent{
+		Timestamp: time.Now(),
+		Level:     p.Level,
+		Source:    p.Subsystem,
+		Message:   p.Message,
+		Raw:       string(payload),
+	}
+	if p.Line != "" {
+		event = parseLogLine(p.Line)
+	} else if t, err := time.Parse(time.RFC3339, p.Time); err == nil {
+		event.Timestamp = t
+	}
+	if event.Level == "" {
+		event.Level = "info"
+	}
+	return event, true
+}
+
+// Close closes the connection, if open. Pending requests fail and log
+// streams end.
+func (c *WSClient) Close() error {
+	c.mu.Lock()
+	conn := c.conn
+	c.conn = nil
+	c.state.Connected = false
+	c.mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+	return conn.close()
+}
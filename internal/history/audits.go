@@ -0,0 +1,147 @@
+package history
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+const auditsStream = "audits"
+
+// AuditRecord is a persisted security audit run
+type AuditRecord struct {
+	Time  time.Time            `json:"time"`
+	Audit models.SecurityAudit `json:"audit"`
+}
+
+// SeverityChange is a finding whose severity changed between runs
+type SeverityChange struct {
+	Finding models.SecurityAuditFinding
+	From    string
+}
+
+// AuditDiff describes what changed between two audit runs
+type AuditDiff struct {
+	PreviousTime time.Time
+	New          []models.SecurityAuditFinding
+	Resolved     []models.SecurityAuditFinding
+	Changed      []SeverityChange
+}
+
+// Empty returns true if nothing changed
+func (d *AuditDiff) Empty() bool {
+	return len(d.New) == 0 && len(d.Resolved) == 0 && len(d.Changed) == 0
+}
+
+// IsNew returns true if the finding first appeared in the current run
+func (d *AuditDiff) IsNew(f models.SecurityAuditFinding) bool {
+	for _, n := range d.New {
+		if findingKey(n) == findingKey(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordAudit persists the audit if it is a new run and returns the diff
+// against the previous run, or nil if there is no previous run.
+// Safe to call on a nil store.
+func (s *Store) RecordAudit(instance string, audit *models.SecurityAudit) (*AuditDiff, error) {
+	if s == nil || audit == nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.audits == nil {
+		s.audits = make(map[string][]AuditRecord)
+	}
+	recent, ok := s.audits[instance]
+	if !ok {
+		// Load the last two runs from disk on first use
+		err := s.readRecords(instance, auditsStream, func(raw json.RawMessage) {
+			var rec AuditRecord
+			if json.Unmarshal(raw, &rec) == nil {
+				recent = append(recent, rec)
+				if len(recent) > 2 {
+					recent = recent[1:]
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(recent) > 0 && sameAuditRun(&recent[len(recent)-1].Audit, audit) {
+		// Same run as last time: diff against the run before it
+		s.audits[instance] = recent
+		if len(recent) < 2 {
+			return nil, nil
+		}
+		return diffAudits(&recent[0], audit), nil
+	}
+
+	rec := AuditRecord{Time: time.Now(), Audit: *audit}
+	if err := s.appendRecord(instance, auditsStream, rec); err != nil {
+		return nil, err
+	}
+
+	var diff *AuditDiff
+	if len(recent) > 0 {
+		diff = diffAudits(&recent[len(recent)-1], audit)
+	}
+	recent = append(recent, rec)
+	if len(recent) > 2 {
+		recent = recent[1:]
+	}
+	s.audits[instance] = recent
+	return diff, nil
+}
+
+// sameAuditRun reports whether two audits are the same run
+func sameAuditRun(a, b *models.SecurityAudit) bool {
+	if a.Timestamp != 0 || b.Timestamp != 0 {
+		return a.Timestamp == b.Timestamp
+	}
+	return reflect.DeepEqual(a.Findings, b.Findings)
+}
+
+// findingKey identifies a finding across runs
+func findingKey(f models.SecurityAuditFinding) string {
+	return f.CheckID + "|" + f.Title
+}
+
+// diffAudits compares the current audit against a previous run
+func diffAudits(prev *AuditRecord, cur *models.SecurityAudit) *AuditDiff {
+	diff := &AuditDiff{PreviousTime: prev.Time}
+	if prev.Audit.Timestamp > 0 {
+		diff.PreviousTime = time.UnixMilli(prev.Audit.Timestamp)
+	}
+
+	before := make(map[string]models.SecurityAuditFinding)
+	for _, f := range prev.Audit.Findings {
+		before[findingKey(f)] = f
+	}
+	after := make(map[string]bool)
+	for _, f := range cur.Findings {
+		key := findingKey(f)
+		after[key] = true
+		old, ok := before[key]
+		switch {
+		case !ok:
+			diff.New = append(diff.New, f)
+		case old.Severity != f.Severity:
+			diff.Changed = append(diff.Changed, SeverityChange{Finding: f, From: old.Severity})
+		}
+	}
+	for _, f := range prev.Audit.Findings {
+		if !after[findingKey(f)] {
+			diff.Resolved = append(diff.Resolved, f)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,60 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const commandsStream = "commands"
+
+// CommandEntry records one command lazyclaw ran against an instance
+type CommandEntry struct {
+	Time       time.Time `json:"time"`
+	Instance   string    `json:"instance"`
+	Host       string    `json:"host"`
+	Command    string    `json:"command"`
+	DurationMs int64     `json:"durationMs"`
+	ExitCode   int       `json:"exitCode"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Failed returns true if the command did not succeed
+func (e CommandEntry) Failed() bool {
+	return e.ExitCode != 0 || e.Error != ""
+}
+
+// RecordCommand appends the entry to the instance's command log. Safe to
+// call on a nil store.
+func (s *Store) RecordCommand(instance string, entry CommandEntry) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appendRecord(instance, commandsStream, entry)
+}
+
+// Commands returns the instance's last limit logged commands, newest first
+// (limit <= 0 returns all). Safe to call on a nil store.
+func (s *Store) Commands(instance string, limit int) ([]CommandEntry, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entries []CommandEntry
+	err := s.readRecords(instance, commandsStream, func(raw json.RawMessage) {
+		var entry CommandEntry
+		if json.Unmarshal(raw, &entry) == nil {
+			entries = append(entries, entry)
+			if limit > 0 && len(entries) > limit {
+				entries = entries[1:]
+			}
+		}
+	})
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, err
+}
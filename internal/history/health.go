@@ -0,0 +1,85 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const healthStream = "health"
+
+// HealthTransition records a health component entering a new status.
+// From is empty the first time lazyclaw sees the component.
+type HealthTransition struct {
+	Time      time.Time `json:"time"`
+	Component string    `json:"component"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+}
+
+// ComponentState is a health component's current status and how long it has held it
+type ComponentState struct {
+	Status string
+	Since  time.Time
+	// Observed is true if Since is only when lazyclaw first saw the
+	// component, so the status may be older
+	Observed bool
+	Changes  int // Recorded transitions, excluding the first observation
+}
+
+// RecordHealth compares component statuses (component key -> status) with the
+// last known ones, appends a transition for each change, and returns the
+// current state of every given component. Safe to call on a nil store.
+func (s *Store) RecordHealth(instance string, statuses map[string]string) (map[string]ComponentState, error) {
+	if s == nil || len(statuses) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.health == nil {
+		s.health = make(map[string]map[string]ComponentState)
+	}
+	known, ok := s.health[instance]
+	if !ok {
+		// Replay transitions from disk on first use
+		known = make(map[string]ComponentState)
+		err := s.readRecords(instance, healthStream, func(raw json.RawMessage) {
+			var tr HealthTransition
+			if json.Unmarshal(raw, &tr) == nil {
+				known[tr.Component] = applyTransition(known[tr.Component], tr)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.health[instance] = known
+	}
+
+	now := time.Now()
+	current := make(map[string]ComponentState, len(statuses))
+	var firstErr error
+	for component, status := range statuses {
+		state, seen := known[component]
+		if !seen || state.Status != status {
+			tr := HealthTransition{Time: now, Component: component, To: status}
+			if seen {
+				tr.From = state.Status
+			}
+			if err := s.appendRecord(instance, healthStream, tr); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			state = applyTransition(state, tr)
+			known[component] = state
+		}
+		current[component] = state
+	}
+	return current, firstErr
+}
+
+func applyTransition(state ComponentState, tr HealthTransition) ComponentState {
+	if tr.From == "" && state.Status == "" {
+		return ComponentState{Status: tr.To, Since: tr.Time, Observed: true}
+	}
+	return ComponentState{Status: tr.To, Since: tr.Time, Changes: state.Changes + 1}
+}
@@ -0,0 +1,139 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lazyclaw/lazyclaw/internal/config"
+)
+
+// Store persists per-instance history as append-only JSONL files under
+// ~/.config/lazyclaw/history/<instance>/<stream>.jsonl
+type Store struct {
+	dir string
+	mu  sync.Mutex
+
+	// Last known link state per instance/channel, for transition detection
+	links map[string]LinkEvent
+
+	// Last two audit runs per instance, for diffing
+	audits map[string][]AuditRecord
+
+	// Current health component states per instance
+	health map[string]map[string]ComponentState
+
+	// Last recorded token counts per instance/session
+	usage map[string]map[string]sessionTokens
+
+	// Set by Close; records arriving later are dropped
+	closed bool
+}
+
+// ErrClosed is returned when recording into a closed store
+var ErrClosed = errors.New("history store is closed")
+
+// Dir returns the history directory path
+func Dir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history"), nil
+}
+
+// Open opens (creating if needed) the history store
+func Open() (*Store, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Store{
+		dir:   dir,
+		links: make(map[string]LinkEvent),
+	}, nil
+}
+
+// streamPath returns the file path for an instance's stream
+func (s *Store) streamPath(instance, stream string) string {
+	return filepath.Join(s.dir, sanitize(instance), stream+".jsonl")
+}
+
+// Close waits for an in-progress write to finish and stops accepting new
+// records, so nothing is left half-written when lazyclaw exits
+func (s *Store) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+// appendRecord appends a JSON record to an instance's stream. s.mu must be
+// held.
+func (s *Store) appendRecord(instance, stream string, record interface{}) error {
+	if s.closed {
+		return ErrClosed
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	path := s.streamPath(instance, stream)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// readRecords calls fn with each raw record in an instance's stream, oldest first.
+// Malformed lines are skipped.
+func (s *Store) readRecords(instance, stream string, fn func(json.RawMessage)) error {
+	f, err := os.Open(s.streamPath(instance, stream))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 || !json.Valid(line) {
+			continue
+		}
+		fn(json.RawMessage(append([]byte(nil), line...)))
+	}
+	return scanner.Err()
+}
+
+// sanitize makes an instance name safe to use as a directory name
+func sanitize(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,111 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+const linksStream = "links"
+
+// Link event kinds
+const (
+	LinkObserved = "observed" // First time lazyclaw saw the channel
+	LinkLinked   = "linked"
+	LinkUnlinked = "unlinked"
+	LinkRelinked = "relinked" // Auth age reset while linked (re-auth)
+)
+
+// LinkEvent records a change in a link channel's state
+type LinkEvent struct {
+	Time      time.Time `json:"time"`
+	Channel   string    `json:"channel"`
+	Kind      string    `json:"kind"`
+	Linked    bool      `json:"linked"`
+	AuthAgeMs int64     `json:"authAgeMs,omitempty"`
+}
+
+// relinkSlack is how much the auth age may go backwards (clock skew,
+// rounding) before it counts as a re-auth
+const relinkSlack = 5 * time.Minute
+
+// RecordLink compares the link channel state with the last known state and
+// appends an event when it changed. Safe to call on a nil store.
+func (s *Store) RecordLink(instance string, lc *models.LinkChannel) error {
+	if s == nil || lc == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := instance + "/" + lc.ID
+	last, ok := s.links[key]
+	if !ok {
+		// Recover the last state from disk on first use
+		events, err := s.linkEventsLocked(instance, lc.ID)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			last = events[len(events)-1]
+			ok = true
+		}
+	}
+
+	authAge := int64(lc.AuthAgeMs)
+	event := LinkEvent{
+		Time:      time.Now(),
+		Channel:   lc.ID,
+		Linked:    lc.Linked,
+		AuthAgeMs: authAge,
+	}
+
+	switch {
+	case !ok:
+		event.Kind = LinkObserved
+	case last.Linked != lc.Linked:
+		event.Kind = LinkUnlinked
+		if lc.Linked {
+			event.Kind = LinkLinked
+		}
+	case lc.Linked && expectedAuthAge(last, event.Time)-authAge > relinkSlack.Milliseconds():
+		event.Kind = LinkRelinked
+	default:
+		// No change; remember the latest auth age without writing
+		last.AuthAgeMs = authAge
+		last.Time = event.Time
+		s.links[key] = last
+		return nil
+	}
+
+	s.links[key] = event
+	return s.appendRecord(instance, linksStream, event)
+}
+
+// expectedAuthAge returns the auth age the last event implies at time now
+func expectedAuthAge(last LinkEvent, now time.Time) int64 {
+	return last.AuthAgeMs + now.Sub(last.Time).Milliseconds()
+}
+
+// LinkEvents returns recorded link events for an instance's channel, oldest first
+func (s *Store) LinkEvents(instance, channel string) ([]LinkEvent, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.linkEventsLocked(instance, channel)
+}
+
+func (s *Store) linkEventsLocked(instance, channel string) ([]LinkEvent, error) {
+	var events []LinkEvent
+	err := s.readRecords(instance, linksStream, func(raw json.RawMessage) {
+		var ev LinkEvent
+		if json.Unmarshal(raw, &ev) == nil && ev.Channel == channel {
+			events = append(events, ev)
+		}
+	})
+	return events, err
+}
@@ -0,0 +1,118 @@
+package history
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+const usageStream = "usage"
+
+// UsageRecord records token usage a session accrued since its previous record
+type UsageRecord struct {
+	Time         time.Time `json:"time"`
+	Session      string    `json:"session"`
+	AgentID      string    `json:"agentId,omitempty"`
+	Kind         string    `json:"kind,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	InputTokens  int       `json:"inputTokens"`  // Session total at Time
+	OutputTokens int       `json:"outputTokens"` // Session total at Time
+	DeltaInput   int       `json:"deltaInput"`
+	DeltaOutput  int       `json:"deltaOutput"`
+}
+
+// sessionTokens is the last recorded token count of a session
+type sessionTokens struct {
+	input, output int
+}
+
+// RecordUsage compares each session's token counts with the last recorded
+// ones and appends a record with the difference for sessions that changed.
+// A session seen for the first time is recorded with its full count; a count
+// that went down (session reset) counts from zero. Safe to call on a nil store.
+func (s *Store) RecordUsage(instance string, sessions []models.Session) error {
+	if s == nil || len(sessions) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.usage == nil {
+		s.usage = make(map[string]map[string]sessionTokens)
+	}
+	known, ok := s.usage[instance]
+	if !ok {
+		// Recover last counts from disk on first use
+		known = make(map[string]sessionTokens)
+		err := s.readRecords(instance, usageStream, func(raw json.RawMessage) {
+			var rec UsageRecord
+			if json.Unmarshal(raw, &rec) == nil {
+				known[rec.Session] = sessionTokens{rec.InputTokens, rec.OutputTokens}
+			}
+		})
+		if err != nil {
+			return err
+		}
+		s.usage[instance] = known
+	}
+
+	now := time.Now()
+	for _, sess := range sessions {
+		key := sess.SessionID
+		if key == "" {
+			key = sess.Key
+		}
+		if key == "" {
+			continue
+		}
+		last := known[key]
+		if sess.InputTokens == last.input && sess.OutputTokens == last.output {
+			continue
+		}
+
+		rec := UsageRecord{
+			Time:         now,
+			Session:      key,
+			AgentID:      sess.AgentID,
+			Kind:         sess.Kind,
+			Model:        sess.Model,
+			InputTokens:  sess.InputTokens,
+			OutputTokens: sess.OutputTokens,
+			DeltaInput:   tokenDelta(last.input, sess.InputTokens),
+			DeltaOutput:  tokenDelta(last.output, sess.OutputTokens),
+		}
+		if err := s.appendRecord(instance, usageStream, rec); err != nil {
+			return err
+		}
+		known[key] = sessionTokens{sess.InputTokens, sess.OutputTokens}
+	}
+	return nil
+}
+
+func tokenDelta(last, current int) int {
+	if current < last {
+		return current
+	}
+	return current - last
+}
+
+// Usage returns usage records for an instance at or after since, oldest first.
+// A zero since returns all records.
+func (s *Store) Usage(instance string, since time.Time) ([]UsageRecord, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []UsageRecord
+	err := s.readRecords(instance, usageStream, func(raw json.RawMessage) {
+		var rec UsageRecord
+		if json.Unmarshal(raw, &rec) == nil && !rec.Time.Before(since) {
+			records = append(records, rec)
+		}
+	})
+	return records, err
+}
@@ -8,6 +8,8 @@ type ConnectionMode string
 const (
 	ConnectionModeLocal ConnectionMode = "local" // Run openclaw locally
 	ConnectionModeSSH   ConnectionMode = "ssh"   // Run openclaw via SSH on remote host
+	ConnectionModeK8s   ConnectionMode = "k8s"   // Run openclaw via kubectl exec in a pod
+	ConnectionModeWS    ConnectionMode = "ws"    // Speak the gateway's WebSocket protocol directly
 )
 
 // HealthLevel indicates the overall health status of an instance
@@ -21,12 +23,66 @@ const (
 
 // InstanceProfile represents a configured OpenClaw Gateway instance
 type InstanceProfile struct {
-	Name        string         `yaml:"name" json:"name"`
-	Tags        []string       `yaml:"tags,omitempty" json:"tags,omitempty"`
-	Mode        ConnectionMode `yaml:"mode" json:"mode"`
-	SSH         *SSHConfig     `yaml:"ssh,omitempty" json:"ssh,omitempty"`
-	OpenClawCLI string         `yaml:"openclaw_cli,omitempty" json:"openclaw_cli,omitempty"` // Path to openclaw on remote/local
-}
+	Name          string            `yaml:"name" json:"name"`
+	Tags          []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Template      string            `yaml:"template,omitempty" json:"template,omitempty"` // Name of a config template to inherit defaults from
+	Mode          ConnectionMode    `yaml:"mode,omitempty" json:"mode"`
+	SSH           *SSHConfig        `yaml:"ssh,omitempty" json:"ssh,omitempty"`
+	K8s           *K8sConfig        `yaml:"k8s,omitempty" json:"k8s,omitempty"`
+	OpenClawCLI   string            `yaml:"openclaw_cli,omitempty" json:"openclaw_cli,omitempty"`     // Path to openclaw on remote/local
+	Timeout       string            `yaml:"timeout,omitempty" json:"timeout,omitempty"`               // Command timeout, e.g. "30s" or "off"
+	StatusTimeout string            `yaml:"status_timeout,omitempty" json:"status_timeout,omitempty"` // Overrides Timeout for `openclaw status`
+	HealthTimeout string            `yaml:"health_timeout,omitempty" json:"health_timeout,omitempty"` // Overrides Timeout for `openclaw health`
+	IndexTimeout  string            `yaml:"index_timeout,omitempty" json:"index_timeout,omitempty"`   // Overrides Timeout for `openclaw memory index`
+	DoctorTimeout string            `yaml:"doctor_timeout,omitempty" json:"doctor_timeout,omitempty"` // Overrides Timeout for `openclaw doctor`
+	Escalation    *EscalationConfig `yaml:"escalation,omitempty" json:"escalation,omitempty"`         // How service operations gain root on the host
+	Scopes        []string          `yaml:"scopes,omitempty" json:"scopes,omitempty"`                 // Overrides security.default_scopes
+	ReadOnly      bool              `yaml:"read_only,omitempty" json:"read_only,omitempty"`           // Hides write actions and refuses write commands
+	Env           map[string]string `yaml:"env,omitempty" json:"env,omitempty"`                       // Exported before running openclaw, e.g. OPENCLAW_HOME
+	Socket        string            `yaml:"socket,omitempty" json:"socket,omitempty"`                 // Local gateway control socket ("" = detect, "off" = CLI only)
+
+	// gRPC control API, for gateways serving one; tried before the CLI
+	GRPC *GRPCConfig `yaml:"grpc,omitempty" json:"grpc,omitempty"`
+
+	// Gateway WebSocket endpoint, for ws mode
+	WS *WSConfig `yaml:"ws,omitempty" json:"ws,omitempty"`
+}
+
+// WSConfig is where and how to reach a gateway's WebSocket protocol
+type WSConfig struct {
+	URL   string `yaml:"url" json:"url"`                         // ws:// or wss:// URL, e.g. "ws://127.0.0.1:18789"
+	Token string `yaml:"token,omitempty" json:"token,omitempty"` // Gateway auth token (default: none)
+}
+
+// GRPCConfig is where and how to reach a gateway's gRPC control API
+type GRPCConfig struct {
+	Address    string `yaml:"address" json:"address"`                             // host:port
+	CACert     string `yaml:"ca_cert,omitempty" json:"ca_cert,omitempty"`         // PEM file of CAs trusted for the server (default: system roots)
+	Cert       string `yaml:"cert,omitempty" json:"cert,omitempty"`               // PEM client certificate, for mutual TLS
+	Key        string `yaml:"key,omitempty" json:"key,omitempty"`                 // PEM key of Cert
+	ServerName string `yaml:"server_name,omitempty" json:"server_name,omitempty"` // Overrides the host name the server certificate is checked against
+	Plaintext  bool   `yaml:"plaintext,omitempty" json:"plaintext,omitempty"`     // No TLS (h2c); only for loopback or tunnelled addresses
+}
+
+// EscalationConfig sets how commands that control services and processes are
+// run with elevated privileges on the instance's host
+type EscalationConfig struct {
+	Method  string `yaml:"method" json:"method"`                       // sudo, doas or none (default)
+	Askpass string `yaml:"askpass,omitempty" json:"askpass,omitempty"` // Program on the host printing the sudo password (sudo -A)
+}
+
+// Operator scopes (InstanceProfile.Scopes, security.default_scopes)
+const (
+	ScopeRead  = "operator.read"  // View status, health, logs and config
+	ScopeWrite = "operator.write" // Change the gateway: config edits, updates, service control
+)
+
+// Escalation methods (EscalationConfig.Method)
+const (
+	EscalationNone = "none"
+	EscalationSudo = "sudo"
+	EscalationDoas = "doas"
+)
 
 // SSHConfig holds SSH connection configuration for remote instances
 type SSHConfig struct {
@@ -37,6 +93,58 @@ type SSHConfig struct {
 	ProxyJump      string `yaml:"proxy_jump,omitempty" json:"proxy_jump,omitempty"`           // SSH proxy/jump host
 	ConnectTimeout int    `yaml:"connect_timeout,omitempty" json:"connect_timeout,omitempty"` // Connection timeout in seconds
 	OpenClawCLI    string `yaml:"openclaw_cli,omitempty" json:"openclaw_cli,omitempty"`       // Path to openclaw binary on remote host
+	HostKeyPolicy  string `yaml:"host_key_policy,omitempty" json:"host_key_policy,omitempty"` // strict, accept-new (default) or insecure
+	AgentSocket    string `yaml:"agent_socket,omitempty" json:"agent_socket,omitempty"`       // ssh-agent socket (default: $SSH_AUTH_SOCK, "none" = no agent)
+	Shell          string `yaml:"shell,omitempty" json:"shell,omitempty"`                     // Remote shell: bash (default), powershell or cmd
+
+	// Tuning for slow or flaky links; unset leaves ssh's own defaults
+	Compression         bool `yaml:"compression,omitempty" json:"compression,omitempty"`                       // Compress the connection, for large status JSON over slow links
+	ServerAliveInterval int  `yaml:"server_alive_interval,omitempty" json:"server_alive_interval,omitempty"`   // Seconds between keepalive probes
+	ServerAliveCountMax int  `yaml:"server_alive_count_max,omitempty" json:"server_alive_count_max,omitempty"` // Unanswered probes before the connection is dropped
+
+	// How log following survives a dropped link: ssh (default) or resilient
+	Transport string `yaml:"transport,omitempty" json:"transport,omitempty"`
+
+	// SSH client connecting to the host: openssh (default) or native
+	Client string `yaml:"client,omitempty" json:"client,omitempty"`
+}
+
+// SSH host key policies (SSHConfig.HostKeyPolicy)
+const (
+	HostKeyStrict    = "strict"     // Only connect to hosts whose key is already known
+	HostKeyAcceptNew = "accept-new" // Trust a host's first key, reject changes to it
+	HostKeyInsecure  = "insecure"   // Never check host keys
+)
+
+// Log following transports (SSHConfig.Transport)
+const (
+	TransportSSH       = "ssh"       // One ssh session; the stream ends with it
+	TransportResilient = "resilient" // ssh, reconnected and resumed when the link drops
+)
+
+// SSH clients (SSHConfig.Client)
+const (
+	SSHClientOpenSSH = "openssh" // The system ssh binary, honouring ~/.ssh/config
+	SSHClientNative  = "native"  // Built in, for hosts without OpenSSH installed
+)
+
+// Remote shells (SSHConfig.Shell)
+const (
+	RemoteShellBash       = "bash"       // POSIX hosts; commands run in a login shell
+	RemoteShellPowerShell = "powershell" // Windows hosts, via Windows PowerShell
+	RemoteShellCmd        = "cmd"        // Windows hosts, via cmd.exe
+)
+
+// K8sConfig holds kubectl exec configuration for gateways running in a
+// Kubernetes cluster. The pod is looked up by selector, so a restarted
+// gateway pod is found again under its new name.
+type K8sConfig struct {
+	Selector    string `yaml:"selector" json:"selector"`                           // Label selector for the gateway pod (e.g., "app=openclaw")
+	Namespace   string `yaml:"namespace,omitempty" json:"namespace,omitempty"`     // Pod namespace (default: the context's namespace)
+	Container   string `yaml:"container,omitempty" json:"container,omitempty"`     // Container running openclaw (default: the pod's default)
+	Context     string `yaml:"context,omitempty" json:"context,omitempty"`         // kubeconfig context (default: current context)
+	Kubeconfig  string `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`   // Path to kubeconfig (default: kubectl's)
+	OpenClawCLI string `yaml:"openclaw_cli,omitempty" json:"openclaw_cli,omitempty"` // Path to openclaw binary in the container
 }
 
 // ConnectionState tracks the current connection status
@@ -58,6 +166,18 @@ type LogEvent struct {
 	Raw       string
 }
 
+// GatewayEvent is a typed event from `openclaw events --follow --json`
+type GatewayEvent struct {
+	Timestamp int64                  `json:"ts"` // Unix milliseconds
+	Type      string                 `json:"type"`
+	Severity  string                 `json:"severity"` // info, warn, error
+	Source    string                 `json:"source,omitempty"`
+	Channel   string                 `json:"channel,omitempty"` // Channel ID, e.g. "whatsapp"
+	Session   string                 `json:"session,omitempty"` // Session key
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
 // HealthSnapshot contains gateway health information
 type HealthSnapshot struct {
 	Timestamp       time.Time
@@ -134,6 +254,11 @@ type HealthDoctorItem struct {
 	Message string `json:"message"`
 }
 
+// DoctorReport is the output of `openclaw doctor --json`
+type DoctorReport struct {
+	Checks []HealthDoctorItem `json:"checks"`
+}
+
 // ============================================================================
 // OpenClaw Status JSON structures (from `openclaw status --json`)
 // ============================================================================
@@ -153,6 +278,42 @@ type OpenClawStatus struct {
 	NodeService    *ServiceInfo    `json:"nodeService,omitempty"`
 	Agents         *AgentsInfo     `json:"agents,omitempty"`
 	SecurityAudit  *SecurityAudit  `json:"securityAudit,omitempty"`
+	Webhooks       []WebhookInfo   `json:"webhooks,omitempty"`
+	Queues         []QueueInfo     `json:"queues,omitempty"`
+
+	// Fields skipped for holding a value of an unexpected type, and the raw
+	// JSON they came from, set only when the status decoded partially
+	ParseErrors []string `json:"-"`
+	Raw         string   `json:"-"`
+}
+
+// QueueInfo describes a gateway message queue or pending-job backlog
+type QueueInfo struct {
+	Name        string `json:"name"`
+	Depth       int    `json:"depth"`
+	InFlight    int    `json:"inFlight,omitempty"`
+	OldestAgeMs int64  `json:"oldestAgeMs,omitempty"` // Age of the oldest pending item
+	Failed      int    `json:"failed,omitempty"`
+}
+
+// WebhookInfo describes a configured webhook or outbound integration
+type WebhookInfo struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name,omitempty"`
+	Kind         string           `json:"kind,omitempty"` // e.g. "webhook", "slack", "email"
+	URL          string           `json:"url,omitempty"`
+	Events       []string         `json:"events,omitempty"`
+	Enabled      bool             `json:"enabled"`
+	LastDelivery *WebhookDelivery `json:"lastDelivery,omitempty"`
+}
+
+// WebhookDelivery is the outcome of the most recent delivery attempt
+type WebhookDelivery struct {
+	At         int64  `json:"at"` // Unix ms
+	OK         bool   `json:"ok"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	DurationMs int    `json:"durationMs,omitempty"`
+	Error      string `json:"error,omitempty"`
 }
 
 // LinkChannel represents the linked channel status (e.g., WhatsApp)
@@ -212,6 +373,13 @@ type Session struct {
 	Flags           []string `json:"flags"`
 }
 
+// SessionCompaction is the result of `openclaw sessions compact --json`
+type SessionCompaction struct {
+	Key          string `json:"key"`
+	TokensBefore int    `json:"tokensBefore"`
+	TokensAfter  int    `json:"tokensAfter"`
+}
+
 // AgentSession groups sessions by agent
 type AgentSession struct {
 	AgentID string    `json:"agentId"`
@@ -366,3 +534,107 @@ type SecurityAuditFinding struct {
 	Detail      string `json:"detail"`
 	Remediation string `json:"remediation,omitempty"`
 }
+
+// FileEntry describes a file or directory on an instance's host
+type FileEntry struct {
+	Name    string
+	IsDir   bool
+	Size    int64
+	ModTime time.Time
+}
+
+// ProcessInfo describes an openclaw-related process on an instance's host
+type ProcessInfo struct {
+	PID     int
+	CPU     float64 // Percent of one core
+	RSSKB   int64
+	Uptime  time.Duration
+	Command string
+}
+
+// ============================================================================
+// OpenClaw Channels JSON structures (from `openclaw channels --json`)
+// ============================================================================
+
+// ChannelsList represents the output of `openclaw channels --json`
+type ChannelsList struct {
+	Channels []ChannelInfo `json:"channels"`
+}
+
+// ChannelInfo contains structured status for a single channel
+type ChannelInfo struct {
+	ID           string `json:"id"`
+	Label        string `json:"label"`
+	Status       string `json:"status"` // "linked", "configured", "disabled", "error"
+	Enabled      bool   `json:"enabled"`
+	Linked       bool   `json:"linked"`
+	AuthAgeMs    int64  `json:"authAgeMs,omitempty"`
+	LastError    string `json:"lastError,omitempty"`
+	LastErrorAt  int64  `json:"lastErrorAt,omitempty"`
+	MessagesIn   int    `json:"messagesIn"`
+	MessagesOut  int    `json:"messagesOut"`
+	LastActivity int64  `json:"lastActivityAt,omitempty"`
+}
+
+// ChannelsStatus represents the output of `openclaw channels status --json`:
+// the live connection state of each channel, as opposed to its configuration
+type ChannelsStatus struct {
+	Channels []ChannelStatus `json:"channels"`
+}
+
+// ChannelStatus is the connection state of a single channel
+type ChannelStatus struct {
+	ID              string `json:"id"`
+	Label           string `json:"label,omitempty"`
+	State           string `json:"state"` // "connected", "connecting", "disconnected", "error"
+	AuthAgeMs       int64  `json:"authAgeMs,omitempty"`
+	LastError       string `json:"lastError,omitempty"`
+	LastErrorAt     int64  `json:"lastErrorAt,omitempty"`
+	LastConnectedAt int64  `json:"lastConnectedAt,omitempty"`
+	Reconnects      int    `json:"reconnects,omitempty"`
+}
+
+// ============================================================================
+// OpenClaw Memory Search JSON structures (from `openclaw memory search --json`)
+// ============================================================================
+
+// MemorySearchResult represents the output of `openclaw memory search --json -- <query>`
+type MemorySearchResult struct {
+	Query   string            `json:"query"`
+	Results []MemorySearchHit `json:"results"`
+}
+
+// MemoryFilesList represents the output of `openclaw memory files --json`
+type MemoryFilesList struct {
+	Files []MemoryFile `json:"files"`
+}
+
+// MemoryFile describes a single indexed file
+type MemoryFile struct {
+	Path      string `json:"path"`
+	Source    string `json:"source"`
+	Chunks    int    `json:"chunks"`
+	Size      int64  `json:"size,omitempty"`
+	IndexedAt int64  `json:"indexedAt,omitempty"` // Unix ms
+}
+
+// MemoryIndexStatus represents the output of `openclaw memory status --json`
+type MemoryIndexStatus struct {
+	Indexing       bool   `json:"indexing"`
+	Dirty          bool   `json:"dirty"`
+	FilesTotal     int    `json:"filesTotal"`
+	FilesProcessed int    `json:"filesProcessed"`
+	ChunksWritten  int    `json:"chunksWritten"`
+	StartedAt      int64  `json:"startedAt,omitempty"` // Unix ms
+	Error          string `json:"error,omitempty"`
+}
+
+// MemorySearchHit is a single ranked chunk returned by a memory search
+type MemorySearchHit struct {
+	Score     float64 `json:"score"`
+	Source    string  `json:"source"`
+	Path      string  `json:"path"`
+	StartLine int     `json:"startLine,omitempty"`
+	EndLine   int     `json:"endLine,omitempty"`
+	Snippet   string  `json:"snippet"`
+}
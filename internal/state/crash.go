@@ -0,0 +1,26 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SaveCrashReport writes a crash report next to the state file and returns
+// its path. Reports are kept private since they describe the user's setup.
+func SaveCrashReport(report string) (string, error) {
+	statePath, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Dir(statePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, "crash-"+time.Now().Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(report), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"gopkg.in/yaml.v3"
 )
@@ -42,6 +43,12 @@ func DefaultState() *State {
 // StatePath returns the full path to the state file
 func StatePath() (string, error) {
 	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" && runtime.GOOS == "windows" {
+		var err error
+		if configHome, err = os.UserConfigDir(); err != nil {
+			return "", err
+		}
+	}
 	if configHome == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
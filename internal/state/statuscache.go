@@ -0,0 +1,124 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// CachedStatus is an instance's last status, saved on exit so the next
+// launch has something to show while fresh data loads
+type CachedStatus struct {
+	FetchedAt time.Time              `json:"fetchedAt"`
+	Status    *models.OpenClawStatus `json:"status"`
+}
+
+// StatusCacheDir returns the directory holding cached statuses, next to the
+// state file
+func StatusCacheDir() (string, error) {
+	statePath, err := StatePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(statePath), "cache", "status"), nil
+}
+
+// statusCachePath returns the cache file path for an instance
+func statusCachePath(instance string) (string, error) {
+	dir, err := StatusCacheDir()
+	if err != nil {
+		return "", err
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, instance)
+	if name == "" {
+		name = "default"
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// LoadStatus returns the instance's cached status, or nil if there is none
+// or it is older than maxAge
+func LoadStatus(instance string, maxAge time.Duration) (*CachedStatus, error) {
+	path, err := statusCachePath(instance)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var cached CachedStatus
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+	if cached.Status == nil || time.Since(cached.FetchedAt) > maxAge {
+		return nil, nil
+	}
+	return &cached, nil
+}
+
+// SaveStatus writes the instance's status to the cache atomically. The file
+// is private since status describes the user's setup.
+func SaveStatus(instance string, cached CachedStatus) error {
+	path, err := statusCachePath(instance)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// PurgeStatusCache deletes every cached status and returns how many there
+// were
+func PurgeStatusCache() (int, error) {
+	dir, err := StatusCacheDir()
+	if err != nil {
+		return 0, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	purged := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return purged, err
+		}
+		if strings.HasSuffix(entry.Name(), ".json") {
+			purged++
+		}
+	}
+	return purged, nil
+}
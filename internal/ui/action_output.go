@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// actionOutputLimit is how many of the latest output lines the output
+// viewer keeps
+const actionOutputLimit = 500
+
+// ActionOutputMsg carries a line the action in the output viewer printed
+type ActionOutputMsg struct {
+	Line string
+
+	next tea.Cmd // Waits for the following line or the result
+}
+
+// ActionDoneMsg is sent when the action in the output viewer returns
+type ActionDoneMsg struct {
+	Result   tea.Msg // The action's own result, handled once recorded
+	Duration time.Duration
+}
+
+// actionResult is implemented by the result messages of actions run in the
+// output viewer
+type actionResult interface {
+	failure() error
+}
+
+// actionOutput holds the output viewer every write action runs in: the
+// output of its commands as it streams, then its exit code and duration
+type actionOutput struct {
+	req      writeRequest
+	instance string
+	commands []string // As confirmed
+	output   []string
+	scroll   int // Lines scrolled back from the latest output
+	running  bool
+	started  time.Time
+	done     bool
+	err      error
+	duration time.Duration
+}
+
+// runWithOutput runs a confirmed write in the output viewer. The viewer can
+// be closed while the action runs; its result is flashed as usual. Only one
+// action runs at a time.
+func (a *App) runWithOutput(instance string, commands []string, req writeRequest) tea.Cmd {
+	v := &a.actionOutput
+	if v.running {
+		a.setFlash(v.req.title+" is still running", true)
+		return nil
+	}
+	adapter := a.cliAdapter()
+	work := req.run()
+	if adapter == nil || work == nil {
+		return work
+	}
+	*v = actionOutput{req: req, instance: instance, commands: commands, running: true, started: time.Now()}
+	a.mode = ModeActionOutput
+
+	ch := make(chan tea.Msg, 64)
+	return func() tea.Msg {
+		go func() {
+			stop := adapter.WatchOutput(func(line string) {
+				ch <- ActionOutputMsg{Line: line}
+			})
+			start := time.Now()
+			result := work()
+			stop()
+			ch <- ActionDoneMsg{Result: result, Duration: time.Since(start)}
+		}()
+		return waitForActionOutput(ch)()
+	}
+}
+
+// waitForActionOutput waits for the next line of the running action, or its
+// result
+func waitForActionOutput(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg := <-ch
+		if out, ok := msg.(ActionOutputMsg); ok {
+			out.next = waitForActionOutput(ch)
+			return out
+		}
+		return msg
+	}
+}
+
+func (a *App) handleActionOutput(msg ActionOutputMsg) tea.Cmd {
+	v := &a.actionOutput
+	v.output = append(v.output, msg.Line)
+	if len(v.output) > actionOutputLimit {
+		v.output = v.output[len(v.output)-actionOutputLimit:]
+	}
+	if v.scroll > 0 {
+		v.scroll++ // Keep the lines being read in place
+	}
+	return msg.next
+}
+
+// handleActionDone records how the action ended, then hands its result on
+// to the action's own handler
+func (a *App) handleActionDone(msg ActionDoneMsg) tea.Cmd {
+	v := &a.actionOutput
+	v.running, v.done = false, true
+	v.duration = msg.Duration
+	if result, ok := msg.Result.(actionResult); ok {
+		v.err = result.failure()
+	}
+	if msg.Result == nil {
+		return nil
+	}
+	return func() tea.Msg { return msg.Result }
+}
+
+// handleActionOutputKey handles keys while the output viewer is open. r
+// runs the action again, through its confirmation.
+func (a *App) handleActionOutputKey(msg tea.KeyMsg) tea.Cmd {
+	v := &a.actionOutput
+	switch {
+	case key.Matches(msg, a.keys.Escape) || key.Matches(msg, a.keys.Enter) || msg.String() == "q":
+		a.mode = ModeNormal
+	case msg.String() == "r" && v.done:
+		a.mode = ModeNormal
+		if !a.canWrite(v.req.title) {
+			return nil
+		}
+		return a.confirmWrite(v.req)
+	case key.Matches(msg, a.keys.Up):
+		v.scroll++
+	case key.Matches(msg, a.keys.Down):
+		v.scroll = max(v.scroll-1, 0)
+	case key.Matches(msg, a.keys.PageUp):
+		v.scroll += a.actionOutputRows()
+	case key.Matches(msg, a.keys.PageDown):
+		v.scroll = max(v.scroll-a.actionOutputRows(), 0)
+	}
+	return nil
+}
+
+// actionOutputRows is how many output lines the output viewer shows
+func (a *App) actionOutputRows() int {
+	return max(a.height-14-len(a.actionOutput.commands), 3)
+}
+
+// renderActionOutput renders the output viewer: the commands run, their
+// output around the scroll position, then how the action ended
+func (a *App) renderActionOutput() string {
+	v := &a.actionOutput
+	content := styles.HelpTitle.Render(v.req.title+" on "+v.instance) + "\n\n"
+	width := max(a.width-12, 40)
+
+	for _, command := range v.commands {
+		content += styles.Muted.Render(truncate("$ "+command, width)) + "\n"
+	}
+	rows := a.actionOutputRows()
+	v.scroll = min(v.scroll, max(len(v.output)-rows, 0))
+	end := len(v.output) - v.scroll
+	for _, line := range v.output[max(end-rows, 0):end] {
+		content += truncate(line, width) + "\n"
+	}
+	if v.done && len(v.output) == 0 {
+		content += styles.Muted.Render("(no output)") + "\n"
+	}
+	if v.scroll > 0 {
+		content += styles.Muted.Render(fmt.Sprintf("-- %d more lines below --", v.scroll)) + "\n"
+	}
+
+	switch {
+	case v.running:
+		content += "\n" + styles.Muted.Render(fmt.Sprintf("Running for %s...", formatLatency(time.Since(v.started)))) + "\n"
+	case v.err != nil:
+		content += "\n" + styles.LogError.Render(fmt.Sprintf("Failed, %s after %s", exitStatus(v.err), formatLatency(v.duration))) + "\n" +
+			styles.LogError.Render(truncate(v.err.Error(), width)) + "\n"
+	default:
+		content += "\n" + styles.StatusOK.Render(fmt.Sprintf("Done, exit code 0 after %s", formatLatency(v.duration))) + "\n"
+	}
+	if v.done && v.req.status != nil {
+		if status := v.req.status(); status != "" {
+			content += status + "\n"
+		}
+	}
+
+	content += "\n" + styles.HintKey.Render("j/k") + styles.Muted.Render(":scroll  ")
+	if v.done {
+		content += styles.HintKey.Render("r") + styles.Muted.Render(":re-run  ")
+	}
+	content += styles.HintKey.Render("esc") + styles.Muted.Render(":close")
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// exitStatus describes the exit code err reports
+func exitStatus(err error) string {
+	if code := gateway.ExitCode(err); code >= 0 {
+		return fmt.Sprintf("exit code %d", code)
+	}
+	return "no exit code"
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// actionsMenu holds the actions popup: the operations of the tab it was
+// opened on. Each is the tab's own key binding, so an action behaves exactly
+// like its key, confirmation prompts and result flashes included.
+type actionsMenu struct {
+	tab     Tab
+	actions []key.Binding
+	cursor  int
+}
+
+// tabActions returns the operations available on the active tab, followed
+// by those every tab offers: running doctor and reconnecting. Write actions
+// are left out where writes are not allowed, such as on read_only instances.
+func (a *App) tabActions() []key.Binding {
+	k := a.keys
+	write := a.writesAllowed()
+	var actions []key.Binding
+	switch a.activeTab {
+	case TabLogs:
+		actions = append(actions, k.ToggleFollow)
+	case TabHealth:
+		actions = append(actions, k.Probe)
+		if a.hostKeyRejected() {
+			actions = append(actions, k.AcceptHostKey)
+		}
+	case TabChannels:
+		if _, ok := a.selectedChannel(); ok && write {
+			actions = append(actions, k.ChannelLink, k.ChannelUnlink, k.ChannelRestart, k.ChannelSend)
+		}
+	case TabAgents:
+		if !a.workspace.open {
+			actions = append(actions, k.AgentSessions)
+		}
+		if !a.workspace.open && write {
+			actions = append(actions, k.HeartbeatToggle, k.HeartbeatInterval, k.HeartbeatDefault)
+			if agent, ok := a.selectedAgent(); ok && agent.BootstrapPending {
+				actions = append(actions, k.BootstrapAgent)
+			}
+			actions = append(actions, k.NewAgent)
+		}
+	case TabSessions:
+		actions = append(actions, k.FilterAgent, k.FilterKind, k.FilterUsage)
+		if _, ok := a.selectedSession(); ok && write {
+			actions = append(actions, k.CompactSession, k.ResetSession, k.KillSession)
+		}
+	case TabEvents:
+		actions = append(actions, k.FilterSeverity)
+	case TabMemory:
+		if write {
+			actions = append(actions, k.Reindex, k.ClearCache)
+		}
+		actions = append(actions, k.BrowseFiles)
+	case TabSecurity:
+		actions = append(actions, k.FilterSeverity, k.Export)
+		if f, ok := a.selectedFinding(); ok && f.Remediation != "" && write {
+			actions = append(actions, k.ApplyFix)
+		}
+	case TabSystem:
+		if write {
+			if a.availableUpdate() != "" {
+				actions = append(actions, k.UpdateGateway)
+			}
+			actions = append(actions, k.UpdateChannel)
+			actions = append(actions, k.ServiceStart, k.ServiceStop, k.ServiceRestart, k.ServiceBoot)
+		}
+		actions = append(actions, k.ServiceLogs)
+		if write {
+			actions = append(actions, k.ProcessTerm, k.ProcessKill)
+		}
+		actions = append(actions, k.CommandLog)
+	case TabUsage:
+		actions = append(actions, k.UsagePeriod)
+	case TabHooks:
+		if write {
+			actions = append(actions, k.TestWebhook)
+		}
+	}
+	return append(actions, k.Doctor, k.Reconnect)
+}
+
+// openActions shows the actions popup for the active tab
+func (a *App) openActions() {
+	a.actions = actionsMenu{tab: a.activeTab, actions: a.tabActions()}
+	a.mode = ModeActions
+}
+
+// handleActionsKey handles keys while the actions popup is open. enter runs
+// the selected action; an action's own key runs it directly.
+func (a *App) handleActionsKey(msg tea.KeyMsg) tea.Cmd {
+	m := &a.actions
+	switch {
+	case key.Matches(msg, a.keys.Escape) || key.Matches(msg, a.keys.Actions) || msg.String() == "q":
+		a.mode = ModeNormal
+	case key.Matches(msg, a.keys.Up):
+		m.cursor = max(m.cursor-1, 0)
+	case key.Matches(msg, a.keys.Down):
+		m.cursor = min(m.cursor+1, len(m.actions)-1)
+	case key.Matches(msg, a.keys.Enter):
+		return a.runAction(m.actions[m.cursor])
+	default:
+		for _, action := range m.actions {
+			if key.Matches(msg, action) {
+				return a.runAction(action)
+			}
+		}
+	}
+	return nil
+}
+
+// runAction closes the popup and presses the action's key on its tab
+func (a *App) runAction(action key.Binding) tea.Cmd {
+	a.mode = ModeNormal
+	a.activeTab = a.actions.tab
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(action.Keys()[0])}
+	_, cmd := a.handleMsg(press)
+	return cmd
+}
+
+// renderActions renders the actions popup
+func (a *App) renderActions() string {
+	m := &a.actions
+	content := styles.HelpTitle.Render("Actions: "+m.tab.String()) + "\n\n"
+	for i, action := range m.actions {
+		help := action.Help()
+		line := fmt.Sprintf("%-3s %s", help.Key, capitalize(help.Desc))
+		if i == m.cursor {
+			content += styles.SelectedItem.Render("> "+line) + "\n"
+		} else {
+			content += styles.UnselectedItem.Render("  "+line) + "\n"
+		}
+	}
+
+	content += "\n" + styles.HintKey.Render("enter") + styles.Muted.Render(":run  ") +
+		styles.HintKey.Render("j/k") + styles.Muted.Render(":select  ") +
+		styles.HintKey.Render("esc") + styles.Muted.Render(":close")
+
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// explainError names what went wrong with a fetch, by the error's kind, and
+// what can be done about it. hint is "" when there is nothing to suggest.
+func explainError(err error) (title, hint string) {
+	switch gateway.KindOf(err) {
+	case gateway.ErrorBinaryNotFound:
+		return "openclaw missing", "Install openclaw on the instance, or set openclaw_cli to its path"
+	case gateway.ErrorSSHAuth:
+		return "SSH authentication failed", "Check ssh.user and ssh.identity_file, and that the host key is in known_hosts"
+	case gateway.ErrorHostKey:
+		return "SSH host key not trusted", "Press A to review the host's key fingerprint and trust it"
+	case gateway.ErrorConnection:
+		return "Host unreachable", "Check the network path to the instance (VPN, bastion, kube context)"
+	case gateway.ErrorTimeout:
+		return "Timed out", "Raise timeout or health_timeout for slow instances"
+	case gateway.ErrorJSONParse:
+		return "Unexpected output", "The instance's openclaw may be incompatible with this lazyclaw"
+	case gateway.ErrorGatewayDown:
+		return "Gateway offline", "openclaw is installed but its gateway is not running"
+	case gateway.ErrorEscalationDenied:
+		return "Privileges denied", "Allow the command in sudoers or doas.conf, or set escalation.askpass"
+	case gateway.ErrorScopeDenied:
+		return "Not permitted", "Grant operator.write in the instance's scopes and set security.allow_write_scopes: true"
+	}
+	return "Failed", ""
+}
+
+// renderHealthError describes a failed health check, for the Health tab
+func renderHealthError(err error, width int) []string {
+	title, hint := explainError(err)
+	lines := []string{"  Health check: " + styles.StatusDown.Render(title)}
+	if hint != "" {
+		lines = append(lines, styles.Muted.Render("  "+truncate(hint, width-4)))
+	}
+	lines = append(lines, "  Error: "+styles.LogError.Render(truncate(err.Error(), width-11)))
+	return lines
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// bootstrapPollInterval is how often the status is fetched while waiting
+// for a bootstrapped agent to stop reporting bootstrap pending
+const bootstrapPollInterval = 2 * time.Second
+
+// bootstrapPollTimeout is how long that is waited for
+const bootstrapPollTimeout = 2 * time.Minute
+
+// AgentBootstrapMsg is sent when an agent bootstrap command returns
+type AgentBootstrapMsg struct {
+	Instance string
+	AgentID  string
+	Error    error
+}
+
+func (m AgentBootstrapMsg) failure() error { return m.Error }
+
+// agentBootstrapPollMsg asks for the status while waiting on a bootstrap
+type agentBootstrapPollMsg struct {
+	seq int
+}
+
+// agentBootstrap holds the agent being bootstrapped: the command running,
+// then the status polls until it is no longer pending
+type agentBootstrap struct {
+	seq     int // Bumped per bootstrap, to end a finished one's polls
+	agent   string
+	running bool
+	polling bool
+	started time.Time
+
+	instance string
+}
+
+// selectedAgent returns the agent selected in the Agents tab
+func (a *App) selectedAgent() (models.AgentInfo, bool) {
+	if a.openclawStatus == nil || a.openclawStatus.Agents == nil {
+		return models.AgentInfo{}, false
+	}
+	agents := a.openclawStatus.Agents.Agents
+	if a.agentCursor < 0 || a.agentCursor >= len(agents) {
+		return models.AgentInfo{}, false
+	}
+	return agents[a.agentCursor], true
+}
+
+// bootstrapping returns true if the agent is being bootstrapped
+func (b *agentBootstrap) bootstrapping(agentID string) bool {
+	return (b.running || b.polling) && b.agent == agentID
+}
+
+// bootstrapAgent asks to confirm bootstrapping the selected agent, after
+// which the status is polled until the agent is no longer pending
+func (a *App) bootstrapAgent() tea.Cmd {
+	b := &a.bootstrap
+	agent, ok := a.selectedAgent()
+	if a.cliAdapter() == nil || !ok || b.running || b.polling {
+		return nil
+	}
+	if !agent.BootstrapPending {
+		a.setFlash("Agent "+agent.ID+" is not pending bootstrap", true)
+		return nil
+	}
+	if !a.canWrite("Bootstrapping agents") {
+		return nil
+	}
+	return a.confirmWrite(writeRequest{
+		title:   "Bootstrap Agent",
+		prompt:  fmt.Sprintf("Bootstrap agent %s on %s?", agent.ID, a.cliAdapter().GetInstanceName()),
+		preview: func(c *gateway.CLIAdapter) error { return c.BootstrapAgent(agent.ID) },
+		run:     func() tea.Cmd { return a.runAgentBootstrap(agent.ID) },
+	})
+}
+
+// runAgentBootstrap runs the confirmed bootstrap
+func (a *App) runAgentBootstrap(agentID string) tea.Cmd {
+	b := &a.bootstrap
+	adapter := a.cliAdapter()
+	*b = agentBootstrap{seq: b.seq + 1, agent: agentID, running: true, instance: adapter.GetInstanceName()}
+	instance := b.instance
+	return func() tea.Msg {
+		return AgentBootstrapMsg{Instance: instance, AgentID: agentID, Error: adapter.BootstrapAgent(agentID)}
+	}
+}
+
+// handleAgentBootstrap starts polling the status once the bootstrap command
+// has returned
+func (a *App) handleAgentBootstrap(msg AgentBootstrapMsg) tea.Cmd {
+	b := &a.bootstrap
+	if msg.Instance != b.instance || msg.AgentID != b.agent {
+		return nil
+	}
+	b.running = false
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	b.polling = true
+	b.started = time.Now()
+	return tea.Batch(a.fetchCLIStatus(), scheduleBootstrapPoll(b.seq))
+}
+
+func scheduleBootstrapPoll(seq int) tea.Cmd {
+	return tea.Tick(bootstrapPollInterval, func(time.Time) tea.Msg {
+		return agentBootstrapPollMsg{seq: seq}
+	})
+}
+
+// pollAgentBootstrap fetches the status while waiting on a bootstrap, giving
+// up after bootstrapPollTimeout
+func (a *App) pollAgentBootstrap(msg agentBootstrapPollMsg) tea.Cmd {
+	b := &a.bootstrap
+	if msg.seq != b.seq || !b.polling {
+		return nil
+	}
+	if time.Since(b.started) > bootstrapPollTimeout {
+		b.polling = false
+		a.setFlash(fmt.Sprintf("Agent %s is still pending bootstrap after %s", b.agent, bootstrapPollTimeout), true)
+		return nil
+	}
+	return tea.Batch(a.fetchCLIStatus(), scheduleBootstrapPoll(msg.seq))
+}
+
+// checkAgentBootstrap stops polling once a status with the agents section
+// no longer reports the agent pending bootstrap
+func (a *App) checkAgentBootstrap(msg CLIStatusMsg) {
+	b := &a.bootstrap
+	if !b.polling || a.openclawStatus.Agents == nil ||
+		slices.Contains(msg.Excluded, gateway.StatusSectionAgents) {
+		return
+	}
+	i := slices.IndexFunc(a.openclawStatus.Agents.Agents, func(agent models.AgentInfo) bool {
+		return agent.ID == b.agent
+	})
+	switch {
+	case i < 0:
+		b.polling = false
+		a.setFlash("Agent "+b.agent+" is no longer reported", true)
+	case !a.openclawStatus.Agents.Agents[i].BootstrapPending:
+		b.polling = false
+		a.setFlash("Agent "+b.agent+" bootstrapped", false)
+	}
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// agentNamePattern matches the names openclaw accepts for agents
+var agentNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Steps of the new agent wizard: one per field, then the review
+const (
+	wizardName = iota
+	wizardWorkspace
+	wizardModel
+	wizardReview
+)
+
+// wizardLabels label the wizard's fields, by step
+var wizardLabels = [...]string{"Name", "Workspace", "Model"}
+
+// AgentCreatedMsg is sent when an agent creation returns
+type AgentCreatedMsg struct {
+	Instance string
+	Name     string
+	Error    error
+}
+
+func (m AgentCreatedMsg) failure() error { return m.Error }
+
+// agentWizard holds the new agent wizard, which asks for the name, the
+// workspace directory and the default model, then creates the agent once
+// they and the command creating it are reviewed
+type agentWizard struct {
+	input    textinput.Model
+	instance string
+	target   string // Where the command runs, e.g. "deploy@gw-1"
+	step     int
+	values   [len(wizardLabels)]string
+	commands []string // Creating the agent, as a dry run recorded them
+	err      string   // Why the entered value was refused
+	creating string   // Name of the agent being created
+}
+
+func newAgentWizard() agentWizard {
+	in := textinput.New()
+	in.Prompt = ""
+	in.CharLimit = 256
+	return agentWizard{input: in}
+}
+
+// openAgentWizard opens the new agent wizard on its first step
+func (a *App) openAgentWizard() tea.Cmd {
+	w := &a.agentWizard
+	if a.cliAdapter() == nil || a.openclawStatus == nil || w.creating != "" {
+		return nil
+	}
+	if !a.canWrite("Creating agents") {
+		return nil
+	}
+	w.instance, w.target = a.cliAdapter().GetInstanceName(), a.cliAdapter().Target()
+	w.values = [len(wizardLabels)]string{}
+	a.mode = ModeAgentWizard
+	return a.wizardStep(wizardName)
+}
+
+// wizardStep moves the wizard to step, editing the value entered there
+// before, if any. The review shows the command that creates the agent.
+func (a *App) wizardStep(step int) tea.Cmd {
+	w := &a.agentWizard
+	w.step, w.err = step, ""
+	if step == wizardReview {
+		w.input.Blur()
+		values := w.values
+		w.commands = a.cliAdapter().DryRun(func(c *gateway.CLIAdapter) error {
+			return c.CreateAgent(values[wizardName], values[wizardWorkspace], values[wizardModel])
+		})
+		return nil
+	}
+	w.input.SetValue(w.values[step])
+	w.input.CursorEnd()
+	w.input.Placeholder = a.wizardPlaceholder(step)
+	w.input.Focus()
+	return textinput.Blink
+}
+
+// wizardPlaceholder describes what an empty field stands for
+func (a *App) wizardPlaceholder(step int) string {
+	switch step {
+	case wizardName:
+		return "letters, digits, - and _"
+	case wizardWorkspace:
+		return "empty for openclaw's default"
+	}
+	if s := a.openclawStatus.Sessions; s != nil && s.Defaults.Model != "" {
+		return "empty for the default, " + s.Defaults.Model
+	}
+	return "empty for the default"
+}
+
+// checkWizardValue returns why value cannot be entered at step, if it cannot
+func (a *App) checkWizardValue(step int, value string) string {
+	if step != wizardName {
+		return ""
+	}
+	if !agentNamePattern.MatchString(value) {
+		return "The name needs letters, digits, - or _, starting with a letter or digit"
+	}
+	if agents := a.openclawStatus.Agents; agents != nil {
+		for _, agent := range agents.Agents {
+			if strings.EqualFold(agent.ID, value) {
+				return "Agent " + agent.ID + " already exists"
+			}
+		}
+	}
+	return ""
+}
+
+// handleAgentWizardKey handles keys while the new agent wizard is open.
+// enter takes the value and moves on, creating the agent from the review;
+// shift+tab goes back a step.
+func (a *App) handleAgentWizardKey(msg tea.KeyMsg) tea.Cmd {
+	w := &a.agentWizard
+	switch {
+	case key.Matches(msg, a.keys.Escape):
+		a.closeAgentWizard()
+		return nil
+	case key.Matches(msg, a.keys.ShiftTab):
+		if w.step > wizardName {
+			if w.step != wizardReview {
+				w.values[w.step] = strings.TrimSpace(w.input.Value())
+			}
+			return a.wizardStep(w.step - 1)
+		}
+		return nil
+	case key.Matches(msg, a.keys.Enter):
+		if w.step == wizardReview {
+			return a.createAgent()
+		}
+		value := strings.TrimSpace(w.input.Value())
+		if w.err = a.checkWizardValue(w.step, value); w.err != "" {
+			return nil
+		}
+		w.values[w.step] = value
+		return a.wizardStep(w.step + 1)
+	}
+	if w.step == wizardReview {
+		return nil
+	}
+	var cmd tea.Cmd
+	w.input, cmd = w.input.Update(msg)
+	return cmd
+}
+
+// closeAgentWizard closes the wizard
+func (a *App) closeAgentWizard() {
+	a.mode = ModeNormal
+	a.agentWizard.input.Blur()
+}
+
+// createAgent creates the agent reviewed in the output viewer, the review
+// having confirmed it
+func (a *App) createAgent() tea.Cmd {
+	w := &a.agentWizard
+	a.closeAgentWizard()
+	instance, values := w.instance, w.values
+	create := func(c *gateway.CLIAdapter) error {
+		return c.CreateAgent(values[wizardName], values[wizardWorkspace], values[wizardModel])
+	}
+	return a.runWrite(instance, w.commands, writeRequest{
+		title:   "New Agent",
+		prompt:  fmt.Sprintf("Create agent %s on %s?", values[wizardName], instance),
+		preview: create,
+		run: func() tea.Cmd {
+			adapter := a.cliAdapter()
+			w.creating = values[wizardName]
+			return func() tea.Msg {
+				return AgentCreatedMsg{Instance: instance, Name: values[wizardName], Error: create(adapter)}
+			}
+		},
+	})
+}
+
+func (a *App) handleAgentCreated(msg AgentCreatedMsg) tea.Cmd {
+	a.agentWizard.creating = ""
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	a.setFlash(fmt.Sprintf("Created agent %s on %s", msg.Name, msg.Instance), false)
+	// Pick up the new agent
+	return a.fetchCLIStatus()
+}
+
+// renderAgentWizard renders the new agent wizard: the fields entered, the
+// one being entered and, last, the review
+func (a *App) renderAgentWizard() string {
+	w := &a.agentWizard
+	content := styles.HelpTitle.Render("New Agent on "+w.instance) + "\n\n"
+	for step, label := range wizardLabels {
+		switch {
+		case step == w.step:
+			content += styles.SelectedItem.Render(fmt.Sprintf("> %-10s", label+":")) + " " + w.input.View() + "\n"
+		case step < w.step || w.step == wizardReview:
+			value := cmp.Or(w.values[step], styles.Muted.Render("default"))
+			content += fmt.Sprintf("  %-10s %s\n", label+":", value)
+		default:
+			content += styles.Muted.Render(fmt.Sprintf("  %-10s", label+":")) + "\n"
+		}
+	}
+	if w.err != "" {
+		content += "\n" + styles.LogError.Render(w.err) + "\n"
+	}
+	if w.step == wizardReview {
+		content += "\n" + a.renderWriteCommands(w.instance, w.target, w.commands, max(a.width-16, 40))
+	}
+
+	content += "\n"
+	if w.step == wizardReview {
+		content += styles.HintKey.Render("enter") + styles.Muted.Render(":create  ")
+	} else {
+		content += styles.Muted.Render(fmt.Sprintf("Step %d of %d  ", w.step+1, len(wizardLabels))) +
+			styles.HintKey.Render("enter") + styles.Muted.Render(":next  ")
+	}
+	if w.step > wizardName {
+		content += styles.HintKey.Render("shift+tab") + styles.Muted.Render(":back  ")
+	}
+	content += styles.HintKey.Render("esc") + styles.Muted.Render(":cancel")
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
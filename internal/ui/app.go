@@ -1,21 +1,24 @@
 package ui
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lazyclaw/lazyclaw/internal/config"
 	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/history"
 	"github.com/lazyclaw/lazyclaw/internal/models"
 	"github.com/lazyclaw/lazyclaw/internal/state"
 	"github.com/lazyclaw/lazyclaw/internal/ui/keys"
 	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+	"github.com/mattn/go-runewidth"
 )
 
 // AppMode represents the current mode of the application
@@ -26,6 +29,20 @@ const (
 	ModeHelp
 	ModeSearch
 	ModeActions
+	ModeMemorySearch
+	ModeConfigSearch
+	ModeConfigEdit
+	ModeHeartbeatEdit
+	ModePassphrase
+	ModeDiscovery
+	ModeCommandLog
+	ModePairing
+	ModeChannelSend
+	ModeDoctor
+	ModeUpdateChannel
+	ModeAgentWizard
+	ModeWriteConfirm
+	ModeActionOutput
 )
 
 // FocusedPane represents which pane has focus
@@ -50,10 +67,14 @@ const (
 	TabMemory
 	TabSecurity
 	TabSystem
+	TabUsage
+	TabConfig
+	TabHooks
+	TabQueues
 )
 
 func (t Tab) String() string {
-	names := []string{"Overview", "Logs", "Health", "Channels", "Agents", "Sessions", "Events", "Memory", "Security", "System"}
+	names := []string{"Overview", "Logs", "Health", "Channels", "Agents", "Sessions", "Events", "Memory", "Security", "System", "Usage", "Config", "Hooks", "Queues"}
 	if int(t) < len(names) {
 		return names[t]
 	}
@@ -73,32 +94,188 @@ type App struct {
 	height           int
 	selectedInstance int // Currently selected instance index
 
+	// Terminal size awaiting layout, and the layout of the applied size
+	pendingWidth, pendingHeight int
+	resizeSeq                   int
+	layout                      paneLayout
+
 	// Keys
 	keys keys.KeyMap
 
 	// Sub-models
-	searchInput textinput.Model
+	searchInput       textinput.Model
+	memorySearchInput textinput.Model
 
 	// Gateway connections - one per instance
-	mockClient  *gateway.MockClient
-	cliAdapters []*gateway.CLIAdapter // One adapter per configured instance
+	adapters    []gateway.Adapter // One adapter per configured instance
 
 	// Current instance state
 	connectionState  models.ConnectionState
-	logs             []models.LogEvent
+	logs             *logBuffer
 	healthSnapshot   *models.HealthSnapshot
 	healthCheckResult *models.HealthCheckResult
 	openclawStatus   *models.OpenClawStatus
+	channelsList     *models.ChannelsList
+	channelsStatus   *models.ChannelsStatus
+	linkEvents       []history.LinkEvent
+	staleSections    map[string]bool // Status sections the last poll left out
+	loading          loadingState    // First fetches still on the way
+	statusDeltas     statusDeltas    // What changed between status fetches
+	lastStatusAt     time.Time       // When openclawStatus was fetched
+	statusTimeout    error           // Set while status fetches time out
+	statusCached     bool            // openclawStatus was restored from the status cache
+	healthError      error           // Why the last health check failed, if it did
+
+	// Retries begun by the CLI adapters (see enableRetries)
+	retries chan gateway.RetryState
+
+	// Persistent history (link events etc.), nil if unavailable
+	history *history.Store
+
+	// Last periodic refresh, paced per tab by refreshDue
+	lastRefresh time.Time
+
+	// Polls the other instances for their badges
+	backgroundPoll backgroundPoller
+
+	// Tailnet scan for openclaw hosts to add as instances
+	discovery discoveryView
+
+	// Commands run against the current instance, read from the history
+	commandLog commandLogView
+
+	// Checks of the latest on-demand doctor run
+	doctor doctorView
+
+	// Operations of the active tab, offered by the actions popup (x)
+	actions actionsMenu
+
+	// Connect stages reported by the adapters, and the latest stage of
+	// each instance connecting
+	connects      chan gateway.ConnectProgress
+	connectStages map[string]gateway.ConnectStage
+
+	// Subscription to the current instance's pushed health updates
+	healthWatch healthWatch
+
+	// The terminal reported losing focus; refresh slows and streams
+	// collect without re-rendering until focus returns
+	blurred bool
+
+	// Rendered tab content, reused until stateVersion or (for the Logs and
+	// Events tabs) streamVersion changes
+	tabCache      renderCache
+	stateVersion  uint64
+	streamVersion uint64
+
+	// Debounced instance switching: only the latest switch loads data
+	switchSeq     int
+	switchPending bool
+
+	// Instance whose fetches' results are shown, see scoped
+	scope instanceScope
+
+	// Log streaming: the running follower (nil when not following) and the
+	// ID given to the last one started
+	logFollower    *logFollower
+	logFollowerSeq int
+
+	// Logs tab scrollback, in matching lines back from the newest (0 = live)
+	logScroll int
+
+	// Gateway event subscription feeding the Events tab
+	events eventStream
+
+	// Memory tab search
+	memorySearch      *models.MemorySearchResult
+	memorySearching   bool
+	memorySearchError string
+	memoryBrowser     memoryFileBrowser
+	reindex           reindexTracker
+	cacheClear        cacheClear
+
+	// Security tab
+	securitySeverity string
+	securityScroll   int
+	auditDiff        *history.AuditDiff
+	remediation      securityRemediation
+
+	// System tab state
+	update        updateTracker
+	updateChannel updateChannelSwitch
+	services      serviceControl
+	processes     processList
+
+	// Channels tab: selection, channel actions and the pairing modal
+	channels    channelControl
+	channelSend channelSend
+	pairing     channelPairing
+
+	// Hooks tab state
+	hooks webhookView
+
+	// Recent depth samples per queue for the Queues tab sparklines
+	queueDepths map[string][]int
+
+	// Recent gateway latency samples for the Overview sparkline
+	latencySamples []int
+
+	// Health tab manual probe state and per-component history
+	probe            healthProbe
+	hostKey          hostKeyReview
+	healthComponents map[string]history.ComponentState
 
-	// Log streaming
-	logChan       chan models.LogEvent
-	logCtx        context.Context
-	logCancel     context.CancelFunc
-	logFollowing  bool // Whether log following is active
+	// Usage tab state
+	usage usageState
+
+	// Gateway Config tab state
+	gatewayConfig gatewayConfigView
+
+	// Agents tab heartbeat interval editor
+	heartbeatInput textinput.Model
+
+	// Passphrase prompt for SSH keys ssh cannot unlock in batch mode
+	passphrase passphrasePrompt
+
+	// Confirmation of the write action about to run, and the output viewer
+	// it then runs in
+	writeConfirm writeConfirm
+	actionOutput actionOutput
+
+	// Transient message shown in the bottom bar
+	flash        string
+	flashIsError bool
+	flashAt      time.Time
+
+	// Agents tab selection, workspace browser, agent bootstrap and the new
+	// agent wizard
+	agentCursor int
+	workspace   workspaceBrowser
+	bootstrap   agentBootstrap
+	agentWizard agentWizard
+
+	// Sessions tab selection, paging and filters
+	sessionCursor      int // Index into the filtered sessions; its page is shown
+	sessionPageSize    int // Sessions on a page, as last rendered
+	sessionRunning     string // Key of the session a kill or reset is running on
+	compacting         string // Key of the session being compacted
+	sessionAgentFilter string // "" = all agents
+	sessionKindFilter  string // "" = all kinds, "direct", "group"
+	sessionMinPercent  int    // Only show sessions at or above this usage
 
 	// Flags
 	logFollow bool
 	mockMode  bool
+	debug     bool   // Show internal metrics such as command queueing
+	recordDir string // Fixtures of each instance's command output are recorded here
+	replayDir string // Instances are served from fixtures recorded here
+
+	// Recent messages and the report written if the UI panics
+	crash crashLog
+
+	// Performance overlay
+	perf     perfStats
+	showPerf bool
 }
 
 // NewApp creates a new application instance
@@ -107,15 +284,36 @@ func NewApp(cfg *config.Config, uiState *state.State, mockMode bool) *App {
 	ti.Placeholder = "Search..."
 	ti.CharLimit = 100
 
+	mi := textinput.New()
+	mi.Placeholder = "Search memory..."
+	mi.CharLimit = 200
+
+	hi := textinput.New()
+	hi.Placeholder = "30m"
+	hi.CharLimit = 20
+
 	app := &App{
-		config:      cfg,
-		mode:        ModeNormal,
-		focusedPane: FocusedPane(uiState.FocusedPane),
-		activeTab:   Tab(uiState.ActiveTab),
-		keys:        keys.DefaultKeyMap(),
-		searchInput: ti,
-		logFollow:   uiState.LogFollow,
-		mockMode:    mockMode,
+		config:            cfg,
+		mode:              ModeNormal,
+		focusedPane:       FocusedPane(uiState.FocusedPane),
+		activeTab:         Tab(uiState.ActiveTab),
+		keys:              keys.DefaultKeyMap(),
+		searchInput:       ti,
+		memorySearchInput: mi,
+		gatewayConfig:     newGatewayConfigView(),
+		heartbeatInput:    hi,
+		passphrase:        newPassphrasePrompt(),
+		channelSend:       newChannelSend(),
+		agentWizard:       newAgentWizard(),
+		writeConfirm:      newWriteConfirm(),
+		logs:              newLogBuffer(cfg.UI.LogTailLines, logArchiveBudget(cfg.UI.LogArchiveMB)),
+		logFollow:         uiState.LogFollow,
+		mockMode:          mockMode,
+	}
+
+	// History is best effort; a nil store records nothing
+	if store, err := history.Open(); err == nil {
+		app.history = store
 	}
 
 	// Add a mock instance if in mock mode and no instances configured
@@ -129,6 +327,40 @@ func NewApp(cfg *config.Config, uiState *state.State, mockMode bool) *App {
 	return app
 }
 
+// SetDebug enables debug mode, which shows internal metrics in the bottom bar
+func (a *App) SetDebug(debug bool) {
+	a.debug = debug
+}
+
+// SetRecordDir records the raw command output of each instance under dir,
+// as fixtures SetReplayDir can serve back
+func (a *App) SetRecordDir(dir string) {
+	a.recordDir = dir
+}
+
+// SetReplayDir serves each instance from the fixtures recorded for it under
+// dir, instead of running any command
+func (a *App) SetReplayDir(dir string) {
+	a.replayDir = dir
+}
+
+// Shutdown stops the log and event streams, terminates child ssh/openclaw
+// processes, waiting at most timeout for them to exit, and closes history
+// once in-progress writes finish. Call it after the program exits.
+func (a *App) Shutdown(timeout time.Duration) {
+	a.stopLogFollowing()
+	a.stopEventStream()
+	a.stopHealthWatch()
+	a.saveStatusCache()
+	for _, adapter := range a.adapters {
+		_ = adapter.Close()
+	}
+	gateway.Shutdown(timeout)
+	if a.history != nil {
+		a.history.Close()
+	}
+}
+
 // GetState returns the current UI state for persistence
 func (a *App) GetState() *state.State {
 	// Resolve selected instance index to name
@@ -148,23 +380,32 @@ func (a *App) GetState() *state.State {
 	}
 }
 
-// MockLogTickMsg is sent when a mock log event is available
-type MockLogTickMsg struct{}
-
 // CLIStatusMsg is sent when CLI status fetch completes
 type CLIStatusMsg struct {
-	Status *models.OpenClawStatus
-	Error  error
-}
-
-// CLILogMsg is sent when a log event arrives from CLI
-type CLILogMsg struct {
-	Event models.LogEvent
+	Status     *models.OpenClawStatus
+	LinkEvents []history.LinkEvent
+	AuditDiff  *history.AuditDiff
+	Excluded   []string // Sections left out of the fetch
+	Error      error
 }
 
 // CLIHealthMsg is sent when CLI health fetch completes
 type CLIHealthMsg struct {
-	Result *models.HealthCheckResult
+	Result     *models.HealthCheckResult
+	Components map[string]history.ComponentState
+	Error      error
+}
+
+// CLIChannelsMsg is sent when CLI channels fetch completes
+type CLIChannelsMsg struct {
+	Channels *models.ChannelsList
+	Status   *models.ChannelsStatus // nil if openclaw cannot report connection state
+	Error    error
+}
+
+// CLIMemorySearchMsg is sent when a memory search completes
+type CLIMemorySearchMsg struct {
+	Result *models.MemorySearchResult
 	Error  error
 }
 
@@ -175,100 +416,250 @@ type RefreshTickMsg struct{}
 func (a *App) Init() tea.Cmd {
 	var cmds []tea.Cmd
 
-	if a.mockMode {
-		// In mock mode, create mock client and start receiving logs
-		cmds = append(cmds, a.connectMock())
-	} else {
-		// Create CLI adapters for all configured instances
-		a.initCLIAdapters()
+	// Create adapters for all configured instances
+	a.initAdapters()
+	a.enableBreakers()
+	a.enableCommandLog()
+	cmds = append(cmds, a.enableRetries(), a.enableConnectProgress())
 
-		// Fetch status and health for current instance
-		cmds = append(cmds, a.fetchCLIStatus())
-		cmds = append(cmds, a.fetchCLIHealth())
+	// Fetch data and start the log and event streams for the current
+	// instance; the first frame renders loading placeholders meanwhile
+	cmds = append(cmds, a.loadInstance())
 
-		// Start log following for current instance
-		cmds = append(cmds, a.startLogFollowing())
+	// Start periodic refresh, and background polling of the other instances
+	cmds = append(cmds, a.scheduleRefresh(), a.scheduleBackgroundPoll())
 
-		// Start periodic refresh
-		cmds = append(cmds, a.scheduleRefresh())
-	}
-
-	return tea.Batch(cmds...)
+	return a.guardCmd(tea.Batch(cmds...))
 }
 
-// initCLIAdapters creates CLI adapters for all configured instances
-func (a *App) initCLIAdapters() {
-	a.cliAdapters = nil
+// initAdapters creates adapters for all configured instances: mock gateways
+// in mock mode, CLI adapters otherwise
+func (a *App) initAdapters() {
+	a.adapters = nil
+	gateway.SetConcurrency(a.config.MaxConcurrentCommands)
+
+	if a.mockMode {
+		for _, inst := range a.config.Instances {
+			a.adapters = append(a.adapters, gateway.NewMockClient(inst.Name))
+		}
+		return
+	}
+
+	if a.replayDir != "" {
+		// Without instances, the fixtures recorded for the local fallback
+		names := []string{"Local"}
+		if len(a.config.Instances) > 0 {
+			names = nil
+			for _, inst := range a.config.Instances {
+				names = append(names, inst.Name)
+			}
+		}
+		for _, name := range names {
+			a.adapters = append(a.adapters, gateway.NewReplayAdapter(name, gateway.FixtureDir(a.replayDir, name)))
+		}
+		return
+	}
 
 	// If no instances configured, create a local adapter
 	if len(a.config.Instances) == 0 {
 		adapter := gateway.NewCLIAdapter()
 		adapter.InstanceName = "Local"
+		adapter.MaxRecentSessions = a.config.MaxRecentSessions
+		adapter.Timeout = a.config.DefaultInstanceTimeout()
+		adapter.Timeouts = a.config.InstanceCommandTimeouts(models.InstanceProfile{})
+		adapter.RecordDir = a.fixtureDir(adapter.InstanceName)
+		adapter.Scopes = a.config.InstanceScopes(models.InstanceProfile{})
+		adapter.Socket = controlSocket(models.InstanceProfile{Name: adapter.InstanceName}, adapter.Timeout)
 		if a.config.OpenClawCLI != "" {
 			adapter.BinaryPath = a.config.OpenClawCLI
 		}
-		a.cliAdapters = append(a.cliAdapters, adapter)
+		a.adapters = append(a.adapters, adapter)
 		return
 	}
 
 	// Create an adapter for each configured instance
-	for _, inst := range a.config.Instances {
-		var adapter *gateway.CLIAdapter
-
-		switch inst.Mode {
-		case models.ConnectionModeSSH:
-			if inst.SSH != nil {
-				// Check for openclaw_cli in both instance level and ssh level
-				openclawPath := inst.OpenClawCLI
-				if openclawPath == "" && inst.SSH.OpenClawCLI != "" {
-					openclawPath = inst.SSH.OpenClawCLI
-				}
-				adapter = gateway.NewSSHCLIAdapter(inst.Name, inst.SSH, openclawPath)
-			} else {
-				// SSH mode but no SSH config - skip
-				continue
-			}
-		default: // Local mode
-			adapter = gateway.NewCLIAdapter()
-			adapter.InstanceName = inst.Name
-			if inst.OpenClawCLI != "" {
-				adapter.BinaryPath = inst.OpenClawCLI
-			} else if a.config.OpenClawCLI != "" {
-				adapter.BinaryPath = a.config.OpenClawCLI
-			}
+	for _, inst := range a.config.ResolvedInstances() {
+		if adapter := a.instanceAdapter(inst); adapter != nil {
+			a.adapters = append(a.adapters, adapter)
 		}
-
-		a.cliAdapters = append(a.cliAdapters, adapter)
 	}
 
 	// Ensure we have at least one adapter
-	if len(a.cliAdapters) == 0 {
+	if len(a.adapters) == 0 {
 		adapter := gateway.NewCLIAdapter()
 		adapter.InstanceName = "Local"
-		a.cliAdapters = append(a.cliAdapters, adapter)
+		adapter.MaxRecentSessions = a.config.MaxRecentSessions
+		adapter.Timeout = a.config.DefaultInstanceTimeout()
+		adapter.Timeouts = a.config.InstanceCommandTimeouts(models.InstanceProfile{})
+		adapter.RecordDir = a.fixtureDir(adapter.InstanceName)
+		adapter.Scopes = a.config.InstanceScopes(models.InstanceProfile{})
+		adapter.Socket = controlSocket(models.InstanceProfile{Name: adapter.InstanceName}, adapter.Timeout)
+		a.adapters = append(a.adapters, adapter)
 	}
 }
 
-// getCurrentAdapter returns the CLI adapter for the currently selected instance
-func (a *App) getCurrentAdapter() *gateway.CLIAdapter {
-	if len(a.cliAdapters) == 0 {
+// instanceAdapter creates the adapter for a resolved instance: a WebSocket
+// client in ws mode, else the CLI adapter. It returns nil if the instance
+// lacks the settings its mode needs.
+func (a *App) instanceAdapter(inst models.InstanceProfile) gateway.Adapter {
+	if inst.Mode == models.ConnectionModeWS {
+		if inst.WS == nil {
+			return nil
+		}
+		client := gateway.NewWSClient(inst.Name, inst.WS.URL, inst.WS.Token, a.config.InstanceScopes(inst))
+		client.Timeout = a.config.InstanceTimeout(inst)
+		return client
+	}
+	if adapter := a.newInstanceAdapter(inst); adapter != nil {
+		return adapter
+	}
+	return nil
+}
+
+// newInstanceAdapter creates the CLI adapter for a resolved instance, or
+// returns nil if the instance lacks the settings its mode needs
+func (a *App) newInstanceAdapter(inst models.InstanceProfile) *gateway.CLIAdapter {
+	var adapter *gateway.CLIAdapter
+
+	switch inst.Mode {
+	case models.ConnectionModeSSH:
+		if inst.SSH != nil {
+			// Check for openclaw_cli in both instance level and ssh level
+			openclawPath := inst.OpenClawCLI
+			if openclawPath == "" && inst.SSH.OpenClawCLI != "" {
+				openclawPath = inst.SSH.OpenClawCLI
+			}
+			adapter = gateway.NewSSHCLIAdapter(inst.Name, inst.SSH, openclawPath)
+			adapter.KnownHostsFile, _ = config.KnownHostsPath()
+		} else {
+			// SSH mode but no SSH config - skip
+			return nil
+		}
+	case models.ConnectionModeK8s:
+		if inst.K8s == nil {
+			// k8s mode but no k8s config - skip
+			return nil
+		}
+		openclawPath := inst.OpenClawCLI
+		if openclawPath == "" {
+			openclawPath = inst.K8s.OpenClawCLI
+		}
+		adapter = gateway.NewK8sCLIAdapter(inst.Name, inst.K8s, openclawPath)
+	default: // Local mode
+		adapter = gateway.NewCLIAdapter()
+		adapter.InstanceName = inst.Name
+		if inst.OpenClawCLI != "" {
+			adapter.BinaryPath = inst.OpenClawCLI
+		} else if a.config.OpenClawCLI != "" {
+			adapter.BinaryPath = a.config.OpenClawCLI
+		}
+		adapter.Socket = controlSocket(inst, a.config.InstanceTimeout(inst))
+	}
+	adapter.MaxRecentSessions = a.config.MaxRecentSessions
+	adapter.Timeout = a.config.InstanceTimeout(inst)
+	adapter.Timeouts = a.config.InstanceCommandTimeouts(inst)
+	adapter.Escalation = inst.Escalation
+	adapter.RecordDir = a.fixtureDir(inst.Name)
+	adapter.Scopes = a.config.InstanceScopes(inst)
+	adapter.Env = inst.Env
+	adapter.GRPC = a.grpcAPI(inst)
+	return adapter
+}
+
+// grpcAPI returns an adapter for the instance's gRPC control API, or nil if
+// it has none or its certificates cannot be loaded, leaving the CLI to it
+func (a *App) grpcAPI(inst models.InstanceProfile) *gateway.GRPCAdapter {
+	if inst.GRPC == nil {
 		return nil
 	}
-	if a.selectedInstance < 0 || a.selectedInstance >= len(a.cliAdapters) {
-		return a.cliAdapters[0]
+	api, err := gateway.NewGRPCAdapter(inst.Name, inst.GRPC)
+	if err != nil {
+		a.setFlash(inst.Name+": gRPC API disabled: "+err.Error(), true)
+		return nil
 	}
-	return a.cliAdapters[a.selectedInstance]
+	api.Timeout = a.config.InstanceTimeout(inst)
+	api.MaxRecentSessions = a.config.MaxRecentSessions
+	return api
+}
+
+// controlSocket returns an adapter for a local instance's gateway control
+// socket: the one its socket setting names, else one found in its openclaw
+// home. It returns nil if there is none, or the setting is "off".
+func controlSocket(inst models.InstanceProfile, timeout time.Duration) *gateway.SocketAdapter {
+	path := inst.Socket
+	switch path {
+	case "off":
+		return nil
+	case "":
+		if path = gateway.DetectSocket(inst.Env["OPENCLAW_HOME"]); path == "" {
+			return nil
+		}
+	}
+	socket := gateway.NewSocketAdapter(inst.Name, path)
+	socket.Timeout = timeout
+	return socket
+}
+
+// fixtureDir returns where an instance's command output is recorded, or ""
+// if it is not
+func (a *App) fixtureDir(instance string) string {
+	if a.recordDir == "" {
+		return ""
+	}
+	return gateway.FixtureDir(a.recordDir, instance)
+}
+
+// getCurrentAdapter returns the adapter for the currently selected instance
+func (a *App) getCurrentAdapter() gateway.Adapter {
+	if len(a.adapters) == 0 {
+		return nil
+	}
+	if a.selectedInstance < 0 || a.selectedInstance >= len(a.adapters) {
+		return a.adapters[0]
+	}
+	return a.adapters[a.selectedInstance]
+}
+
+// cliAdapter returns the current adapter if it runs the openclaw CLI, which
+// features beyond status, health and logs require, or nil
+func (a *App) cliAdapter() *gateway.CLIAdapter {
+	adapter, _ := a.getCurrentAdapter().(*gateway.CLIAdapter)
+	return adapter
 }
 
 // Update implements tea.Model
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer a.recoverPanic()
+	defer a.perf.timeUpdate(time.Now())
+	if scoped, ok := msg.(instanceMsg); ok {
+		if msg = a.unscope(scoped); msg == nil {
+			return a, nil
+		}
+	}
+	a.crash.record(msg)
+	model, cmd := a.handleMsg(msg)
+	return model, a.guardCmd(cmd)
+}
+
+func (a *App) handleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	a.noteUpdate(msg)
+	prevTab := a.activeTab
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		a.width = msg.Width
-		a.height = msg.Height
-		a.updateViewportSizes()
+		if cmd := a.handleResize(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ResizeSettledMsg:
+		a.handleResizeSettled(msg)
+
+	case tea.BlurMsg:
+		a.blurred = true
+
+	case tea.FocusMsg:
+		a.blurred = false
 
 	case tea.KeyMsg:
 		// Handle help mode
@@ -280,6 +671,34 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		if a.mode == ModeDiscovery {
+			return a, a.handleDiscoveryKey(msg)
+		}
+		if a.mode == ModeCommandLog {
+			return a, a.handleCommandLogKey(msg)
+		}
+		if a.mode == ModeActions {
+			return a, a.handleActionsKey(msg)
+		}
+		if a.mode == ModePairing {
+			return a, a.handlePairingKey(msg)
+		}
+		if a.mode == ModeDoctor {
+			return a, a.handleDoctorKey(msg)
+		}
+		if a.mode == ModeUpdateChannel {
+			return a, a.handleUpdateChannelKey(msg)
+		}
+		if a.mode == ModeAgentWizard {
+			return a, a.handleAgentWizardKey(msg)
+		}
+		if a.mode == ModeWriteConfirm {
+			return a, a.handleWriteConfirmKey(msg)
+		}
+		if a.mode == ModeActionOutput {
+			return a, a.handleActionOutputKey(msg)
+		}
+
 		// Handle search mode
 		if a.mode == ModeSearch {
 			if key.Matches(msg, a.keys.Escape) {
@@ -297,6 +716,87 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, cmd
 		}
 
+		// Handle memory search input
+		if a.mode == ModeMemorySearch {
+			if key.Matches(msg, a.keys.Escape) {
+				a.mode = ModeNormal
+				a.memorySearchInput.Blur()
+				return a, nil
+			}
+			if key.Matches(msg, a.keys.Enter) {
+				a.mode = ModeNormal
+				a.memorySearchInput.Blur()
+				query := strings.TrimSpace(a.memorySearchInput.Value())
+				if query == "" || a.cliAdapter() == nil {
+					return a, nil
+				}
+				a.memorySearching = true
+				a.memorySearchError = ""
+				return a, a.searchMemory(query)
+			}
+			var cmd tea.Cmd
+			a.memorySearchInput, cmd = a.memorySearchInput.Update(msg)
+			return a, cmd
+		}
+
+		// Handle gateway config filter and edit input
+		if a.mode == ModeConfigSearch {
+			if key.Matches(msg, a.keys.Escape) {
+				a.gatewayConfig.filter.Reset()
+			}
+			if key.Matches(msg, a.keys.Escape) || key.Matches(msg, a.keys.Enter) {
+				a.mode = ModeNormal
+				a.gatewayConfig.filter.Blur()
+				return a, nil
+			}
+			var cmd tea.Cmd
+			a.gatewayConfig.filter, cmd = a.gatewayConfig.filter.Update(msg)
+			a.gatewayConfig.cursor = 0
+			return a, cmd
+		}
+		if a.mode == ModePassphrase {
+			if key.Matches(msg, a.keys.Escape) {
+				a.closePassphrasePrompt()
+				return a, nil
+			}
+			if key.Matches(msg, a.keys.Enter) {
+				return a, a.submitPassphrase()
+			}
+			var cmd tea.Cmd
+			a.passphrase.input, cmd = a.passphrase.input.Update(msg)
+			return a, cmd
+		}
+		if a.mode == ModeChannelSend {
+			return a, a.handleChannelSendKey(msg)
+		}
+		if a.mode == ModeHeartbeatEdit {
+			if key.Matches(msg, a.keys.Escape) {
+				a.mode = ModeNormal
+				a.heartbeatInput.Blur()
+				return a, nil
+			}
+			if key.Matches(msg, a.keys.Enter) {
+				return a, a.applyHeartbeatEdit()
+			}
+			var cmd tea.Cmd
+			a.heartbeatInput, cmd = a.heartbeatInput.Update(msg)
+			return a, cmd
+		}
+		if a.mode == ModeConfigEdit {
+			if key.Matches(msg, a.keys.Escape) {
+				a.mode = ModeNormal
+				a.gatewayConfig.edit.Blur()
+				a.gatewayConfig.editKey = ""
+				return a, nil
+			}
+			if key.Matches(msg, a.keys.Enter) {
+				return a, a.applyConfigEdit()
+			}
+			var cmd tea.Cmd
+			a.gatewayConfig.edit, cmd = a.gatewayConfig.edit.Update(msg)
+			return a, cmd
+		}
+
 		// Normal mode keybindings
 		switch {
 		case key.Matches(msg, a.keys.Quit):
@@ -306,11 +806,49 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.mode = ModeHelp
 			return a, nil
 
+		case key.Matches(msg, a.keys.PerfOverlay):
+			a.showPerf = !a.showPerf
+			return a, nil
+
+		case key.Matches(msg, a.keys.Discover):
+			return a, a.openDiscovery()
+
+		case key.Matches(msg, a.keys.Doctor):
+			return a, a.openDoctor()
+
+		case key.Matches(msg, a.keys.Actions):
+			a.openActions()
+			return a, nil
+
 		case key.Matches(msg, a.keys.Search):
+			if a.activeTab == TabMemory {
+				a.mode = ModeMemorySearch
+				a.memorySearchInput.Focus()
+				return a, textinput.Blink
+			}
+			if a.activeTab == TabConfig {
+				a.mode = ModeConfigSearch
+				a.gatewayConfig.filter.Focus()
+				return a, textinput.Blink
+			}
 			a.mode = ModeSearch
 			a.searchInput.Focus()
 			return a, textinput.Blink
 
+		case key.Matches(msg, a.keys.Escape):
+			if a.activeTab == TabAgents {
+				a.workspace = workspaceBrowser{}
+			}
+			if a.activeTab == TabMemory {
+				a.memorySearch = nil
+				a.memorySearchError = ""
+				a.memorySearchInput.Reset()
+			}
+			if a.activeTab == TabConfig {
+				a.gatewayConfig.filter.Reset()
+				a.gatewayConfig.cursor = 0
+			}
+
 		case key.Matches(msg, a.keys.Tab):
 			if a.focusedPane == PaneInstances {
 				a.focusedPane = PaneDetails
@@ -345,23 +883,241 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.activeTab = TabSecurity
 		case key.Matches(msg, a.keys.Tab10):
 			a.activeTab = TabSystem
+		case key.Matches(msg, a.keys.Tab11):
+			a.activeTab = TabUsage
+		case key.Matches(msg, a.keys.Tab13):
+			a.activeTab = TabHooks
+		case key.Matches(msg, a.keys.Tab14):
+			a.activeTab = TabQueues
+
+		case key.Matches(msg, a.keys.Tab12):
+			a.activeTab = TabConfig
 
 		case key.Matches(msg, a.keys.ToggleFollow):
 			a.logFollow = !a.logFollow
 
+		case key.Matches(msg, a.keys.PageDown):
+			switch a.activeTab {
+			case TabSessions:
+				a.sessionCursor += a.sessionPageSize
+			case TabSecurity:
+				a.securityScroll += a.pageSize()
+			case TabUsage:
+				a.usage.scroll += a.pageSize()
+			case TabLogs:
+				a.logScroll = max(a.logScroll-a.pageSize(), 0)
+			}
+
+		case key.Matches(msg, a.keys.PageUp):
+			switch a.activeTab {
+			case TabSessions:
+				a.sessionCursor = max(a.sessionCursor-a.sessionPageSize, 0)
+			case TabSecurity:
+				a.securityScroll -= a.pageSize()
+			case TabUsage:
+				a.usage.scroll -= a.pageSize()
+			case TabLogs:
+				a.logScroll += a.pageSize()
+			}
+
+		case a.activeTab == TabUsage && key.Matches(msg, a.keys.UsagePeriod):
+			if a.cliAdapter() != nil {
+				cmds = append(cmds, a.cycleUsagePeriod())
+			}
+
+		case a.activeTab == TabEvents && key.Matches(msg, a.keys.FilterSeverity):
+			a.cycleEventSeverity()
+
+		case a.activeTab == TabSecurity && key.Matches(msg, a.keys.FilterSeverity):
+			a.securitySeverity = nextOption(a.securitySeverity, []string{severityCritical, severityWarn})
+			a.securityScroll = 0
+			a.remediation.cursor = 0
+
+		case a.activeTab == TabSecurity && key.Matches(msg, a.keys.Export):
+			if path, err := a.exportSecurityAudit(); err != nil {
+				a.setFlash("Export failed: "+err.Error(), true)
+			} else {
+				a.setFlash("Exported audit to "+strings.TrimSuffix(path, ".md")+".{md,csv}", false)
+			}
+
+		case a.activeTab == TabSecurity && key.Matches(msg, a.keys.ApplyFix):
+			if cmd := a.confirmRemediation(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSessions && key.Matches(msg, a.keys.FilterAgent):
+			a.sessionAgentFilter = nextOption(a.sessionAgentFilter, a.sessionAgentIDs())
+			a.sessionCursor = 0
+
+		case a.activeTab == TabSessions && key.Matches(msg, a.keys.FilterKind):
+			a.sessionKindFilter = nextOption(a.sessionKindFilter, []string{"direct", "group"})
+			a.sessionCursor = 0
+
+		case a.activeTab == TabSessions && key.Matches(msg, a.keys.KillSession):
+			if cmd := a.confirmSessionAction("kill"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSessions && key.Matches(msg, a.keys.ResetSession):
+			if cmd := a.confirmSessionAction("reset"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSessions && key.Matches(msg, a.keys.CompactSession):
+			if cmd := a.compactSession(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSessions && key.Matches(msg, a.keys.FilterUsage):
+			switch a.sessionMinPercent {
+			case 0:
+				a.sessionMinPercent = 50
+			case 50:
+				a.sessionMinPercent = 80
+			default:
+				a.sessionMinPercent = 0
+			}
+			a.sessionCursor = 0
+
 		case key.Matches(msg, a.keys.Reconnect):
-			if a.mockMode {
-				cmds = append(cmds, a.connectMock())
-			} else if a.getCurrentAdapter() != nil {
+			if a.getCurrentAdapter() != nil {
 				cmds = append(cmds, a.fetchCLIStatus())
 				cmds = append(cmds, a.fetchCLIHealth())
-				a.stopLogFollowing()
 				cmds = append(cmds, a.startLogFollowing())
+				cmds = append(cmds, a.startEventStream())
+				cmds = append(cmds, a.startHealthWatch())
+			}
+
+		case a.activeTab == TabAgents && !a.workspace.open && key.Matches(msg, a.keys.AgentSessions):
+			a.showAgentSessions()
+
+		case a.activeTab == TabAgents && !a.workspace.open && key.Matches(msg, a.keys.HeartbeatToggle):
+			if cmd := a.toggleHeartbeat(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabAgents && !a.workspace.open && key.Matches(msg, a.keys.HeartbeatInterval):
+			if cmd := a.startHeartbeatEdit(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabAgents && !a.workspace.open && key.Matches(msg, a.keys.HeartbeatDefault):
+			if cmd := a.setDefaultHeartbeatAgent(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabAgents && !a.workspace.open && key.Matches(msg, a.keys.BootstrapAgent):
+			if cmd := a.bootstrapAgent(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabAgents && !a.workspace.open && key.Matches(msg, a.keys.NewAgent):
+			if cmd := a.openAgentWizard(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabMemory && key.Matches(msg, a.keys.ClearCache):
+			if cmd := a.confirmCacheClear(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabMemory && key.Matches(msg, a.keys.Reindex):
+			if cmd := a.startReindex(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabHealth && key.Matches(msg, a.keys.AcceptHostKey):
+			if cmd := a.reviewHostKey(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabHealth && key.Matches(msg, a.keys.Probe):
+			if cmd := a.startHealthProbe(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.UpdateGateway):
+			if cmd := a.startUpdate(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.UpdateChannel):
+			a.openUpdateChannels()
+
+		case a.activeTab == TabChannels && key.Matches(msg, a.keys.ChannelLink):
+			if cmd := a.linkChannel(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabChannels && key.Matches(msg, a.keys.ChannelUnlink):
+			if cmd := a.confirmChannelAction("unlink"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabChannels && key.Matches(msg, a.keys.ChannelRestart):
+			if cmd := a.confirmChannelAction("restart"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabChannels && key.Matches(msg, a.keys.ChannelSend):
+			if cmd := a.openChannelSend(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.ServiceStart):
+			if cmd := a.controlService("start"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.ServiceStop):
+			if cmd := a.controlService("stop"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.ServiceRestart):
+			if cmd := a.controlService("restart"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.ServiceBoot):
+			if cmd := a.toggleServiceBoot(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.ServiceLogs):
+			a.showServiceLogs()
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.CommandLog):
+			if cmd := a.openCommandLog(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabHooks && key.Matches(msg, a.keys.TestWebhook):
+			if cmd := a.testWebhook(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.ProcessTerm):
+			if cmd := a.signalProcess("TERM"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabSystem && key.Matches(msg, a.keys.ProcessKill):
+			if cmd := a.signalProcess("KILL"); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
+		case a.activeTab == TabMemory && key.Matches(msg, a.keys.BrowseFiles):
+			if cmd := a.toggleMemoryBrowser(); cmd != nil {
+				cmds = append(cmds, cmd)
 			}
 
 		case key.Matches(msg, a.keys.Up):
+			if a.focusedPane == PaneDetails {
+				a.moveDetailsSelection(-1)
+			}
 			// Navigate instances when left pane is focused
-			if a.focusedPane == PaneInstances && len(a.cliAdapters) > 1 {
+			if a.focusedPane == PaneInstances && len(a.adapters) > 1 {
 				if a.selectedInstance > 0 {
 					a.selectedInstance--
 					a.switchInstance(&cmds)
@@ -369,93 +1125,379 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, a.keys.Down):
+			if a.focusedPane == PaneDetails {
+				a.moveDetailsSelection(1)
+			}
 			// Navigate instances when left pane is focused
-			if a.focusedPane == PaneInstances && len(a.cliAdapters) > 1 {
-				if a.selectedInstance < len(a.cliAdapters)-1 {
+			if a.focusedPane == PaneInstances && len(a.adapters) > 1 {
+				if a.selectedInstance < len(a.adapters)-1 {
 					a.selectedInstance++
 					a.switchInstance(&cmds)
 				}
 			}
 
+		case a.activeTab == TabAgents && a.workspace.open && key.Matches(msg, a.keys.Back):
+			if cmd := a.workspaceBack(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+
 		case key.Matches(msg, a.keys.Enter):
 			// Select instance and switch to details pane
 			if a.focusedPane == PaneInstances {
 				a.focusedPane = PaneDetails
 				cmds = append(cmds, a.fetchCLIStatus())
 				cmds = append(cmds, a.fetchCLIHealth())
+			} else if a.activeTab == TabConfig {
+				if cmd := a.startConfigEdit(); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			} else if a.activeTab == TabSystem {
+				if cmd := a.toggleService(); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			} else if a.activeTab == TabAgents && a.cliAdapter() != nil {
+				var cmd tea.Cmd
+				if a.workspace.open {
+					cmd = a.workspaceEnter()
+				} else {
+					cmd = a.openWorkspaceBrowser()
+				}
+				if cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			}
+		}
+
+	case gateway.ConnectedMsg:
+		a.connectionState.Connected = true
+		a.connectionState.LastError = ""
+		a.connectionState.Scopes = msg.Scopes
+		a.connectionState.ProtocolVersion = msg.ProtocolVersion
+		a.connectionState.GatewayVersion = msg.GatewayVersion
+
+	case gateway.DisconnectedMsg:
+		a.connectionState.Connected = false
+		a.connectionState.LastError = msg.Error
+
+	case gateway.LogMsg:
+		a.appendLog(msg.Event)
+
+	case gateway.HealthMsg:
+		a.healthSnapshot = &msg.Snapshot
+
+	case CLIStatusMsg:
+		a.loading.status = false
+		if a.noteStatusTimeout(msg.Error) {
+			// Keep showing the last good data; the instance is degraded
+		} else if msg.Error != nil {
+			a.connectionState.Connected = false
+			a.connectionState.LastError = msg.Error.Error()
+			cmds = append(cmds, a.checkPassphrase(msg.Error))
+		} else {
+			a.keepExcludedSections(&msg)
+			a.applyStatusDelta(msg.Status)
+			a.openclawStatus = msg.Status
+			a.lastStatusAt = time.Now()
+			a.statusCached = false
+			a.linkEvents = msg.LinkEvents
+			a.auditDiff = msg.AuditDiff
+			a.recordQueueDepths(msg.Status.Queues)
+			a.checkPairing()
+			a.checkRemediation(msg)
+			a.checkUpdate()
+			a.checkUpdateChannel()
+			a.checkAgentBootstrap(msg)
+			// Update connection state from CLI status
+			if msg.Status.Gateway != nil {
+				a.recordLatency(msg.Status.Gateway)
+				a.connectionState.Connected = msg.Status.Gateway.Reachable
+				if msg.Status.Gateway.Self.Version != "" {
+					a.connectionState.GatewayVersion = msg.Status.Gateway.Self.Version
+				}
+				if msg.Status.Gateway.Error != nil {
+					a.connectionState.LastError = *msg.Status.Gateway.Error
+				} else {
+					a.connectionState.LastError = ""
+				}
+			}
+			if a.activeTab == TabSystem {
+				if cmd := a.fetchChangelog(); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 
-	case gateway.ConnectedMsg:
-		a.connectionState.Connected = true
-		a.connectionState.LastError = ""
-		a.connectionState.Scopes = msg.Scopes
-		a.connectionState.ProtocolVersion = msg.ProtocolVersion
-		a.connectionState.GatewayVersion = msg.GatewayVersion
-		// If mock mode, start listening for mock logs
-		if a.mockMode && a.mockClient != nil {
-			cmds = append(cmds, a.waitForMockLog())
+	case CLIHealthMsg:
+		a.loading.health = false
+		a.healthError = msg.Error
+		var timeout *gateway.TimeoutError
+		if msg.Error == nil {
+			a.setHealthResult(msg.Result, msg.Components)
+		} else if errors.As(msg.Error, &timeout) {
+			a.setFlash("Health check timed out after "+timeout.After.String(), true)
+		} else if a.hostKeyRejected() {
+			a.setFlash("Unknown SSH host key: review it on the Health tab (A)", true)
+		} else {
+			cmds = append(cmds, a.checkPassphrase(msg.Error))
+		}
+
+	case HealthPushMsg:
+		cmds = append(cmds, a.handleHealthPush(msg))
+
+	case RetryMsg:
+		// The banner and badge read the adapter's retry state; keep listening
+		cmds = append(cmds, waitForRetry(a.retries))
+
+	case ConnectingMsg:
+		cmds = append(cmds, a.handleConnecting(msg))
+
+	case ConnectProgressMsg:
+		cmds = append(cmds, a.handleConnectProgress(msg))
+
+	case ConnectFailedMsg:
+		cmds = append(cmds, a.handleConnectFailed(msg))
+
+	case HealthProbeMsg:
+		a.handleHealthProbe(msg)
+
+	case KeyCheckedMsg:
+		cmds = append(cmds, a.handleKeyChecked(msg))
+
+	case KeyUnlockedMsg:
+		cmds = append(cmds, a.handleKeyUnlocked(msg))
+
+	case HostKeyScannedMsg:
+		a.handleHostKeyScanned(msg)
+
+	case DiscoveryMsg:
+		a.handleDiscovery(msg)
+	case CommandLogMsg:
+		a.handleCommandLog(msg)
+
+	case HostKeyTrustedMsg:
+		cmds = append(cmds, a.handleHostKeyTrusted(msg))
+
+	case spinner.TickMsg:
+		if a.probe.running {
+			var cmd tea.Cmd
+			a.probe.spinner, cmd = a.probe.spinner.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+
+	case CLIMemorySearchMsg:
+		a.memorySearching = false
+		if msg.Error != nil {
+			a.memorySearchError = msg.Error.Error()
+			a.memorySearch = nil
+		} else {
+			a.memorySearch = msg.Result
+		}
+
+	case CLIMemoryIndexMsg:
+		if cmd := a.handleMemoryIndex(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ReindexPollMsg:
+		if a.reindex.indexing() && a.activeTab == TabMemory {
+			cmds = append(cmds, a.fetchMemoryIndexStatus())
+		} else {
+			a.reindex.polling = false
+		}
+
+	case ReindexDoneMsg:
+		if cmd := a.handleReindexDone(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ChangelogMsg:
+		a.handleChangelog(msg)
+
+	case UpdateDoneMsg:
+		if cmd := a.handleUpdateDone(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case UpdateChannelMsg:
+		if cmd := a.handleUpdateChannel(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ServiceActionMsg:
+		if cmd := a.handleServiceAction(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ActionOutputMsg:
+		if cmd := a.handleActionOutput(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ActionDoneMsg:
+		if cmd := a.handleActionDone(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case PairingOutputMsg:
+		if cmd := a.handlePairingOutput(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case PairingDoneMsg:
+		a.handlePairingDone(msg)
+
+	case SessionCompactedMsg:
+		if cmd := a.handleSessionCompacted(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case DoctorMsg:
+		a.handleDoctor(msg)
+
+	case CacheClearedMsg:
+		if cmd := a.handleCacheCleared(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case RemediationMsg:
+		if cmd := a.handleRemediation(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case SessionActionMsg:
+		if cmd := a.handleSessionAction(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ChannelSendMsg:
+		if cmd := a.handleChannelSend(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case ChannelActionMsg:
+		if cmd := a.handleChannelAction(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case pairingPollMsg:
+		if cmd := a.pollPairing(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case AgentBootstrapMsg:
+		if cmd := a.handleAgentBootstrap(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case AgentCreatedMsg:
+		if cmd := a.handleAgentCreated(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case agentBootstrapPollMsg:
+		if cmd := a.pollAgentBootstrap(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case WebhookTestMsg:
+		if cmd := a.handleWebhookTest(msg); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
 
-	case gateway.DisconnectedMsg:
-		a.connectionState.Connected = false
-		a.connectionState.LastError = msg.Error
+	case ProcessesMsg:
+		a.handleProcesses(msg)
 
-	case gateway.LogMsg:
-		a.logs = append(a.logs, msg.Event)
-		if len(a.logs) > a.config.UI.LogTailLines {
-			a.logs = a.logs[1:]
-		}
-		// Continue listening for more logs in mock mode
-		if a.mockMode && a.mockClient != nil {
-			cmds = append(cmds, a.waitForMockLog())
+	case ProcessSignalMsg:
+		if cmd := a.handleProcessSignal(msg); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
 
-	case gateway.HealthMsg:
-		a.healthSnapshot = &msg.Snapshot
+	case UsageMsg:
+		a.handleUsage(msg)
 
-	case CLIStatusMsg:
-		if msg.Error != nil {
-			a.connectionState.Connected = false
-			a.connectionState.LastError = msg.Error.Error()
-		} else {
-			a.openclawStatus = msg.Status
-			// Update connection state from CLI status
-			if msg.Status.Gateway != nil {
-				a.connectionState.Connected = msg.Status.Gateway.Reachable
-				if msg.Status.Gateway.Self.Version != "" {
-					a.connectionState.GatewayVersion = msg.Status.Gateway.Self.Version
-				}
-				if msg.Status.Gateway.Error != nil {
-					a.connectionState.LastError = *msg.Status.Gateway.Error
-				} else {
-					a.connectionState.LastError = ""
-				}
-			}
+	case GatewayConfigMsg:
+		a.handleGatewayConfig(msg)
+
+	case HeartbeatSetMsg:
+		if cmd := a.handleHeartbeatSet(msg); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
 
-	case CLIHealthMsg:
+	case GatewayConfigSetMsg:
+		if cmd := a.handleGatewayConfigSet(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case WorkspaceDirMsg:
+		a.handleWorkspaceDir(msg)
+
+	case WorkspaceFileMsg:
+		a.handleWorkspaceFile(msg)
+
+	case CLIMemoryFilesMsg:
+		a.handleMemoryFiles(msg)
+
+	case CLIChannelsMsg:
 		if msg.Error == nil {
-			a.healthCheckResult = msg.Result
+			a.channelsList = msg.Channels
+		}
+		if msg.Status != nil {
+			a.channelsStatus = msg.Status
 		}
+		a.checkPairing()
 
 	case CLILogMsg:
-		a.logs = append(a.logs, msg.Event)
-		if len(a.logs) > a.config.UI.LogTailLines {
-			a.logs = a.logs[1:]
+		if cmd := a.handleCLILog(msg); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
-		// Continue listening for more log events
-		if a.logFollowing {
-			cmds = append(cmds, a.waitForCLILog())
+
+	case LogBackfillMsg:
+		if cmd := a.handleLogBackfill(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case LogFollowEndedMsg:
+		a.handleLogFollowEnded(msg)
+
+	case GatewayEventMsg:
+		if cmd := a.handleGatewayEvent(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case EventStreamClosedMsg:
+		a.handleEventStreamClosed(msg)
+
+	case InstanceSettledMsg:
+		if msg.Seq == a.switchSeq && a.switchPending {
+			cmds = append(cmds, a.loadInstance())
 		}
 
 	case RefreshTickMsg:
-		// Refresh status periodically
-		if !a.mockMode && a.getCurrentAdapter() != nil {
+		// Refresh status at the active tab's cadence
+		if a.getCurrentAdapter() != nil && a.refreshDue() {
+			a.lastRefresh = time.Now()
+			// With ui.batch_refresh, health rides along in the status poll's
+			// round trip
+			if prefetch := a.prefetchRefresh(); prefetch != nil {
+				cmds = append(cmds, prefetch, a.fetchCLIHealth())
+			}
 			cmds = append(cmds, a.fetchCLIStatus())
+			if a.cliAdapter() != nil {
+				cmds = append(cmds, a.cliTabRefreshCmds()...)
+			}
 		}
 		cmds = append(cmds, a.scheduleRefresh())
 
+	case BackgroundPollMsg:
+		cmds = append(cmds, a.handleBackgroundPoll())
+
+	case BackgroundStatusMsg:
+		a.backgroundPoll.inFlight = false
+
+	}
+
+	if a.activeTab != prevTab {
+		cmds = append(cmds, a.tabDataCmds()...)
 	}
 
 	return a, tea.Batch(cmds...)
@@ -463,6 +1505,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model
 func (a *App) View() string {
+	defer a.recoverPanic()
+	defer a.perf.timeView(time.Now())
+
 	if a.width == 0 || a.height == 0 {
 		return "Initializing..."
 	}
@@ -471,16 +1516,41 @@ func (a *App) View() string {
 	if a.mode == ModeHelp {
 		return a.renderHelp()
 	}
+	if a.mode == ModeDiscovery {
+		return a.renderDiscovery()
+	}
+	if a.mode == ModeCommandLog {
+		return a.renderCommandLog()
+	}
+	if a.mode == ModeActions {
+		return a.renderActions()
+	}
+	if a.mode == ModePairing {
+		return a.renderPairingModal()
+	}
+	if a.mode == ModeDoctor {
+		return a.renderDoctor()
+	}
+	if a.mode == ModeUpdateChannel {
+		return a.renderUpdateChannelPicker()
+	}
+	if a.mode == ModeAgentWizard {
+		return a.renderAgentWizard()
+	}
+	if a.mode == ModeWriteConfirm {
+		return a.renderWriteConfirm()
+	}
+	if a.mode == ModeActionOutput {
+		return a.renderActionOutput()
+	}
 
 	// Main layout
 	return a.renderMainLayout()
 }
 
 func (a *App) renderMainLayout() string {
-	// Calculate dimensions
-	leftWidth := 25
-	rightWidth := a.width - leftWidth - 3 // Account for borders
-	contentHeight := a.height - 4          // Account for bottom bar and borders
+	leftWidth, rightWidth := a.layout.leftWidth, a.layout.rightWidth
+	contentHeight, perfOverlay := a.layoutWithPerfOverlay(a.layout.contentHeight)
 
 	// Render left pane (instances)
 	leftPane := a.renderInstancesPane(leftWidth, contentHeight)
@@ -491,14 +1561,42 @@ func (a *App) renderMainLayout() string {
 	// Combine panes
 	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, leftPane, rightPane)
 
-	// Bottom bar
+	// Bottom bar, with the performance overlay above it if enabled
 	bottomBar := a.renderBottomBar()
+	if perfOverlay != "" {
+		bottomBar = lipgloss.JoinVertical(lipgloss.Left, perfOverlay, bottomBar)
+	}
 
 	// Search bar if active
 	if a.mode == ModeSearch {
 		searchBar := a.renderSearchBar()
 		return lipgloss.JoinVertical(lipgloss.Left, mainContent, searchBar, bottomBar)
 	}
+	if a.mode == ModeMemorySearch {
+		searchBar := styles.InputPrompt.Render("Memory search: ") + a.memorySearchInput.View()
+		return lipgloss.JoinVertical(lipgloss.Left, mainContent, searchBar, bottomBar)
+	}
+	if a.mode == ModeConfigSearch {
+		searchBar := styles.InputPrompt.Render("Config filter: ") + a.gatewayConfig.filter.View()
+		return lipgloss.JoinVertical(lipgloss.Left, mainContent, searchBar, bottomBar)
+	}
+	if a.mode == ModeHeartbeatEdit {
+		agentID, _ := a.selectedHeartbeat()
+		editBar := styles.InputPrompt.Render("Heartbeat interval for "+agentID+": ") + a.heartbeatInput.View()
+		return lipgloss.JoinVertical(lipgloss.Left, mainContent, editBar, bottomBar)
+	}
+	if a.mode == ModePassphrase {
+		promptBar := styles.InputPrompt.Render(a.passphraseLabel()) + a.passphrase.input.View()
+		return lipgloss.JoinVertical(lipgloss.Left, mainContent, promptBar, bottomBar)
+	}
+	if a.mode == ModeConfigEdit {
+		editBar := styles.InputPrompt.Render("Set "+a.gatewayConfig.editKey+": ") + a.gatewayConfig.edit.View()
+		return lipgloss.JoinVertical(lipgloss.Left, mainContent, editBar, bottomBar)
+	}
+	if a.mode == ModeChannelSend {
+		sendBar := styles.InputPrompt.Render(a.channelSendPrompt()) + a.channelSend.input.View()
+		return lipgloss.JoinVertical(lipgloss.Left, mainContent, sendBar, bottomBar)
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, mainContent, bottomBar)
 }
@@ -513,14 +1611,18 @@ func (a *App) renderInstancesPane(width, height int) string {
 	style = style.Width(width).Height(height)
 
 	title := styles.TitleStyle.Render("Instances")
+	if a.config.Locked {
+		// Config is managed externally; instance management is view-only
+		title += " " + styles.Muted.Render("[locked]")
+	}
 
 	var lines []string
 
 	// Show adapters (which match configured instances or local)
-	if len(a.cliAdapters) == 0 {
+	if len(a.adapters) == 0 {
 		lines = append(lines, styles.Muted.Render("Detecting gateway..."))
 	} else {
-		for i, adapter := range a.cliAdapters {
+		for i, adapter := range a.adapters {
 			// Get status badge for this adapter
 			status := a.getAdapterStatusBadge(adapter)
 
@@ -532,11 +1634,21 @@ func (a *App) renderInstancesPane(width, height int) string {
 
 			// Add mode indicator
 			modeIndicator := ""
-			if adapter.IsRemote() {
-				modeIndicator = styles.Muted.Render(" [SSH]")
+			if cli, ok := adapter.(*gateway.CLIAdapter); ok {
+				switch cli.Mode() {
+				case models.ConnectionModeSSH:
+					modeIndicator = styles.Muted.Render(" [SSH]")
+				case models.ConnectionModeK8s:
+					modeIndicator = styles.Muted.Render(" [K8S]")
+				}
+			} else if _, ok := adapter.(*gateway.WSClient); ok {
+				modeIndicator = styles.Muted.Render(" [WS]")
 			}
 
 			line := status + " " + name + modeIndicator
+			if stage, ok := a.connectStage(adapter); ok {
+				line += styles.Muted.Render(" " + stage.String() + "…")
+			}
 
 			if i == a.selectedInstance {
 				lines = append(lines, styles.SelectedItem.Render(line))
@@ -551,11 +1663,27 @@ func (a *App) renderInstancesPane(width, height int) string {
 }
 
 // getAdapterStatusBadge returns a status badge for a specific adapter
-func (a *App) getAdapterStatusBadge(adapter *gateway.CLIAdapter) string {
+func (a *App) getAdapterStatusBadge(adapter gateway.Adapter) string {
 	if adapter == nil {
 		return styles.StatusDegraded.Render("[...]")
 	}
 
+	// An instance that kept failing, now only probed now and then
+	cli, _ := adapter.(*gateway.CLIAdapter)
+	if cli != nil && cli.Unreachable() {
+		return styles.StatusDown.Render("[UNREACHABLE]")
+	}
+
+	// A fetch failing to connect, about to be tried again
+	if cli != nil && cli.Retrying() != nil {
+		return styles.StatusDegraded.Render("[RETRY]")
+	}
+
+	// A fetch that timed out: the last data may still be shown, but stale
+	if cli != nil && errors.Is(cli.GetLastError(), gateway.ErrTimeout) {
+		return styles.StatusDegraded.Render("[SLOW]")
+	}
+
 	// For the current adapter, use cached status
 	if adapter == a.getCurrentAdapter() {
 		if a.openclawStatus != nil && a.openclawStatus.Gateway != nil {
@@ -569,8 +1697,15 @@ func (a *App) getAdapterStatusBadge(adapter *gateway.CLIAdapter) string {
 		}
 	}
 
-	// For other adapters, check their cached status
-	cached := adapter.GetCachedStatus()
+	// For other CLI adapters, check their cached status, kept current by
+	// the background poller. A failed poll outdates it.
+	if cli == nil {
+		return styles.StatusDegraded.Render("[...]")
+	}
+	if cli.GetLastError() != nil {
+		return styles.StatusDown.Render("[ERR]")
+	}
+	cached := cli.GetCachedStatus()
 	if cached != nil && cached.Gateway != nil {
 		if cached.Gateway.Reachable {
 			return styles.StatusOK.Render("[OK]")
@@ -578,10 +1713,6 @@ func (a *App) getAdapterStatusBadge(adapter *gateway.CLIAdapter) string {
 		return styles.StatusDown.Render("[DOWN]")
 	}
 
-	if adapter.GetLastError() != nil {
-		return styles.StatusDown.Render("[ERR]")
-	}
-
 	return styles.StatusDegraded.Render("[...]")
 }
 
@@ -597,55 +1728,132 @@ func (a *App) renderDetailsPane(width, height int) string {
 	// Render tabs
 	tabs := a.renderTabs()
 
-	// Render tab content
+	// Render tab content, below a warning if a fetch is being retried or the
+	// status is timing out
 	contentHeight := height - 3 // Account for tabs
-	var content string
+	banner := a.renderRetryBanner(width - 2)
+	if banner == "" {
+		banner = a.renderDegradedBanner(width - 2)
+	}
+	if banner == "" {
+		banner = a.renderCachedBanner(width - 2)
+	}
+	if banner == "" {
+		banner = a.renderPartialBanner(width - 2)
+	}
+	if banner != "" {
+		content := a.cachedTabContent(width-2, contentHeight-1)
+		return style.Render(lipgloss.JoinVertical(lipgloss.Left, tabs, banner, content))
+	}
+	content := a.cachedTabContent(width-2, contentHeight)
+
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, tabs, content))
+}
+
+// renderTabContent renders the active tab's content
+func (a *App) renderTabContent(width, height int) string {
+	if placeholder := a.renderLoadingPlaceholder(width); placeholder != "" {
+		return placeholder
+	}
 	switch a.activeTab {
 	case TabOverview:
-		content = a.renderOverviewTab(width-2, contentHeight)
+		return a.renderOverviewTab(width, height)
 	case TabLogs:
-		content = a.renderLogsTab(width-2, contentHeight)
+		return a.renderLogsTab(width, height)
 	case TabHealth:
-		content = a.renderHealthTab(width-2, contentHeight)
+		return a.renderHealthTab(width, height)
 	case TabChannels:
-		content = a.renderChannelsTab(width-2, contentHeight)
+		return a.renderChannelsTab(width, height)
 	case TabAgents:
-		content = a.renderAgentsTab(width-2, contentHeight)
+		if a.workspace.open {
+			return a.renderWorkspaceBrowser(width, height)
+		}
+		return a.renderAgentsTab(width, height)
 	case TabSessions:
-		content = a.renderSessionsTab(width-2, contentHeight)
+		return a.renderSessionsTab(width, height)
 	case TabEvents:
-		content = a.renderEventsTab(width-2, contentHeight)
+		return a.renderEventsTab(width, height)
 	case TabMemory:
-		content = a.renderMemoryTab(width-2, contentHeight)
+		if a.memoryBrowser.open {
+			return a.renderMemoryBrowser(width, height)
+		}
+		return a.renderMemoryTab(width, height)
 	case TabSecurity:
-		content = a.renderSecurityTab(width-2, contentHeight)
+		return a.renderSecurityTab(width, height)
 	case TabSystem:
-		content = a.renderSystemTab(width-2, contentHeight)
+		return a.renderSystemTab(width, height)
+	case TabUsage:
+		return a.renderUsageTab(width, height)
+	case TabConfig:
+		return a.renderGatewayConfigTab(width, height)
+	case TabHooks:
+		return a.renderWebhooksTab(width, height)
+	case TabQueues:
+		return a.renderQueuesTab(width, height)
 	default:
-		content = styles.Muted.Render("Tab not implemented")
+		return styles.Muted.Render("Tab not implemented")
 	}
-
-	return style.Render(lipgloss.JoinVertical(lipgloss.Left, tabs, content))
 }
 
 func (a *App) renderTabs() string {
 	var tabs []string
 	allTabs := []Tab{
 		TabOverview, TabLogs, TabHealth, TabChannels, TabAgents,
-		TabSessions, TabEvents, TabMemory, TabSecurity, TabSystem,
+		TabSessions, TabEvents, TabMemory, TabSecurity, TabSystem, TabUsage, TabConfig, TabHooks, TabQueues,
 	}
 
 	for _, t := range allTabs {
+		label := t.String() + a.tabBadge(t)
 		if t == a.activeTab {
-			tabs = append(tabs, styles.ActiveTab.Render(t.String()))
+			tabs = append(tabs, styles.ActiveTab.Render(label))
 		} else {
-			tabs = append(tabs, styles.InactiveTab.Render(t.String()))
+			tabs = append(tabs, styles.InactiveTab.Render(label))
 		}
 	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
 }
 
+// tabBadge returns a small attention counter for a tab label, or "" if the
+// tab needs no attention
+func (a *App) tabBadge(t Tab) string {
+	switch t {
+	case TabOverview:
+		if a.openclawStatus != nil && len(a.openclawStatus.ParseErrors) > 0 {
+			return " " + styles.TabBadgeWarn.Render("partial")
+		}
+	case TabLogs:
+		if errors := a.logs.Errors(); errors > 0 {
+			return " " + styles.TabBadgeError.Render(fmt.Sprintf("%d", errors))
+		}
+	case TabSecurity:
+		if a.openclawStatus != nil && a.openclawStatus.SecurityAudit != nil {
+			if critical := a.openclawStatus.SecurityAudit.Summary.Critical; critical > 0 {
+				return " " + styles.TabBadgeError.Render(fmt.Sprintf("%d", critical))
+			}
+		}
+	case TabAgents:
+		if a.openclawStatus != nil && a.openclawStatus.Agents != nil {
+			if pending := a.openclawStatus.Agents.BootstrapPendingCount; pending > 0 {
+				return " " + styles.TabBadgeWarn.Render(fmt.Sprintf("%d", pending))
+			}
+		}
+	case TabSystem:
+		if a.availableUpdate() != "" {
+			return " " + styles.TabBadgeWarn.Render("●")
+		}
+	case TabHooks:
+		if failing := a.failingWebhooks(); failing > 0 {
+			return " " + styles.TabBadgeError.Render(fmt.Sprintf("%d", failing))
+		}
+	case TabQueues:
+		if stale := a.staleQueues(); stale > 0 {
+			return " " + styles.TabBadgeWarn.Render(fmt.Sprintf("%d", stale))
+		}
+	}
+	return ""
+}
+
 func (a *App) renderOverviewTab(width, height int) string {
 	var lines []string
 
@@ -657,7 +1865,7 @@ func (a *App) renderOverviewTab(width, height int) string {
 	// Fallback to basic connection info
 	lines = append(lines, styles.HelpSection.Render("Connection"))
 
-	if len(a.config.Instances) == 0 && !a.mockMode {
+	if len(a.config.Instances) == 0 {
 		lines = append(lines, styles.Muted.Render("No instance configured"))
 		lines = append(lines, "")
 		lines = append(lines, styles.Muted.Render("Checking openclaw CLI..."))
@@ -665,7 +1873,7 @@ func (a *App) renderOverviewTab(width, height int) string {
 	}
 
 	if len(a.config.Instances) > 0 {
-		inst := a.config.Instances[0]
+		inst := a.config.ResolveInstance(a.config.Instances[0])
 		lines = append(lines, "  Name: "+inst.Name)
 		lines = append(lines, "  Mode: "+string(inst.Mode))
 		if inst.SSH != nil {
@@ -696,135 +1904,10 @@ func (a *App) renderOverviewTab(width, height int) string {
 
 func (a *App) renderRealOverview(width, height int) string {
 	var lines []string
-	status := a.openclawStatus
-
-	// Quick status summary at top
-	lines = append(lines, styles.HelpSection.Render("Quick Status"))
-	lines = append(lines, "")
-
-	// Gateway status with latency
-	if status.Gateway != nil {
-		gw := status.Gateway
-		if gw.Reachable {
-			lines = append(lines, fmt.Sprintf("  Gateway:    %s (%dms latency)",
-				styles.BadgeOK.Render("ONLINE"), gw.ConnectLatencyMs))
-		} else {
-			lines = append(lines, "  Gateway:    "+styles.BadgeError.Render("OFFLINE"))
-		}
-	}
-
-	// Service status compact
-	if status.GatewayService != nil && status.GatewayService.Installed {
-		if contains(status.GatewayService.RuntimeShort, "running") {
-			lines = append(lines, "  Service:    "+styles.BadgeOK.Render("RUNNING"))
-		} else {
-			lines = append(lines, "  Service:    "+styles.BadgeError.Render("STOPPED"))
-		}
-	}
-
-	// Sessions count
-	if status.Sessions != nil {
-		lines = append(lines, fmt.Sprintf("  Sessions:   %s active",
-			styles.LabelValueHighlight.Render(fmt.Sprintf("%d", status.Sessions.Count))))
-	}
-
-	// Agents count
-	if status.Agents != nil {
-		lines = append(lines, fmt.Sprintf("  Agents:     %d configured (default: %s)",
-			len(status.Agents.Agents), status.Agents.DefaultID))
-	}
-
-	// Security summary with colored badges
-	if status.SecurityAudit != nil {
-		summary := status.SecurityAudit.Summary
-		secLine := "  Security:   "
-		if summary.Critical > 0 {
-			secLine += styles.SeverityCritical.Render(fmt.Sprintf(" %d ", summary.Critical))
-		}
-		if summary.Warn > 0 {
-			secLine += styles.SeverityWarn.Render(fmt.Sprintf(" %d ", summary.Warn))
-		}
-		if summary.Critical == 0 && summary.Warn == 0 {
-			secLine += styles.BadgeOK.Render("OK")
-		}
-		lines = append(lines, secLine)
-	}
-	lines = append(lines, "")
-
-	// Channels summary
-	if len(status.ChannelSummary) > 0 {
-		lines = append(lines, styles.HelpSection.Render("Channels"))
-		for _, ch := range status.ChannelSummary {
-			if ch != "" && ch[0] != ' ' {
-				// Colorize based on status
-				if contains(ch, "linked") {
-					lines = append(lines, "  "+styles.StatusOK.Render("●")+" "+ch)
-				} else if contains(ch, "configured") {
-					lines = append(lines, "  "+styles.StatusOK.Render("●")+" "+ch)
-				} else {
-					lines = append(lines, "  "+styles.Muted.Render("○")+" "+ch)
-				}
-			}
-		}
-		lines = append(lines, "")
-	}
-
-	// Model & token info
-	if status.Sessions != nil {
-		lines = append(lines, styles.HelpSection.Render("Model Configuration"))
-		lines = append(lines, fmt.Sprintf("  Model:   %s", styles.LabelValueHighlight.Render(status.Sessions.Defaults.Model)))
-		lines = append(lines, fmt.Sprintf("  Context: %s tokens", formatNumber(status.Sessions.Defaults.ContextTokens)))
-		lines = append(lines, "")
-	}
-
-	// Memory summary
-	if status.Memory != nil {
-		lines = append(lines, styles.HelpSection.Render("Memory (RAG)"))
-		features := []string{}
-		if status.Memory.Vector.Enabled && status.Memory.Vector.Available {
-			features = append(features, "vector")
-		}
-		if status.Memory.FTS.Enabled && status.Memory.FTS.Available {
-			features = append(features, "FTS")
-		}
-		if status.Memory.Cache.Enabled {
-			features = append(features, "cache")
-		}
-		lines = append(lines, fmt.Sprintf("  %d files, %d chunks [%s]",
-			status.Memory.Files, status.Memory.Chunks, strings.Join(features, ", ")))
-		if status.Memory.Dirty {
-			lines = append(lines, "  "+styles.LogWarn.Render("Index needs refresh"))
-		}
-		lines = append(lines, "")
-	}
-
-	// Recent activity from sessions
-	if status.Sessions != nil && len(status.Sessions.Recent) > 0 {
-		lines = append(lines, styles.HelpSection.Render("Recent Activity"))
-		maxRecent := 5
-		if len(status.Sessions.Recent) < maxRecent {
-			maxRecent = len(status.Sessions.Recent)
-		}
-		for _, sess := range status.Sessions.Recent[:maxRecent] {
-			age := formatAge(sess.Age)
-			pct := sess.PercentUsed
-
-			// Mini progress indicator
-			var pctStyle lipgloss.Style
-			if pct >= 80 {
-				pctStyle = styles.LogError
-			} else if pct >= 50 {
-				pctStyle = styles.LogWarn
-			} else {
-				pctStyle = styles.Muted
-			}
 
-			lines = append(lines, fmt.Sprintf("  %s %s (%s ago) %s",
-				styles.Muted.Render("●"),
-				truncate(sess.Key, 40),
-				age,
-				pctStyle.Render(fmt.Sprintf("%d%%", pct))))
-		}
+	// Widgets in configured order (ui.overview_widgets)
+	for _, widget := range a.config.UI.Widgets() {
+		lines = append(lines, a.renderOverviewWidget(widget, width)...)
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
@@ -849,24 +1932,46 @@ func (a *App) renderSessionsTab(width, height int) string {
 	lines = append(lines, fmt.Sprintf("  Context Window: %s tokens", formatNumber(sessions.Defaults.ContextTokens)))
 	lines = append(lines, "")
 
-	// Recent sessions table
-	lines = append(lines, styles.HelpSection.Render("Recent Sessions"))
-	lines = append(lines, "")
+	filtered := a.filteredSessions()
+
+	// Clamp the selection now that we know how many sessions match, and
+	// show its page
+	pageSize := sessionsPageSize(height)
+	a.sessionPageSize = pageSize
+	pageCount := (len(filtered) + pageSize - 1) / pageSize
+	if pageCount < 1 {
+		pageCount = 1
+	}
+	a.sessionCursor = min(max(a.sessionCursor, 0), max(len(filtered)-1, 0))
+	page := a.sessionCursor / pageSize
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+
+	// Recent sessions header with active filters
+	title := styles.HelpSection.Render("Recent Sessions") + "  " + a.renderSessionFilters()
+	if a.writesAllowed() {
+		title += "  " + styles.Muted.Render("c: compact  C: reset  K: kill")
+	}
+	lines = append(lines, title)
+	if a.sessionAgentFilter != "" || a.sessionKindFilter != "" || a.sessionMinPercent > 0 {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  %d sessions match", len(filtered))))
+	} else {
+		lines = append(lines, "")
+	}
 
 	// Table header
 	header := fmt.Sprintf("  %-12s %-8s %-10s %8s %8s %6s", "Agent", "Kind", "Age", "Tokens", "Remain", "Used")
 	lines = append(lines, styles.TableHeader.Render(header))
 
-	// Show recent sessions with token usage bars
-	maxSessions := height - 10
-	if maxSessions > len(sessions.Recent) {
-		maxSessions = len(sessions.Recent)
-	}
-	if maxSessions < 0 {
-		maxSessions = 0
+	if len(filtered) == 0 {
+		lines = append(lines, styles.Muted.Render("  No sessions match the current filters"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
-	for i, sess := range sessions.Recent[:maxSessions] {
+	for i, sess := range filtered[start:end] {
 		age := formatAge(sess.Age)
 		tokens := formatNumber(sess.TotalTokens)
 		remain := formatNumber(sess.RemainingTokens)
@@ -880,18 +1985,24 @@ func (a *App) renderSessionsTab(width, height int) string {
 			pctStyle = styles.LogWarn
 		}
 
-		row := fmt.Sprintf("  %-12s %-8s %-10s %8s %8s %s",
-			truncate(sess.AgentID, 12),
+		row := fmt.Sprintf("  %s %-8s %-10s %8s %8s %s",
+			padRight(sess.AgentID, 12),
 			sess.Kind,
 			age,
 			tokens,
 			remain,
 			pctStyle.Render(pct),
 		)
+		if a.compacting != "" && sessionKey(sess) == a.compacting {
+			row += "  " + styles.Muted.Render("compacting...")
+		}
 
-		if i%2 == 0 {
+		switch {
+		case start+i == a.sessionCursor && a.focusedPane == PaneDetails:
+			lines = append(lines, styles.TableRowSelected.Render(row))
+		case i%2 == 0:
 			lines = append(lines, row)
-		} else {
+		default:
 			lines = append(lines, styles.TableRowAlt.Render(row))
 		}
 
@@ -900,14 +2011,125 @@ func (a *App) renderSessionsTab(width, height int) string {
 		lines = append(lines, "    "+bar)
 	}
 
-	if len(sessions.Recent) > maxSessions {
+	if pageCount > 1 {
 		lines = append(lines, "")
-		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  ... and %d more sessions", len(sessions.Recent)-maxSessions)))
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  Page %d/%d  (%d-%d of %d)  pgup/pgdn: page",
+			page+1, pageCount, start+1, end, len(filtered))))
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
+// sessionsPageSize returns how many sessions fit on one page of the Sessions tab
+func sessionsPageSize(height int) int {
+	// Summary and table headers take ~11 lines, each session takes two (row + bar)
+	size := (height - 13) / 2
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// filteredSessions returns recent sessions matching the Sessions tab filters
+func (a *App) filteredSessions() []models.Session {
+	if a.openclawStatus == nil || a.openclawStatus.Sessions == nil {
+		return nil
+	}
+	// The per-agent list is more complete than the global recent list
+	source := a.openclawStatus.Sessions.Recent
+	if a.sessionAgentFilter != "" {
+		for _, group := range a.openclawStatus.Sessions.ByAgent {
+			if group.AgentID == a.sessionAgentFilter && len(group.Recent) > 0 {
+				source = group.Recent
+				break
+			}
+		}
+	}
+
+	var filtered []models.Session
+	for _, sess := range source {
+		if a.sessionAgentFilter != "" && sess.AgentID != a.sessionAgentFilter {
+			continue
+		}
+		if a.sessionKindFilter != "" && sess.Kind != a.sessionKindFilter {
+			continue
+		}
+		if sess.PercentUsed < a.sessionMinPercent {
+			continue
+		}
+		filtered = append(filtered, sess)
+	}
+	return filtered
+}
+
+// sessionAgentIDs returns the distinct agent IDs among recent sessions, in order of appearance
+func (a *App) sessionAgentIDs() []string {
+	if a.openclawStatus == nil || a.openclawStatus.Sessions == nil {
+		return nil
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, sess := range a.openclawStatus.Sessions.Recent {
+		if !seen[sess.AgentID] {
+			seen[sess.AgentID] = true
+			ids = append(ids, sess.AgentID)
+		}
+	}
+	for _, group := range a.openclawStatus.Sessions.ByAgent {
+		if !seen[group.AgentID] {
+			seen[group.AgentID] = true
+			ids = append(ids, group.AgentID)
+		}
+	}
+	return ids
+}
+
+// renderSessionFilters renders the active Sessions tab filters with their keys
+func (a *App) renderSessionFilters() string {
+	agent := "all"
+	if a.sessionAgentFilter != "" {
+		agent = a.sessionAgentFilter
+	}
+	kind := "all"
+	if a.sessionKindFilter != "" {
+		kind = a.sessionKindFilter
+	}
+	usage := "any"
+	if a.sessionMinPercent > 0 {
+		usage = fmt.Sprintf(">=%d%%", a.sessionMinPercent)
+	}
+
+	filter := func(k, label, value string, active bool) string {
+		v := styles.Muted.Render(value)
+		if active {
+			v = styles.LabelValueHighlight.Render(value)
+		}
+		return styles.HintKey.Render(k) + styles.Muted.Render(":"+label+"=") + v
+	}
+
+	return strings.Join([]string{
+		filter("a", "agent", agent, a.sessionAgentFilter != ""),
+		filter("t", "kind", kind, a.sessionKindFilter != ""),
+		filter("u", "used", usage, a.sessionMinPercent > 0),
+	}, "  ")
+}
+
+// nextOption cycles through "" (no filter) followed by each option
+func nextOption(current string, options []string) string {
+	if current == "" {
+		if len(options) > 0 {
+			return options[0]
+		}
+		return ""
+	}
+	for i, opt := range options {
+		if opt == current && i+1 < len(options) {
+			return options[i+1]
+		}
+	}
+	return ""
+}
+
 // ============================================================================
 // Agents Tab
 // ============================================================================
@@ -931,12 +2153,25 @@ func (a *App) renderAgentsTab(width, height int) string {
 	lines = append(lines, "")
 
 	// Agent details
-	for _, agent := range agents.Agents {
-		lines = append(lines, styles.HelpSection.Render(fmt.Sprintf("Agent: %s", agent.ID)))
+	for i, agent := range agents.Agents {
+		title := styles.HelpSection.Render(fmt.Sprintf("Agent: %s", agent.ID))
+		if i == a.agentCursor && a.focusedPane == PaneDetails {
+			hint := "enter: browse workspace  s: sessions"
+			if agent.BootstrapPending && !a.bootstrap.bootstrapping(agent.ID) && a.writesAllowed() {
+				hint += "  B: bootstrap"
+			}
+			title = styles.HelpSection.Render("> ") + styles.TableRowSelected.Render(fmt.Sprintf("Agent: %s", agent.ID)) +
+				"  " + styles.Muted.Render(hint)
+		}
+		lines = append(lines, title)
 
 		// Status badge
 		if agent.BootstrapPending {
-			lines = append(lines, "  Status:     "+styles.BadgeWarning.Render("BOOTSTRAP PENDING"))
+			status := "  Status:     " + styles.BadgeWarning.Render("BOOTSTRAP PENDING")
+			if a.bootstrap.bootstrapping(agent.ID) {
+				status += "  " + styles.Muted.Render("bootstrapping...")
+			}
+			lines = append(lines, status)
 		} else {
 			lines = append(lines, "  Status:     "+styles.BadgeOK.Render("READY"))
 		}
@@ -948,20 +2183,64 @@ func (a *App) renderAgentsTab(width, height int) string {
 	}
 
 	// Heartbeat info
-	if a.openclawStatus.Heartbeat != nil {
-		hb := a.openclawStatus.Heartbeat
-		lines = append(lines, styles.HelpSection.Render("Heartbeat Configuration"))
-		lines = append(lines, fmt.Sprintf("  Default Agent: %s", hb.DefaultAgentID))
-		for _, agent := range hb.Agents {
-			status := styles.Muted.Render("disabled")
-			if agent.Enabled {
-				status = styles.StatusOK.Render("enabled")
-			}
-			lines = append(lines, fmt.Sprintf("  - %s: %s (every %s)", agent.AgentID, status, agent.Every))
+	lines = append(lines, a.renderHeartbeatSection()...)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// moveDetailsSelection moves the selection within the active tab's list
+func (a *App) moveDetailsSelection(delta int) {
+	switch a.activeTab {
+	case TabSecurity:
+		a.moveSecurityCursor(delta)
+	case TabUsage:
+		a.usage.scroll += delta
+	case TabConfig:
+		a.moveConfigCursor(delta)
+	case TabSystem:
+		a.moveSystemCursor(delta)
+	case TabHooks:
+		a.moveWebhookCursor(delta)
+	case TabChannels:
+		a.moveChannelCursor(delta)
+	case TabSessions:
+		a.sessionCursor = max(a.sessionCursor+delta, 0)
+	case TabMemory:
+		if a.memoryBrowser.open {
+			a.moveMemoryCursor(delta)
+		}
+	case TabAgents:
+		if a.workspace.open {
+			a.workspaceMove(delta)
+			return
+		}
+		if a.openclawStatus == nil || a.openclawStatus.Agents == nil {
+			return
+		}
+		a.agentCursor += delta
+		if a.agentCursor >= len(a.openclawStatus.Agents.Agents) {
+			a.agentCursor = len(a.openclawStatus.Agents.Agents) - 1
+		}
+		if a.agentCursor < 0 {
+			a.agentCursor = 0
 		}
 	}
+}
 
-	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+// showAgentSessions switches to the Sessions tab filtered to the selected agent
+func (a *App) showAgentSessions() {
+	if a.openclawStatus == nil || a.openclawStatus.Agents == nil {
+		return
+	}
+	agents := a.openclawStatus.Agents.Agents
+	if a.agentCursor < 0 || a.agentCursor >= len(agents) {
+		return
+	}
+	a.sessionAgentFilter = agents[a.agentCursor].ID
+	a.sessionKindFilter = ""
+	a.sessionMinPercent = 0
+	a.sessionCursor = 0
+	a.activeTab = TabSessions
 }
 
 // ============================================================================
@@ -969,7 +2248,7 @@ func (a *App) renderAgentsTab(width, height int) string {
 // ============================================================================
 
 func (a *App) renderChannelsTab(width, height int) string {
-	if a.openclawStatus == nil {
+	if a.openclawStatus == nil && a.channelsList == nil && a.channelsStatus == nil {
 		return styles.Muted.Render("No channel data available")
 	}
 
@@ -979,7 +2258,7 @@ func (a *App) renderChannelsTab(width, height int) string {
 	lines = append(lines, "")
 
 	// Link channel (WhatsApp)
-	if a.openclawStatus.LinkChannel != nil {
+	if a.openclawStatus != nil && a.openclawStatus.LinkChannel != nil {
 		lc := a.openclawStatus.LinkChannel
 		lines = append(lines, styles.CardTitle.Render(fmt.Sprintf("  %s", lc.Label)))
 
@@ -987,14 +2266,27 @@ func (a *App) renderChannelsTab(width, height int) string {
 			lines = append(lines, "    Status:   "+styles.BadgeOK.Render("LINKED"))
 			authAge := formatAge(int64(lc.AuthAgeMs))
 			lines = append(lines, fmt.Sprintf("    Auth Age: %s", authAge))
+			lines = append(lines, a.renderAuthExpiry(int64(lc.AuthAgeMs), width)...)
 		} else {
-			lines = append(lines, "    Status:   "+styles.BadgeError.Render("NOT LINKED"))
+			status := "    Status:   " + styles.BadgeError.Render("NOT LINKED")
+			if a.writesAllowed() && len(a.channelRows()) == 0 {
+				status += "  " + styles.Muted.Render("l: link")
+			}
+			lines = append(lines, status)
 		}
+		lines = append(lines, a.renderLinkHistory()...)
 		lines = append(lines, "")
 	}
 
+	// Structured per-channel table when available
+	if a.channelsList != nil && len(a.channelsList.Channels) > 0 ||
+		a.channelsStatus != nil && len(a.channelsStatus.Channels) > 0 {
+		lines = append(lines, a.renderChannelsTable(width)...)
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
 	// Channel summary from the status
-	if len(a.openclawStatus.ChannelSummary) > 0 {
+	if a.openclawStatus != nil && len(a.openclawStatus.ChannelSummary) > 0 {
 		lines = append(lines, styles.HelpSection.Render("Channel Configuration"))
 		lines = append(lines, "")
 
@@ -1019,17 +2311,230 @@ func (a *App) renderChannelsTab(width, height int) string {
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
+// renderAuthExpiry renders auth age against the configured expiry threshold
+func (a *App) renderAuthExpiry(authAgeMs int64, width int) []string {
+	days := a.config.Channels.AuthExpiryDays
+	if days <= 0 {
+		return nil
+	}
+
+	var lines []string
+	expiryMs := (time.Duration(days) * 24 * time.Hour).Milliseconds()
+	remaining := expiryMs - authAgeMs
+	pct := int(authAgeMs * 100 / expiryMs)
+
+	switch {
+	case remaining <= 0:
+		lines = append(lines, fmt.Sprintf("    Re-auth:  %s (overdue by %s, threshold %dd)",
+			styles.BadgeError.Render("EXPIRED"), formatAge(-remaining), days))
+	case pct >= 80:
+		lines = append(lines, fmt.Sprintf("    Re-auth:  %s in %s (threshold %dd)",
+			styles.BadgeWarning.Render("SOON"), formatAge(remaining), days))
+	default:
+		lines = append(lines, fmt.Sprintf("    Re-auth:  expected in %s (threshold %dd)",
+			styles.LabelValueHighlight.Render(formatAge(remaining)), days))
+	}
+
+	if pct > 100 {
+		pct = 100
+	}
+	lines = append(lines, "    "+renderProgressBar(pct, width-8))
+	return lines
+}
+
+// renderLinkHistory renders recent link/unlink events from the history store
+func (a *App) renderLinkHistory() []string {
+	if len(a.linkEvents) == 0 {
+		return nil
+	}
+
+	lines := []string{"", "    " + styles.CardTitle.Render("Link History")}
+
+	maxEvents := 5
+	start := 0
+	if len(a.linkEvents) > maxEvents {
+		start = len(a.linkEvents) - maxEvents
+	}
+	// Newest first
+	for i := len(a.linkEvents) - 1; i >= start; i-- {
+		ev := a.linkEvents[i]
+		var kind string
+		switch ev.Kind {
+		case history.LinkUnlinked:
+			kind = styles.StatusDown.Render(ev.Kind)
+		case history.LinkLinked, history.LinkRelinked:
+			kind = styles.StatusOK.Render(ev.Kind)
+		default:
+			kind = styles.Muted.Render(ev.Kind)
+		}
+		lines = append(lines, fmt.Sprintf("    %s  %s",
+			styles.Muted.Render(ev.Time.Format("2006-01-02 15:04")), kind))
+	}
+	if start > 0 {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("    ... %d earlier events", start)))
+	}
+	return lines
+}
+
+// channelRow is one channel in the Channels table, combining its
+// configuration from `channels --json` with its live connection state from
+// `channels status --json`
+type channelRow struct {
+	id          string
+	label       string
+	linked      bool
+	status      string // Configuration status, e.g. "linked"
+	state       string // Connection state, e.g. "connected"; "" if unknown
+	authAgeMs   int64
+	in, out     int
+	hasCounts   bool
+	lastError   string
+	lastErrorAt int64
+}
+
+// channelRows merges the channel list and connection states by channel ID,
+// in list order with channels only known to the status appended
+func (a *App) channelRows() []channelRow {
+	var rows []channelRow
+	byID := make(map[string]int)
+	if a.channelsList != nil {
+		for _, ch := range a.channelsList.Channels {
+			label := ch.Label
+			if label == "" {
+				label = ch.ID
+			}
+			byID[ch.ID] = len(rows)
+			rows = append(rows, channelRow{
+				id:          ch.ID,
+				label:       label,
+				linked:      ch.Linked || strings.EqualFold(ch.Status, "linked"),
+				status:      ch.Status,
+				authAgeMs:   ch.AuthAgeMs,
+				in:          ch.MessagesIn,
+				out:         ch.MessagesOut,
+				hasCounts:   true,
+				lastError:   ch.LastError,
+				lastErrorAt: ch.LastErrorAt,
+			})
+		}
+	}
+	if a.channelsStatus == nil {
+		return rows
+	}
+	for _, st := range a.channelsStatus.Channels {
+		i, ok := byID[st.ID]
+		if !ok {
+			label := st.Label
+			if label == "" {
+				label = st.ID
+			}
+			i = len(rows)
+			rows = append(rows, channelRow{id: st.ID, label: label})
+		}
+		row := &rows[i]
+		row.state = st.State
+		if st.AuthAgeMs > 0 {
+			row.authAgeMs = st.AuthAgeMs
+		}
+		if st.LastError != "" && st.LastErrorAt >= row.lastErrorAt {
+			row.lastError, row.lastErrorAt = st.LastError, st.LastErrorAt
+		}
+	}
+	return rows
+}
+
+// channelStateStyle picks the style of a configuration or connection state
+func channelStateStyle(state string) lipgloss.Style {
+	switch strings.ToLower(state) {
+	case "linked", "connected", "ok", "configured":
+		return styles.StatusOK
+	case "error", "fail", "unlinked", "disconnected":
+		return styles.StatusDown
+	case "disabled", "":
+		return styles.Muted
+	}
+	return styles.StatusDegraded
+}
+
+// renderChannelsTable renders structured channel data as a table
+func (a *App) renderChannelsTable(width int) []string {
+	var lines []string
+
+	title := styles.HelpSection.Render("Channels")
+	if a.writesAllowed() {
+		title += "  " + styles.Muted.Render("j/k: select  l: link  u: unlink  R: restart  m: test message")
+	}
+	lines = append(lines, title)
+	lines = append(lines, "")
+
+	header := fmt.Sprintf("  %-14s %-12s %-12s %9s %7s %7s  %s", "Channel", "Status", "Connection", "Auth Age", "In", "Out", "Last Error")
+	lines = append(lines, styles.TableHeader.Render(header))
+
+	for i, ch := range a.channelRows() {
+		status, state := ch.status, ch.state
+		if status == "" {
+			status = "-"
+		}
+		if state == "" {
+			state = "-"
+		}
+
+		authAge := "-"
+		if ch.authAgeMs > 0 {
+			authAge = formatAge(ch.authAgeMs)
+		}
+		in, out := "-", "-"
+		if ch.hasCounts {
+			in, out = formatNumber(ch.in), formatNumber(ch.out)
+		}
+
+		lastErr := styles.Muted.Render("-")
+		if ch.lastError != "" {
+			text := ch.lastError
+			if ch.lastErrorAt > 0 {
+				text = formatAge(time.Now().UnixMilli()-ch.lastErrorAt) + " ago: " + text
+			}
+			lastErr = styles.LogError.Render(truncate(text, max(width-73, 10)))
+		}
+
+		row := fmt.Sprintf("  %s %s %s %9s %7s %7s  %s",
+			padRight(ch.label, 14),
+			channelStateStyle(ch.status).Render(padRight(status, 12)),
+			channelStateStyle(ch.state).Render(padRight(state, 12)),
+			authAge,
+			in,
+			out,
+			lastErr,
+		)
+		switch {
+		case i == a.channels.cursor && a.focusedPane == PaneDetails:
+			lines = append(lines, styles.TableRowSelected.Render(row))
+		case i%2 == 0:
+			lines = append(lines, row)
+		default:
+			lines = append(lines, styles.TableRowAlt.Render(row))
+		}
+	}
+
+	return lines
+}
+
 // ============================================================================
 // Memory Tab
 // ============================================================================
 
 func (a *App) renderMemoryTab(width, height int) string {
+	var lines []string
+
+	// Search results come first: the tab doubles as a search tool
+	lines = append(lines, a.renderMemorySearch(width, height)...)
+
 	if a.openclawStatus == nil || a.openclawStatus.Memory == nil {
-		return styles.Muted.Render("No memory/RAG data available")
+		lines = append(lines, styles.Muted.Render("No memory/RAG data available"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
 	mem := a.openclawStatus.Memory
-	var lines []string
 
 	lines = append(lines, styles.HelpSection.Render("Memory System (RAG)"))
 	lines = append(lines, "")
@@ -1047,11 +2552,7 @@ func (a *App) renderMemoryTab(width, height int) string {
 	lines = append(lines, "  "+styles.CardTitle.Render("Content"))
 	lines = append(lines, fmt.Sprintf("    Files:  %d", mem.Files))
 	lines = append(lines, fmt.Sprintf("    Chunks: %d", mem.Chunks))
-	if mem.Dirty {
-		lines = append(lines, "    Status: "+styles.LogWarn.Render("DIRTY (needs reindex)"))
-	} else {
-		lines = append(lines, "    Status: "+styles.StatusOK.Render("CLEAN"))
-	}
+	lines = append(lines, a.renderReindexStatus(mem, width)...)
 	lines = append(lines, "")
 
 	// Source breakdown
@@ -1091,8 +2592,14 @@ func (a *App) renderMemoryTab(width, height int) string {
 
 	// Cache
 	if mem.Cache.Enabled {
-		lines = append(lines, fmt.Sprintf("    Embedding Cache: %s (%d entries)",
-			styles.StatusOK.Render("enabled"), mem.Cache.Entries))
+		cache := fmt.Sprintf("    Embedding Cache: %s (%d entries)",
+			styles.StatusOK.Render("enabled"), mem.Cache.Entries)
+		if a.cacheClear.running {
+			cache += "  " + styles.Muted.Render("clearing...")
+		} else if a.writesAllowed() {
+			cache += "  " + styles.Muted.Render("C: clear")
+		}
+		lines = append(lines, cache)
 	} else {
 		lines = append(lines, "    Embedding Cache: "+styles.Muted.Render("disabled"))
 	}
@@ -1100,6 +2607,62 @@ func (a *App) renderMemoryTab(width, height int) string {
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
+// renderMemorySearch renders the memory search prompt state and ranked results
+func (a *App) renderMemorySearch(width, height int) []string {
+	var lines []string
+
+	query := a.memorySearchInput.Value()
+	switch {
+	case a.memorySearching:
+		lines = append(lines, styles.HelpSection.Render("Search"))
+		lines = append(lines, "  "+styles.Muted.Render(fmt.Sprintf("Searching for %q...", query)))
+	case a.memorySearchError != "":
+		lines = append(lines, styles.HelpSection.Render("Search"))
+		lines = append(lines, "  "+styles.LogError.Render(a.memorySearchError))
+	case a.memorySearch != nil:
+		result := a.memorySearch
+		lines = append(lines, styles.HelpSection.Render(fmt.Sprintf("Search: %q", result.Query))+"  "+
+			styles.Muted.Render(fmt.Sprintf("%d results (esc: clear)", len(result.Results))))
+		if len(result.Results) == 0 {
+			lines = append(lines, "  "+styles.Muted.Render("No matching chunks"))
+		}
+
+		// Each hit takes a title line plus up to two preview lines
+		maxHits := (height - 4) / 4
+		if maxHits < 1 {
+			maxHits = 1
+		}
+		for i, hit := range result.Results {
+			if i >= maxHits {
+				lines = append(lines, styles.Muted.Render(fmt.Sprintf("  ... %d more results", len(result.Results)-maxHits)))
+				break
+			}
+			location := hit.Path
+			if hit.StartLine > 0 {
+				location = fmt.Sprintf("%s:%d", hit.Path, hit.StartLine)
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s %s %s",
+				styles.LabelValueHighlight.Render(fmt.Sprintf("%2d.", i+1)),
+				styles.Muted.Render(fmt.Sprintf("%.2f", hit.Score)),
+				truncatePath(location, width-24),
+				styles.Muted.Render("["+hit.Source+"]")))
+			preview := wrapText(hit.Snippet, width-8)
+			if len(preview) > 2 {
+				preview = preview[:2]
+				preview[1] = truncate(preview[1]+" ...", width-8)
+			}
+			for _, pl := range preview {
+				lines = append(lines, "      "+styles.Muted.Render(pl))
+			}
+		}
+	default:
+		lines = append(lines, styles.Muted.Render("  Press / to search memory, b to browse indexed files"))
+	}
+	lines = append(lines, "")
+
+	return lines
+}
+
 // ============================================================================
 // Security Tab
 // ============================================================================
@@ -1112,7 +2675,7 @@ func (a *App) renderSecurityTab(width, height int) string {
 	audit := a.openclawStatus.SecurityAudit
 	var lines []string
 
-	lines = append(lines, styles.HelpSection.Render("Security Audit"))
+	lines = append(lines, styles.HelpSection.Render("Security Audit")+"  "+a.renderSecurityFilter())
 	lines = append(lines, "")
 
 	// Summary badges
@@ -1130,11 +2693,44 @@ func (a *App) renderSecurityTab(width, height int) string {
 	lines = append(lines, summaryLine)
 	lines = append(lines, "")
 
+	// Changes since the previous audit run
+	lines = append(lines, a.renderAuditDiff(width)...)
+
+	findings := a.filteredFindings()
+
+	// Count by check
+	if counts := countByCheck(findings); len(counts) > 1 {
+		lines = append(lines, styles.HelpSection.Render("By Check"))
+		for _, c := range counts {
+			style := styles.SeverityInfo
+			switch c.Severity {
+			case "critical":
+				style = styles.LogError
+			case "warn":
+				style = styles.LogWarn
+			}
+			lines = append(lines, fmt.Sprintf("  %3d  %s", c.Count, style.Render(c.CheckID)))
+		}
+		lines = append(lines, "")
+	}
+
 	// Findings
-	lines = append(lines, styles.HelpSection.Render("Findings"))
+	title := "Findings"
+	if len(findings) != len(audit.Findings) {
+		title = fmt.Sprintf("Findings (%d/%d)", len(findings), len(audit.Findings))
+	}
+	lines = append(lines, styles.HelpSection.Render(title))
 	lines = append(lines, "")
 
-	for _, finding := range audit.Findings {
+	r := &a.remediation
+	cursor := min(r.cursor, len(findings)-1)
+	selectedStart, selectedEnd := -1, -1
+	for i, finding := range findings {
+		selected := i == cursor && a.focusedPane == PaneDetails
+		if selected {
+			selectedStart = lipgloss.Height(lipgloss.JoinVertical(lipgloss.Left, lines...))
+		}
+
 		// Severity badge
 		var severityBadge string
 		switch finding.Severity {
@@ -1146,7 +2742,22 @@ func (a *App) renderSecurityTab(width, height int) string {
 			severityBadge = styles.SeverityInfo.Render(" INFO ")
 		}
 
-		lines = append(lines, "  "+severityBadge+" "+styles.CardTitle.Render(finding.Title))
+		titleLine := "  " + severityBadge + " " + styles.CardTitle.Render(finding.Title)
+		if selected {
+			titleLine = "> " + severityBadge + " " + styles.TableRowSelected.Render(finding.Title)
+		}
+		if a.auditDiff != nil && a.auditDiff.IsNew(finding) {
+			titleLine += " " + styles.BadgeWarning.Render("NEW")
+		}
+		if sameFinding(finding, r.finding) {
+			switch {
+			case r.running:
+				titleLine += " " + styles.Muted.Render("applying fix...")
+			case r.verifying:
+				titleLine += " " + styles.Muted.Render("re-auditing...")
+			}
+		}
+		lines = append(lines, titleLine)
 
 		// Detail (wrap if too long)
 		detailLines := wrapText(finding.Detail, width-6)
@@ -1156,12 +2767,55 @@ func (a *App) renderSecurityTab(width, height int) string {
 
 		// Remediation
 		if finding.Remediation != "" {
-			lines = append(lines, "    "+styles.StatusOK.Render("Fix: ")+finding.Remediation)
+			fix := "    " + styles.StatusOK.Render("Fix: ") + finding.Remediation
+			if selected && a.writesAllowed() {
+				fix += "  " + styles.HintKey.Render("F") + styles.Muted.Render(":apply")
+			}
+			lines = append(lines, fix)
+		}
+		if selected {
+			selectedEnd = lipgloss.Height(lipgloss.JoinVertical(lipgloss.Left, lines...))
 		}
 		lines = append(lines, "")
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	// Bring a newly selected finding into view; scrollLines shows height-2
+	// lines besides its position hint
+	if selectedStart >= 0 && r.shown != r.cursor {
+		r.shown = r.cursor
+		a.securityScroll = max(a.securityScroll, selectedEnd-(height-2))
+		a.securityScroll = min(a.securityScroll, selectedStart)
+	}
+
+	return scrollLines(lines, &a.securityScroll, height)
+}
+
+// scrollLines returns the window of lines starting at *offset that fits in
+// height, clamping the offset and showing a position hint when scrolled
+func scrollLines(lines []string, offset *int, height int) string {
+	// Styles with margins render as several lines; count what is displayed
+	lines = strings.Split(lipgloss.JoinVertical(lipgloss.Left, lines...), "\n")
+
+	maxVisible := height - 1
+	if maxVisible < 1 {
+		maxVisible = 1
+	}
+	if len(lines) <= maxVisible {
+		*offset = 0
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	maxOffset := len(lines) - maxVisible
+	if *offset > maxOffset {
+		*offset = maxOffset
+	}
+	if *offset < 0 {
+		*offset = 0
+	}
+	visible := append([]string(nil), lines[*offset:*offset+maxVisible]...)
+	visible = append(visible, styles.Muted.Render(fmt.Sprintf("  -- lines %d-%d of %d (j/k, pgup/pgdn) --",
+		*offset+1, *offset+maxVisible, len(lines))))
+	return lipgloss.JoinVertical(lipgloss.Left, visible...)
 }
 
 // ============================================================================
@@ -1176,6 +2830,9 @@ func (a *App) renderSystemTab(width, height int) string {
 	status := a.openclawStatus
 	var lines []string
 
+	// Update banner and changelog
+	lines = append(lines, a.renderUpdateBanner(width)...)
+
 	// Gateway info
 	if status.Gateway != nil {
 		gw := status.Gateway
@@ -1198,36 +2855,12 @@ func (a *App) renderSystemTab(width, height int) string {
 		lines = append(lines, "")
 	}
 
-	// Services
-	lines = append(lines, styles.HelpSection.Render("Services"))
-	if status.GatewayService != nil {
-		svc := status.GatewayService
-		svcBadge := styles.BadgeMuted.Render("NOT INSTALLED")
-		if svc.Installed {
-			if contains(svc.RuntimeShort, "running") {
-				svcBadge = styles.BadgeOK.Render("RUNNING")
-			} else {
-				svcBadge = styles.BadgeError.Render("STOPPED")
-			}
-		}
-		lines = append(lines, "  Gateway Service: "+svcBadge)
-		if svc.RuntimeShort != "" {
-			lines = append(lines, fmt.Sprintf("    %s", styles.Muted.Render(svc.RuntimeShort)))
-		}
-	}
-	if status.NodeService != nil {
-		svc := status.NodeService
-		svcBadge := styles.BadgeMuted.Render("NOT INSTALLED")
-		if svc.Installed {
-			if contains(svc.RuntimeShort, "running") {
-				svcBadge = styles.BadgeOK.Render("RUNNING")
-			} else {
-				svcBadge = styles.BadgeError.Render("STOPPED")
-			}
-		}
-		lines = append(lines, "  Node Service:    "+svcBadge)
-	}
-	lines = append(lines, "")
+	lines = append(lines, a.renderCLIVersionSection(width)...)
+	lines = append(lines, a.renderConnectionSection(width)...)
+
+	// Services and host processes
+	lines = append(lines, a.renderServicesSection(width)...)
+	lines = append(lines, a.renderProcessesSection(width)...)
 
 	// OS info
 	if status.OS != nil {
@@ -1243,9 +2876,13 @@ func (a *App) renderSystemTab(width, height int) string {
 		lines = append(lines, styles.HelpSection.Render("Update Status"))
 		lines = append(lines, fmt.Sprintf("  Install Kind: %s", status.Update.InstallKind))
 		lines = append(lines, fmt.Sprintf("  Pkg Manager:  %s", status.Update.PackageManager))
-		lines = append(lines, fmt.Sprintf("  Channel:      %s", status.UpdateChannel))
+		lines = append(lines, fmt.Sprintf("  Channel:      %s", status.UpdateChannel)+a.renderUpdateChannelHint())
 		if status.Update.Registry.LatestVersion != "" {
-			lines = append(lines, fmt.Sprintf("  Latest:       %s", styles.LabelValueHighlight.Render(status.Update.Registry.LatestVersion)))
+			latest := styles.LabelValueHighlight.Render(status.Update.Registry.LatestVersion)
+			if c := &a.updateChannel; a.switchedChannel() && c.fromLatest != "" && c.fromLatest != status.Update.Registry.LatestVersion {
+				latest += styles.Muted.Render(fmt.Sprintf("  (was %s on %s)", c.fromLatest, c.from))
+			}
+			lines = append(lines, fmt.Sprintf("  Latest:       %s", latest))
 		}
 		lines = append(lines, fmt.Sprintf("  Install Path: %s", truncatePath(status.Update.Root, width-16)))
 		lines = append(lines, "")
@@ -1272,13 +2909,13 @@ func (a *App) renderLogsTab(width, height int) string {
 	}
 	lines = append(lines, fmt.Sprintf("  %s  %s logs%s  %s",
 		followBadge,
-		styles.LabelValueHighlight.Render(fmt.Sprintf("%d", len(a.logs))),
+		styles.LabelValueHighlight.Render(fmt.Sprintf("%d", a.logs.Len())),
 		filterInfo,
 		styles.Muted.Render("(f:follow /:search)")))
 	lines = append(lines, "")
 
-	if len(a.logs) == 0 {
-		if a.logFollowing {
+	if a.logs.Len() == 0 {
+		if a.following() {
 			lines = append(lines, styles.Muted.Render("  Waiting for log events..."))
 		} else {
 			lines = append(lines, styles.Muted.Render("  No logs available. Press r to reconnect."))
@@ -1286,35 +2923,19 @@ func (a *App) renderLogsTab(width, height int) string {
 		return lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
-	// Filter logs
-	filter := strings.ToLower(a.searchInput.Value())
-	var filtered []models.LogEvent
-	for _, log := range a.logs {
-		if filter != "" && !strings.Contains(strings.ToLower(log.Message), filter) &&
-			!strings.Contains(strings.ToLower(log.Level), filter) {
-			continue
-		}
-		filtered = append(filtered, log)
-	}
+	// The buffer keeps the filtered index current; only format what fits
+	filter := a.searchInput.Value()
+	a.logs.SetFilter(filter)
 
-	// Calculate visible logs (show from the end if following)
 	maxVisible := height - 4
 	if maxVisible < 1 {
 		maxVisible = 1
 	}
 
-	startIdx := 0
-	if a.logFollow && len(filtered) > maxVisible {
-		startIdx = len(filtered) - maxVisible
-	} else if len(filtered) > maxVisible {
-		startIdx = len(filtered) - maxVisible
-	}
-
-	visible := filtered[startIdx:]
-	if len(visible) > maxVisible {
-		visible = visible[:maxVisible]
+	visible := a.logs.Tail(maxVisible)
+	if a.logScroll > 0 {
+		visible = a.logs.Page(a.logScroll, maxVisible)
 	}
-
 	for _, log := range visible {
 		var levelStyle lipgloss.Style
 		var levelTag string
@@ -1341,10 +2962,15 @@ func (a *App) renderLogsTab(width, height int) string {
 		lines = append(lines, line)
 	}
 
-	if filter != "" && len(filtered) != len(a.logs) {
+	if filter != "" && a.logs.MatchCount() != a.logs.Len() {
+		lines = append(lines, "")
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  Showing %d/%d logs (filtered)", a.logs.MatchCount(), a.logs.Len())))
+	}
+	if a.logScroll > 0 {
 		lines = append(lines, "")
-		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  Showing %d/%d logs (filtered)", len(filtered), len(a.logs))))
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  -- %d lines back (pgup/pgdn, pgdn to the end for live) --", a.logScroll)))
 	}
+	lines = append(lines, a.renderLogArchiveSummary()...)
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
@@ -1364,9 +2990,25 @@ func (a *App) renderHealthTab(width, height int) string {
 		return a.renderHealthCheckResult(width, height)
 	}
 
+	lines = append(lines, a.renderProbeStatus())
+	lines = append(lines, "")
+
+	// Say why the health check failed, telling a missing openclaw from an
+	// offline gateway
+	if a.healthError != nil {
+		lines = append(lines, renderHealthError(a.healthError, width)...)
+		lines = append(lines, "")
+		if review := a.renderHostKeyReview(width); review != nil {
+			lines = append(lines, review...)
+			lines = append(lines, "")
+		}
+	}
+
 	// Fall back to deriving health info from status
 	if a.openclawStatus == nil {
-		lines = append(lines, styles.Muted.Render("  No health data available. Waiting for health check..."))
+		if a.healthError == nil {
+			lines = append(lines, styles.Muted.Render("  No health data available. Waiting for health check..."))
+		}
 		return lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
@@ -1468,9 +3110,12 @@ func (a *App) renderHealthCheckResult(width, height int) string {
 		lines = append(lines, "  Overall: "+styles.BadgeMuted.Render(strings.ToUpper(result.Overall)))
 	}
 
+	lines[len(lines)-1] += a.healthChangeMarker("overall")
+
 	if result.ProbeDurationMs > 0 {
 		lines = append(lines, fmt.Sprintf("  Probe Duration: %dms", result.ProbeDurationMs))
 	}
+	lines = append(lines, a.renderProbeStatus())
 	lines = append(lines, "")
 
 	// Gateway health
@@ -1478,10 +3123,10 @@ func (a *App) renderHealthCheckResult(width, height int) string {
 		gw := result.Gateway
 		lines = append(lines, styles.HelpSection.Render("Gateway"))
 		if gw.Reachable {
-			lines = append(lines, fmt.Sprintf("  Reachable:  %s (%dms)",
-				styles.StatusOK.Render("yes"), gw.LatencyMs))
+			lines = append(lines, fmt.Sprintf("  Reachable:  %s (%dms)%s",
+				styles.StatusOK.Render("yes"), gw.LatencyMs, a.healthChangeMarker("gateway")))
 		} else {
-			lines = append(lines, "  Reachable:  "+styles.StatusDown.Render("no"))
+			lines = append(lines, "  Reachable:  "+styles.StatusDown.Render("no")+a.healthChangeMarker("gateway"))
 			if gw.Error != "" {
 				lines = append(lines, "  Error:      "+styles.LogError.Render(gw.Error))
 			}
@@ -1500,19 +3145,20 @@ func (a *App) renderHealthCheckResult(width, height int) string {
 			if label == "" {
 				label = ch.ID
 			}
+			changed := a.healthChangeMarker("channel:" + ch.ID)
 			switch strings.ToLower(ch.Status) {
 			case "ok", "connected":
-				lines = append(lines, fmt.Sprintf("  %s %s: %s",
-					styles.StatusOK.Render("*"), label, styles.StatusOK.Render(ch.Status)))
+				lines = append(lines, fmt.Sprintf("  %s %s: %s%s",
+					styles.StatusOK.Render("*"), label, styles.StatusOK.Render(ch.Status), changed))
 			case "error", "fail":
-				lines = append(lines, fmt.Sprintf("  %s %s: %s",
-					styles.StatusDown.Render("*"), label, styles.StatusDown.Render(ch.Status)))
+				lines = append(lines, fmt.Sprintf("  %s %s: %s%s",
+					styles.StatusDown.Render("*"), label, styles.StatusDown.Render(ch.Status), changed))
 				if ch.Error != "" {
 					lines = append(lines, "    "+styles.LogError.Render(ch.Error))
 				}
 			default:
-				lines = append(lines, fmt.Sprintf("  %s %s: %s",
-					styles.StatusDegraded.Render("*"), label, styles.Muted.Render(ch.Status)))
+				lines = append(lines, fmt.Sprintf("  %s %s: %s%s",
+					styles.StatusDegraded.Render("*"), label, styles.Muted.Render(ch.Status), changed))
 			}
 		}
 		lines = append(lines, "")
@@ -1522,16 +3168,17 @@ func (a *App) renderHealthCheckResult(width, height int) string {
 	if len(result.Services) > 0 {
 		lines = append(lines, styles.HelpSection.Render("Services"))
 		for _, svc := range result.Services {
+			changed := a.healthChangeMarker("service:" + svc.Name)
 			switch strings.ToLower(svc.Status) {
 			case "running":
-				lines = append(lines, fmt.Sprintf("  %s: %s",
-					svc.Name, styles.StatusOK.Render("running")))
+				lines = append(lines, fmt.Sprintf("  %s: %s%s",
+					svc.Name, styles.StatusOK.Render("running"), changed))
 			case "stopped":
-				lines = append(lines, fmt.Sprintf("  %s: %s",
-					svc.Name, styles.StatusDown.Render("stopped")))
+				lines = append(lines, fmt.Sprintf("  %s: %s%s",
+					svc.Name, styles.StatusDown.Render("stopped"), changed))
 			default:
-				lines = append(lines, fmt.Sprintf("  %s: %s",
-					svc.Name, styles.Muted.Render(svc.Status)))
+				lines = append(lines, fmt.Sprintf("  %s: %s%s",
+					svc.Name, styles.Muted.Render(svc.Status), changed))
 			}
 			if svc.Details != "" {
 				lines = append(lines, "    "+styles.Muted.Render(svc.Details))
@@ -1555,7 +3202,7 @@ func (a *App) renderHealthCheckResult(width, height int) string {
 			default:
 				statusBadge = styles.Muted.Render(strings.ToUpper(item.Status))
 			}
-			lines = append(lines, fmt.Sprintf("  [%s] %s", statusBadge, item.Check))
+			lines = append(lines, fmt.Sprintf("  [%s] %s%s", statusBadge, item.Check, a.healthChangeMarker("doctor:"+item.Check)))
 			if item.Message != "" {
 				lines = append(lines, "    "+styles.Muted.Render(item.Message))
 			}
@@ -1622,36 +3269,43 @@ var eventKeywords = []string{
 }
 
 func (a *App) renderEventsTab(width, height int) string {
+	if a.events.live() {
+		return a.renderGatewayEvents(width, height)
+	}
+
 	var lines []string
 
 	lines = append(lines, styles.HelpSection.Render("System Events"))
+	if a.events.closed != "" {
+		lines = append(lines, "  "+styles.Muted.Render(a.events.closed+" Showing events derived from the log stream."))
+	}
 	lines = append(lines, "")
 
-	if len(a.logs) == 0 {
+	if a.logs.Len() == 0 {
 		lines = append(lines, styles.Muted.Render("  No events yet. Events are derived from the log stream."))
-		if !a.logFollowing {
+		if !a.following() {
 			lines = append(lines, styles.Muted.Render("  Press r to reconnect and start receiving logs."))
 		}
 		return lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
-	// Filter logs to event-like entries
-	var events []models.LogEvent
-	for _, log := range a.logs {
-		if isEventLog(log) {
-			events = append(events, log)
+	// Filter logs to event-like entries, starting with archived history
+	events := a.logs.Archive().Notable()
+	a.logs.Each(func(log *models.LogEvent) {
+		if isEventLog(*log) {
+			events = append(events, *log)
 		}
-	}
+	})
 
 	if len(events) == 0 {
 		lines = append(lines, styles.Muted.Render("  No system events detected in log stream."))
-		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  (%d total log entries)", len(a.logs))))
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  (%d total log entries)", a.logs.Len())))
 		return lipgloss.JoinVertical(lipgloss.Left, lines...)
 	}
 
 	lines = append(lines, fmt.Sprintf("  %s events from %s log entries",
 		styles.LabelValueHighlight.Render(fmt.Sprintf("%d", len(events))),
-		styles.Muted.Render(fmt.Sprintf("%d", len(a.logs)))))
+		styles.Muted.Render(fmt.Sprintf("%d", a.logs.Len()+a.logs.Archive().Stored().count))))
 	lines = append(lines, "")
 
 	// Show most recent events (from the end)
@@ -1755,27 +3409,50 @@ func formatNumber(n int) string {
 
 // truncate truncates a string to max length with ellipsis
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	if runewidth.StringWidth(s) <= maxLen {
 		return s
 	}
 	if maxLen <= 3 {
-		return s[:maxLen]
+		return runewidth.Truncate(s, maxLen, "")
 	}
-	return s[:maxLen-3] + "..."
+	return runewidth.Truncate(s, maxLen, "...")
 }
 
 // truncatePath truncates a path, keeping the end visible
 func truncatePath(path string, maxLen int) string {
-	if len(path) <= maxLen {
+	if runewidth.StringWidth(path) <= maxLen {
 		return path
 	}
 	if maxLen <= 6 {
-		return path[len(path)-maxLen:]
+		return truncateLeft(path, maxLen)
+	}
+	return "..." + truncateLeft(path, maxLen-3)
+}
+
+// truncateLeft returns the end of s that fits in maxLen columns
+func truncateLeft(s string, maxLen int) string {
+	runes := []rune(s)
+	width := 0
+	i := len(runes)
+	for i > 0 {
+		w := runewidth.RuneWidth(runes[i-1])
+		if width+w > maxLen {
+			break
+		}
+		width += w
+		i--
 	}
-	return "..." + path[len(path)-maxLen+3:]
+	return string(runes[i:])
 }
 
-// wrapText wraps text to fit within maxWidth
+// padRight truncates s to width columns and pads it with spaces to exactly
+// width. Use it instead of %-*s, which pads by runes rather than columns.
+func padRight(s string, width int) string {
+	return runewidth.FillRight(truncate(s, width), width)
+}
+
+// wrapText wraps text to fit within maxWidth columns. Words wider than a
+// line (such as CJK text, which has no spaces) are broken.
 func wrapText(text string, maxWidth int) []string {
 	if maxWidth <= 0 {
 		return []string{text}
@@ -1784,15 +3461,34 @@ func wrapText(text string, maxWidth int) []string {
 	var lines []string
 	words := splitWords(text)
 	currentLine := ""
+	currentWidth := 0
 
 	for _, word := range words {
+		for runewidth.StringWidth(word) > maxWidth {
+			if currentLine != "" {
+				lines = append(lines, currentLine)
+				currentLine, currentWidth = "", 0
+			}
+			head := runewidth.Truncate(word, maxWidth, "")
+			if head == "" {
+				// A single character wider than the line
+				head = string([]rune(word)[:1])
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		if word == "" {
+			continue
+		}
+		wordWidth := runewidth.StringWidth(word)
 		if currentLine == "" {
-			currentLine = word
-		} else if len(currentLine)+1+len(word) <= maxWidth {
+			currentLine, currentWidth = word, wordWidth
+		} else if currentWidth+1+wordWidth <= maxWidth {
 			currentLine += " " + word
+			currentWidth += 1 + wordWidth
 		} else {
 			lines = append(lines, currentLine)
-			currentLine = word
+			currentLine, currentWidth = word, wordWidth
 		}
 	}
 	if currentLine != "" {
@@ -1854,7 +3550,34 @@ func renderProgressBar(percent int, width int) string {
 	return fmt.Sprintf("[%s%s] %3d%%", filledChar, emptyChar, percent)
 }
 
+// setFlash shows a transient message in the bottom bar
+func (a *App) setFlash(msg string, isError bool) {
+	a.flash = msg
+	a.flashIsError = isError
+	a.flashAt = time.Now()
+}
+
+// flashDuration is how long flash messages stay in the bottom bar
+const flashDuration = 5 * time.Second
+
+// pageSize returns the number of lines a page-scroll moves in the details pane
+func (a *App) pageSize() int {
+	size := a.height - 12
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
 func (a *App) renderBottomBar() string {
+	if a.flash != "" && time.Since(a.flashAt) < flashDuration {
+		style := styles.StatusOK
+		if a.flashIsError {
+			style = styles.StatusDown
+		}
+		return styles.BottomBar.Width(a.width).Render(style.Render(a.flash))
+	}
+
 	hints := []string{
 		styles.HintKey.Render("q") + styles.HintDesc.Render(":quit"),
 		styles.HintKey.Render("?") + styles.HintDesc.Render(":help"),
@@ -1863,10 +3586,32 @@ func (a *App) renderBottomBar() string {
 		styles.HintKey.Render("f") + styles.HintDesc.Render(":follow"),
 		styles.HintKey.Render("r") + styles.HintDesc.Render(":refresh"),
 	}
+	if a.debug {
+		hints = append(hints, a.renderPoolStats())
+	}
 
 	return styles.BottomBar.Width(a.width).Render(lipgloss.JoinHorizontal(lipgloss.Left, joinWithSeparator(hints, "  ")...))
 }
 
+// renderPoolStats summarizes command pool load for debug mode
+func (a *App) renderPoolStats() string {
+	stats := gateway.Stats()
+	text := fmt.Sprintf("cmds %d/%d", stats.Running, stats.Size)
+	if stats.Queued > 0 {
+		text += fmt.Sprintf(" +%d queued", stats.Queued)
+	}
+	text += fmt.Sprintf(" · %d done · wait avg %s max %s", stats.Completed,
+		stats.AvgWait.Round(time.Millisecond), stats.MaxWait.Round(time.Millisecond))
+	if cli := a.cliAdapter(); cli != nil {
+		text += fmt.Sprintf(" · %s fetches %d", cli.GetInstanceName(), cli.Queued())
+	}
+	style := styles.Muted
+	if stats.Queued > 0 {
+		style = styles.LogWarn
+	}
+	return style.Render(text)
+}
+
 func (a *App) renderSearchBar() string {
 	prompt := styles.InputPrompt.Render("Search: ")
 	return prompt + a.searchInput.View()
@@ -1887,18 +3632,94 @@ func (a *App) renderHelp() string {
 	help += "  4  Channels    - WhatsApp, Telegram status\n"
 	help += "  5  Agents      - Agent configuration\n"
 	help += "  6  Sessions    - Active sessions & token usage\n"
-	help += "  7  Events      - System events feed\n"
+	help += "  7  Events      - Live gateway events feed\n"
 	help += "  8  Memory      - RAG/vector search info\n"
 	help += "  9  Security    - Security audit findings\n"
-	help += "  0  System      - Services, OS, updates\n\n"
+	help += "  0  System      - Services, OS, updates\n"
+	help += "  -  Usage       - Token usage & estimated costs\n"
+	help += "  =  Config      - Gateway configuration\n"
+	help += "  [  Hooks       - Webhooks & integrations\n"
+	help += "  ]  Queues      - Message queues & backlogs\n\n"
+
+	help += styles.HelpSection.Render("Logs") + "\n"
+	help += "  pgup/pgdn      Scroll back through history (incl. archived lines)\n\n"
+
+	help += styles.HelpSection.Render("Health") + "\n"
+	help += "  p              Run a health probe now\n"
+	help += "  A              Review and trust an unknown SSH host key\n\n"
+
+	help += styles.HelpSection.Render("Channels") + "\n"
+	help += "  j/k            Select channel\n"
+	help += "  l              Link the channel by scanning its QR code (relink if linked)\n"
+	help += "  u / R          Unlink, restart the channel (confirm first)\n"
+	help += "  m              Send a test message through the channel\n\n"
+
+	help += styles.HelpSection.Render("Sessions") + "\n"
+	help += "  pgup/pgdn      Previous/next page\n"
+	help += "  a / t / u      Cycle agent, kind, min-usage filters\n"
+	help += "  j/k, K         Select session, kill it (confirm first)\n"
+	help += "  c              Compact the selected session now\n"
+	help += "  C              Reset (clear) the selected session's context (confirm first)\n\n"
+
+	help += styles.HelpSection.Render("Agents") + "\n"
+	help += "  j/k, enter     Select agent, browse its workspace\n"
+	help += "  s              Show the selected agent's sessions\n"
+	help += "  h / H / D      Toggle heartbeat, set interval, make default\n"
+	help += "  B              Bootstrap the selected pending agent\n"
+	help += "  N              Create an agent (name, workspace, model)\n"
+	help += "  backspace      Up a directory / close preview\n\n"
+
+	help += styles.HelpSection.Render("Events") + "\n"
+	help += "  v              Cycle severity filter (all/warn+/error+)\n"
+	help += "  /              Filter by type, source or message\n\n"
+
+	help += styles.HelpSection.Render("Memory") + "\n"
+	help += "  /              Search memory\n"
+	help += "  b              Browse indexed files (j/k to move)\n"
+	help += "  i              Reindex memory (requires write scopes)\n"
+	help += "  C              Clear the embedding cache (confirm first)\n\n"
+
+	help += styles.HelpSection.Render("Security") + "\n"
+	help += "  v              Cycle severity filter (all/critical/warn+)\n"
+	help += "  E              Export audit as Markdown + CSV\n"
+	help += "  F              Run the selected finding's remediation, then re-audit\n\n"
+
+	help += styles.HelpSection.Render("Usage") + "\n"
+	help += "  p              Cycle period (today/7 days/30 days/all time)\n\n"
+
+	help += styles.HelpSection.Render("Config") + "\n"
+	help += "  /              Filter keys and values\n"
+	help += "  j/k, enter     Select key, edit it (whitelisted keys only)\n\n"
+
+	help += styles.HelpSection.Render("Hooks") + "\n"
+	help += "  j/k, t         Select webhook, send a test delivery\n\n"
+
+	help += styles.HelpSection.Render("System") + "\n"
+	help += "  U              Update gateway\n"
+	help += "  V              Switch update channel (stable/beta/nightly)\n"
+	help += "  j/k            Select gateway or node service\n"
+	help += "  s / S / R      Start, stop, restart service\n"
+	help += "  enter          Start the service if stopped, stop it if running\n"
+	help += "  B              Enable or disable starting the service at boot\n"
+	help += "  L              Show the selected service's logs\n"
+	help += "  T / K          SIGTERM / SIGKILL selected process\n"
+	help += "  c              Show the commands run against this instance\n\n"
 
 	help += styles.HelpSection.Render("Actions") + "\n"
-	help += "  /              Search/filter logs\n"
+	help += "  x              Pick from the current tab's actions\n"
+	help += "  /              Search/filter logs (search memory on Memory tab)\n"
 	help += "  f              Toggle log follow mode\n"
 	help += "  r              Refresh status\n"
+	help += "  n              Discover openclaw hosts on the tailnet\n"
+	help += "  d              Run openclaw doctor and show its checks\n"
+	help += "  ctrl+p         Toggle performance overlay\n"
 	help += "  ?              Show this help\n"
 	help += "  q              Quit\n\n"
 
+	help += styles.HelpSection.Render("Output Viewer") + "\n"
+	help += "  j/k            Scroll a write action's output\n"
+	help += "  r              Run the action again, once finished\n\n"
+
 	help += styles.Muted.Render("Press esc or ? to close")
 
 	// Center the help overlay
@@ -1924,134 +3745,212 @@ func (a *App) getStatusBadge() string {
 	return styles.StatusOK.Render("[OK]")
 }
 
-func (a *App) updateViewportSizes() {
-	// Update viewport sizes based on window dimensions
-	// Currently a no-op as we render logs inline
-}
-
-func (a *App) connectMock() tea.Cmd {
-	return func() tea.Msg {
-		a.mockClient = gateway.NewMockClient()
-		return a.mockClient.Connect()
-	}
-}
+func (a *App) fetchCLIStatus() tea.Cmd {
+	exclude := a.statusExclusions()
+	adapter := a.getCurrentAdapter()
+	cli, isCLI := adapter.(*gateway.CLIAdapter)
+	if !isCLI {
+		// Only the CLI can leave sections out
+		exclude = nil
+	}
+	return a.scoped(func() tea.Msg {
+		if adapter == nil {
+			return CLIStatusMsg{Error: fmt.Errorf("adapter not initialized")}
+		}
+		var status *models.OpenClawStatus
+		var err error
+		if isCLI {
+			status, err = cli.GetStatus(exclude)
+		} else {
+			status, err = adapter.GetFullStatus()
+		}
+		if err != nil {
+			return CLIStatusMsg{Error: err}
+		}
 
-func (a *App) waitForMockLog() tea.Cmd {
-	return func() tea.Msg {
-		if a.mockClient == nil {
-			return nil
+		// Track link/unlink transitions for the channel detail view
+		var linkEvents []history.LinkEvent
+		if lc := status.LinkChannel; lc != nil && a.history != nil {
+			_ = a.history.RecordLink(adapter.GetInstanceName(), lc)
+			linkEvents, _ = a.history.LinkEvents(adapter.GetInstanceName(), lc.ID)
 		}
-		log, ok := <-a.mockClient.GetLogs()
-		if !ok {
-			return gateway.DisconnectedMsg{Error: "mock client closed"}
+
+		// Record token usage for the Usage tab
+		_ = a.history.RecordUsage(adapter.GetInstanceName(), allSessions(status.Sessions))
+
+		// Persist audit runs so the Security tab can show what changed
+		var auditDiff *history.AuditDiff
+		if status.SecurityAudit != nil && a.history != nil {
+			auditDiff, _ = a.history.RecordAudit(adapter.GetInstanceName(), status.SecurityAudit)
 		}
-		return gateway.LogMsg{Event: log}
-	}
+		return CLIStatusMsg{Status: status, LinkEvents: linkEvents, AuditDiff: auditDiff, Excluded: exclude}
+	})
 }
 
-func (a *App) fetchCLIStatus() tea.Cmd {
-	return func() tea.Msg {
-		adapter := a.getCurrentAdapter()
+func (a *App) fetchCLIHealth() tea.Cmd {
+	adapter := a.getCurrentAdapter()
+	return a.scoped(func() tea.Msg {
 		if adapter == nil {
-			return CLIStatusMsg{Error: fmt.Errorf("CLI adapter not initialized")}
+			return CLIHealthMsg{Error: fmt.Errorf("adapter not initialized")}
 		}
-		status, err := adapter.GetFullStatus()
-		return CLIStatusMsg{Status: status, Error: err}
-	}
+		result, err := adapter.GetHealthSnapshot()
+		if err != nil {
+			return CLIHealthMsg{Error: err}
+		}
+		return CLIHealthMsg{Result: result, Components: a.recordHealth(adapter.GetInstanceName(), result)}
+	})
 }
 
-func (a *App) fetchCLIHealth() tea.Cmd {
+func (a *App) searchMemory(query string) tea.Cmd {
 	return func() tea.Msg {
-		adapter := a.getCurrentAdapter()
+		adapter := a.cliAdapter()
 		if adapter == nil {
-			return CLIHealthMsg{Error: fmt.Errorf("CLI adapter not initialized")}
+			return CLIMemorySearchMsg{Error: fmt.Errorf("CLI adapter not initialized")}
 		}
-		result, err := adapter.GetHealthSnapshot()
-		return CLIHealthMsg{Result: result, Error: err}
+		result, err := adapter.SearchMemory(query)
+		return CLIMemorySearchMsg{Result: result, Error: err}
 	}
 }
 
-// startLogFollowing starts the log following process for the current adapter
-func (a *App) startLogFollowing() tea.Cmd {
-	return func() tea.Msg {
-		adapter := a.getCurrentAdapter()
+func (a *App) fetchCLIChannels() tea.Cmd {
+	adapter := a.cliAdapter()
+	return a.scoped(func() tea.Msg {
 		if adapter == nil {
-			return nil
+			return CLIChannelsMsg{Error: fmt.Errorf("CLI adapter not initialized")}
 		}
+		channels, err := adapter.GetChannels()
+		// Older openclaw releases have no channels status; the table then
+		// shows configuration only
+		status, _ := adapter.GetChannelsStatus()
+		return CLIChannelsMsg{Channels: channels, Status: status, Error: err}
+	})
+}
 
-		// Create channel and context for log streaming
-		a.logChan = make(chan models.LogEvent, 100)
-		a.logCtx, a.logCancel = context.WithCancel(context.Background())
+// instanceSwitchDelay is how long the instance selection must stay put before
+// the new instance is loaded, so scrolling through instances stays cheap
+const instanceSwitchDelay = 300 * time.Millisecond
 
-		if err := adapter.FollowLogs(a.logCtx, a.logChan); err != nil {
-			// Log following failed to start - not fatal
-			return CLILogMsg{Event: models.LogEvent{
-				Timestamp: time.Now(),
-				Level:     "warn",
-				Source:    "lazyclaw",
-				Message:   fmt.Sprintf("Could not start log following: %v", err),
-			}}
-		}
+// InstanceSettledMsg is sent instanceSwitchDelay after an instance switch
+type InstanceSettledMsg struct {
+	Seq int
+}
 
-		a.logFollowing = true
+// switchInstance handles switching to a new instance. State is cleared right
+// away; fetching starts once the selection settles (see loadInstance).
+func (a *App) switchInstance(cmds *[]tea.Cmd) {
+	a.openclawStatus = nil
+	a.statusTimeout = nil
+	a.statusCached = false
+	a.healthError = nil
+	a.hostKey = hostKeyReview{}
+	a.staleSections = nil
+	clear(a.statusDeltas.changed)
+	a.healthCheckResult = nil
+	a.channelsList = nil
+	a.channelsStatus = nil
+	a.linkEvents = nil
+	a.auditDiff = nil
+	a.remediation = securityRemediation{}
+	a.memorySearch = nil
+	a.memorySearchError = ""
+	a.memoryBrowser = memoryFileBrowser{}
+	a.reindex = reindexTracker{}
+	a.agentCursor = 0
+	a.bootstrap = agentBootstrap{seq: a.bootstrap.seq}
+	a.workspace = workspaceBrowser{}
+	a.update = updateTracker{}
+	a.updateChannel = updateChannelSwitch{}
+	a.services = serviceControl{}
+	a.processes = processList{}
+	a.hooks = webhookView{}
+	a.latencySamples = nil
+	a.queueDepths = nil
+	a.probe = healthProbe{}
+	a.healthComponents = nil
+	a.usage = usageState{period: a.usage.period}
+	a.gatewayConfig = newGatewayConfigView()
+	a.sizeInputs()
+	a.logs.Reset()
+	a.logScroll = 0
+	a.stopLogFollowing()
+	a.stopEventStream()
+	a.stopHealthWatch()
+	a.leaveInstance()
+	a.startLoading()
+
+	a.switchSeq++
+	a.switchPending = true
+	seq := a.switchSeq
+	*cmds = append(*cmds, tea.Tick(instanceSwitchDelay, func(time.Time) tea.Msg {
+		return InstanceSettledMsg{Seq: seq}
+	}))
+}
 
-		// Wait for the first log event
-		select {
-		case event, ok := <-a.logChan:
-			if !ok {
-				a.logFollowing = false
-				return nil
-			}
-			return CLILogMsg{Event: event}
-		case <-a.logCtx.Done():
-			return nil
-		}
+// loadInstance fetches the selected instance's data and starts its streams
+func (a *App) loadInstance() tea.Cmd {
+	a.switchPending = false
+	a.scope.shown = a.cliAdapter()
+	a.lastRefresh = time.Now()
+	a.startLoading()
+	a.restoreCachedStatus()
+
+	// Remote instances get the sections below in one SSH round trip; the
+	// fetches then pick up their share of its output
+	var cmds []tea.Cmd
+	if prefetch := a.prefetchInstance(); prefetch != nil {
+		cmds = append(cmds, prefetch)
+	}
+
+	// The visible tab's data is requested first, so it claims a command slot
+	// ahead of the rest
+	cmds = append(cmds, a.tabDataCmds()...)
+	if a.activeTab == TabHealth {
+		cmds = append(cmds, a.fetchCLIHealth(), a.fetchCLIStatus())
+	} else {
+		cmds = append(cmds, a.fetchCLIStatus(), a.fetchCLIHealth())
 	}
+	cmds = append(cmds, a.startLogFollowing())
+	cmds = append(cmds, a.startEventStream())
+	cmds = append(cmds, a.startHealthWatch())
+	return tea.Batch(cmds...)
 }
 
-// waitForCLILog waits for the next log event from the CLI log channel
-func (a *App) waitForCLILog() tea.Cmd {
-	return func() tea.Msg {
-		if a.logChan == nil {
-			return nil
-		}
-		select {
-		case event, ok := <-a.logChan:
-			if !ok {
-				a.logFollowing = false
-				return nil
-			}
-			return CLILogMsg{Event: event}
-		case <-a.logCtx.Done():
-			return nil
+// cliTabRefreshCmds returns the active tab's periodic fetches beyond status,
+// which only the CLI provides
+func (a *App) cliTabRefreshCmds() []tea.Cmd {
+	var cmds []tea.Cmd
+	switch a.activeTab {
+	case TabChannels:
+		cmds = append(cmds, a.fetchCLIChannels())
+	case TabUsage:
+		cmds = append(cmds, a.fetchUsage())
+	case TabSystem:
+		cmds = append(cmds, a.fetchProcesses())
+	case TabMemory:
+		// Catch reindexes started outside lazyclaw
+		if !a.reindex.polling {
+			cmds = append(cmds, a.fetchMemoryIndexStatus())
 		}
 	}
+	return cmds
 }
 
-// stopLogFollowing stops the current log following process
-func (a *App) stopLogFollowing() {
-	if a.logCancel != nil {
-		a.logCancel()
+// refreshDue reports whether the active tab's refresh interval has elapsed.
+// Nothing is due while an instance switch is settling, and the interval is
+// stretched to ui.unfocused_refresh while the terminal is unfocused.
+func (a *App) refreshDue() bool {
+	if a.switchPending {
+		return false
 	}
-	a.logFollowing = false
-	// Drain the channel
-	if a.logChan != nil {
-		go func() {
-			for range a.logChan {
-			}
-		}()
+	interval := a.config.UI.RefreshInterval(strings.ToLower(a.activeTab.String()))
+	if a.blurred {
+		unfocused := a.config.UI.UnfocusedRefreshInterval()
+		if unfocused == 0 {
+			return false
+		}
+		interval = max(interval, unfocused)
 	}
-}
-
-// switchInstance handles switching to a new instance
-func (a *App) switchInstance(cmds *[]tea.Cmd) {
-	a.openclawStatus = nil
-	a.healthCheckResult = nil
-	a.logs = nil
-	a.stopLogFollowing()
-	*cmds = append(*cmds, a.fetchCLIStatus())
-	*cmds = append(*cmds, a.fetchCLIHealth())
-	*cmds = append(*cmds, a.startLogFollowing())
+	return interval > 0 && time.Since(a.lastRefresh) >= interval
 }
 
 func (a *App) scheduleRefresh() tea.Cmd {
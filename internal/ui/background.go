@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// backgroundMinStep is the shortest gap between two background polls,
+// however large the fleet, so polling never crowds out the selected instance
+const backgroundMinStep = time.Second
+
+// backgroundExclusions are left out of background status fetches: the
+// instance badges only need the gateway's reachability
+var backgroundExclusions = []string{
+	gateway.StatusSectionSecurityAudit,
+	gateway.StatusSectionMemory,
+	gateway.StatusSectionAgents,
+}
+
+// BackgroundPollMsg polls the next non-selected instance
+type BackgroundPollMsg struct{}
+
+// BackgroundStatusMsg is sent when a background status fetch completes; the
+// result is cached by the adapter, where the instance badges read it
+type BackgroundStatusMsg struct {
+	Error error
+}
+
+// backgroundPoller keeps the badges of non-selected instances current. It
+// polls one instance per step, staggering the fleet over
+// ui.background_refresh, with at most one fetch in flight.
+type backgroundPoller struct {
+	next     int  // Index of the adapter to consider next
+	inFlight bool // A background fetch is running
+}
+
+// scheduleBackgroundPoll schedules the next poll, or returns nil if
+// background polling is off or there is nothing to poll
+func (a *App) scheduleBackgroundPoll() tea.Cmd {
+	interval := a.config.UI.BackgroundRefreshInterval()
+	others := len(a.adapters) - 1
+	if interval == 0 || others < 1 {
+		return nil
+	}
+	step := max(interval/time.Duration(others), backgroundMinStep)
+	return tea.Tick(step, func(time.Time) tea.Msg {
+		return BackgroundPollMsg{}
+	})
+}
+
+// handleBackgroundPoll starts a status fetch for the next non-selected
+// instance due one, and schedules the following poll
+func (a *App) handleBackgroundPoll() tea.Cmd {
+	next := a.scheduleBackgroundPoll()
+	if a.backgroundPoll.inFlight || (a.blurred && a.config.UI.UnfocusedRefreshInterval() == 0) {
+		return next
+	}
+	cli := a.nextBackgroundAdapter()
+	if cli == nil {
+		return next
+	}
+	a.backgroundPoll.inFlight = true
+	return tea.Batch(next, func() tea.Msg {
+		_, err := cli.GetStatus(backgroundExclusions)
+		return BackgroundStatusMsg{Error: err}
+	})
+}
+
+// nextBackgroundAdapter returns the next CLI adapter, other than the
+// selected one, whose cached status is older than the background interval
+// (stretched while the terminal is unfocused), or nil if none is due
+func (a *App) nextBackgroundAdapter() *gateway.CLIAdapter {
+	interval := a.config.UI.BackgroundRefreshInterval()
+	if a.blurred {
+		interval = max(interval, a.config.UI.UnfocusedRefreshInterval())
+	}
+	current := a.getCurrentAdapter()
+	p := &a.backgroundPoll
+	for range a.adapters {
+		p.next %= len(a.adapters)
+		adapter := a.adapters[p.next]
+		p.next++
+		cli, ok := adapter.(*gateway.CLIAdapter)
+		if !ok || adapter == current {
+			continue
+		}
+		if age := cli.GetStatusAge(); age == 0 || age >= interval {
+			return cli
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package ui
+
+import "github.com/lazyclaw/lazyclaw/internal/gateway"
+
+// enableBreakers applies the configured circuit breaker to the CLI adapters
+func (a *App) enableBreakers() {
+	policy := gateway.BreakerPolicy{
+		Failures:      a.config.CircuitBreaker.Threshold(),
+		ProbeInterval: a.config.CircuitBreaker.Interval(),
+	}
+	for _, adapter := range a.adapters {
+		if cli, ok := adapter.(*gateway.CLIAdapter); ok {
+			cli.Breaker = policy
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// defaultTestMessage is sent when the prompt for the text is left empty
+const defaultTestMessage = "Test message from lazyclaw"
+
+// ChannelSendMsg is sent when a test message send returns
+type ChannelSendMsg struct {
+	Channel   string
+	Recipient string
+	Error     error
+}
+
+func (m ChannelSendMsg) failure() error { return m.Error }
+
+// channelSend holds the test message prompt, which asks for the recipient
+// and then the text
+type channelSend struct {
+	input     textinput.Model
+	target    channelTarget
+	recipient string // Set once entered; the prompt then asks for the text
+	sending   bool
+}
+
+func newChannelSend() channelSend {
+	in := textinput.New()
+	in.CharLimit = 1000
+	return channelSend{input: in}
+}
+
+// openChannelSend prompts for a test message to send through the selected
+// channel
+func (a *App) openChannelSend() tea.Cmd {
+	s := &a.channelSend
+	target, ok := a.writableChannel()
+	if !ok || s.sending {
+		return nil
+	}
+	s.target, s.recipient = target, ""
+	s.input.Reset()
+	s.input.Placeholder = "Phone number, username or chat ID"
+	s.input.Focus()
+	a.mode = ModeChannelSend
+	return textinput.Blink
+}
+
+// closeChannelSend closes the prompt
+func (a *App) closeChannelSend() {
+	a.mode = ModeNormal
+	a.channelSend.input.Blur()
+}
+
+// handleChannelSendKey handles keys while the test message prompt is open.
+// enter moves from the recipient to the text, then sends.
+func (a *App) handleChannelSendKey(msg tea.KeyMsg) tea.Cmd {
+	s := &a.channelSend
+	switch {
+	case key.Matches(msg, a.keys.Escape):
+		a.closeChannelSend()
+		return nil
+	case key.Matches(msg, a.keys.Enter):
+		value := strings.TrimSpace(s.input.Value())
+		if s.recipient != "" {
+			return a.sendTestMessage(cmp.Or(value, defaultTestMessage))
+		}
+		if value != "" {
+			s.recipient = value
+			s.input.Reset()
+			s.input.Placeholder = defaultTestMessage
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	return cmd
+}
+
+// sendTestMessage asks to confirm sending text to the recipient entered,
+// then sends it, flashing whether it was delivered
+func (a *App) sendTestMessage(text string) tea.Cmd {
+	s := &a.channelSend
+	a.closeChannelSend()
+	target, recipient := s.target, s.recipient
+	return a.confirmWrite(writeRequest{
+		title:   "Send Test Message",
+		prompt:  fmt.Sprintf("Send a message to %s through %s on %s?", recipient, target.label, a.cliAdapter().GetInstanceName()),
+		preview: func(c *gateway.CLIAdapter) error { return c.SendMessage(target.id, recipient, text) },
+		run:     func() tea.Cmd { return a.deliverTestMessage(target, recipient, text) },
+	})
+}
+
+// deliverTestMessage sends the confirmed test message
+func (a *App) deliverTestMessage(target channelTarget, recipient, text string) tea.Cmd {
+	a.channelSend.sending = true
+	adapter := a.cliAdapter()
+	return func() tea.Msg {
+		err := adapter.SendMessage(target.id, recipient, text)
+		return ChannelSendMsg{Channel: target.label, Recipient: recipient, Error: err}
+	}
+}
+
+func (a *App) handleChannelSend(msg ChannelSendMsg) tea.Cmd {
+	a.channelSend.sending = false
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+	} else {
+		a.setFlash(fmt.Sprintf("Test message delivered to %s via %s", msg.Recipient, msg.Channel), false)
+	}
+	// Pick up the message counts and any new channel error
+	return a.fetchCLIChannels()
+}
+
+// channelSendPrompt returns the label of the test message prompt
+func (a *App) channelSendPrompt() string {
+	s := &a.channelSend
+	if s.recipient == "" {
+		return "Send test message via " + s.target.label + " to: "
+	}
+	return "Message to " + s.recipient + ": "
+}
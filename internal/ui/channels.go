@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// channelActions describe the channel actions: the verb titling the
+// confirmation, the openclaw channels subcommand run, what the result flash
+// says was done, what the confirmation warns of and whether the action is
+// destructive, so confirmed by typing the instance name
+var channelActions = map[string]struct {
+	verb        string
+	command     string
+	done        string
+	warning     string
+	destructive bool
+}{
+	"unlink":  {"Unlink", "logout", "unlinked", "It stops receiving messages until it is linked again.", true},
+	"relink":  {"Relink", "logout", "unlinked", "It is unlinked, then linked again by scanning a new QR code.", true},
+	"restart": {"Restart", "restart", "restarted", "Its connection drops while it reconnects.", false},
+}
+
+// ChannelActionMsg is sent when a channel unlink/relink/restart returns
+type ChannelActionMsg struct {
+	Channel channelTarget
+	Action  string
+	Error   error
+}
+
+func (m ChannelActionMsg) failure() error { return m.Error }
+
+// channelTarget is a channel the Channels tab actions apply to
+type channelTarget struct {
+	id     string
+	label  string
+	linked bool
+}
+
+// channelControl holds the Channels tab selection and the channel action
+// running
+type channelControl struct {
+	cursor  int    // Index into the channel table
+	running string // ID of the channel an action is running on
+}
+
+// moveChannelCursor moves the Channels tab selection by delta
+func (a *App) moveChannelCursor(delta int) {
+	a.channels.cursor = min(max(a.channels.cursor+delta, 0), max(len(a.channelRows())-1, 0))
+}
+
+// selectedChannel returns the channel selected in the table or, without
+// one, the link channel of the status
+func (a *App) selectedChannel() (channelTarget, bool) {
+	if rows := a.channelRows(); len(rows) > 0 {
+		row := rows[min(a.channels.cursor, len(rows)-1)]
+		return channelTarget{id: row.id, label: row.label, linked: row.linked}, true
+	}
+	if a.openclawStatus != nil && a.openclawStatus.LinkChannel != nil {
+		lc := a.openclawStatus.LinkChannel
+		return channelTarget{id: cmp.Or(lc.ID, "whatsapp"), label: cmp.Or(lc.Label, "WhatsApp"), linked: lc.Linked}, true
+	}
+	return channelTarget{}, false
+}
+
+// channelLinked reports whether the latest status or channel list says the
+// channel is linked, and false for known if neither mentions it
+func (a *App) channelLinked(id string) (linked, known bool) {
+	if a.openclawStatus != nil && a.openclawStatus.LinkChannel != nil {
+		if lc := a.openclawStatus.LinkChannel; cmp.Or(lc.ID, "whatsapp") == id {
+			return lc.Linked, true
+		}
+	}
+	if a.channelsList != nil {
+		for _, ch := range a.channelsList.Channels {
+			if ch.ID == id {
+				return ch.Linked || strings.EqualFold(ch.Status, "linked"), true
+			}
+		}
+	}
+	return false, false
+}
+
+// writableChannel returns the selected channel if channel actions can run
+// on it, flashing why not otherwise
+func (a *App) writableChannel() (channelTarget, bool) {
+	target, ok := a.selectedChannel()
+	if a.cliAdapter() == nil || !ok || a.channels.running != "" {
+		return channelTarget{}, false
+	}
+	if !a.canWrite("Controlling channels") {
+		return channelTarget{}, false
+	}
+	return target, true
+}
+
+// linkChannel pairs the selected channel if it is not linked, and asks to
+// relink it otherwise
+func (a *App) linkChannel() tea.Cmd {
+	target, ok := a.writableChannel()
+	if !ok {
+		return nil
+	}
+	if target.linked {
+		return a.confirmChannelAction("relink")
+	}
+	return a.confirmPairing(target)
+}
+
+// confirmChannelAction asks to confirm action on the selected channel
+func (a *App) confirmChannelAction(action string) tea.Cmd {
+	target, ok := a.writableChannel()
+	if !ok {
+		return nil
+	}
+	if action == "unlink" && !target.linked {
+		a.setFlash(target.label+" is not linked", true)
+		return nil
+	}
+	spec := channelActions[action]
+	return a.confirmWrite(writeRequest{
+		title:       spec.verb + " " + target.label,
+		prompt:      fmt.Sprintf("%s %s on %s?", spec.verb, target.label, a.cliAdapter().GetInstanceName()),
+		warning:     spec.warning,
+		destructive: spec.destructive,
+		preview: func(c *gateway.CLIAdapter) error {
+			_, err := c.ControlChannel(target.id, spec.command)
+			if action == "relink" {
+				err = c.PairChannel(context.Background(), target.id, func(gateway.PairingEvent) {})
+			}
+			return err
+		},
+		run: func() tea.Cmd { return a.runChannelAction(target, action) },
+	})
+}
+
+// runChannelAction runs the confirmed action, whose result is flashed
+func (a *App) runChannelAction(target channelTarget, action string) tea.Cmd {
+	a.channels.running = target.id
+	adapter := a.cliAdapter()
+	return func() tea.Msg {
+		_, err := adapter.ControlChannel(target.id, channelActions[action].command)
+		return ChannelActionMsg{Channel: target, Action: action, Error: err}
+	}
+}
+
+func (a *App) handleChannelAction(msg ChannelActionMsg) tea.Cmd {
+	a.channels.running = ""
+	// Failed actions may have changed the channel too
+	refresh := tea.Batch(a.fetchCLIStatus(), a.fetchCLIChannels())
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return refresh
+	}
+	if msg.Action == "relink" && (a.mode == ModeNormal || a.mode == ModeActionOutput) {
+		msg.Channel.linked = true // Until a status after the unlink says otherwise
+		return tea.Batch(refresh, a.startPairing(msg.Channel))
+	}
+	a.setFlash(fmt.Sprintf("%s %s", msg.Channel.label, channelActions[msg.Action].done), false)
+	return refresh
+}
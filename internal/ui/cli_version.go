@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// renderCLIVersionSection shows the instance's openclaw release on the
+// System tab, badged by whether it supports every feature lazyclaw uses
+func (a *App) renderCLIVersionSection(width int) []string {
+	cli := a.cliAdapter()
+	if cli == nil {
+		return nil
+	}
+	lines := []string{styles.HelpSection.Render("openclaw CLI")}
+	version := cli.CachedVersion()
+	if version == "" {
+		return append(lines, "  Version: "+styles.BadgeMuted.Render("UNKNOWN"), "")
+	}
+
+	missing := cli.Missing()
+	if len(missing) == 0 {
+		return append(lines, "  Version: "+styles.BadgeOK.Render("v"+version), "")
+	}
+	names := make([]string, len(missing))
+	for i, capability := range missing {
+		names[i] = capability.String()
+	}
+	lines = append(lines,
+		"  Version: "+styles.BadgeWarning.Render("v"+version),
+		"  Missing: "+truncate(strings.Join(names, ", "), width-11),
+		styles.Muted.Render("  Upgrade openclaw for these; lazyclaw falls back without them"),
+		"")
+	return lines
+}
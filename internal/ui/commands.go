@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/history"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// commandLogLimit is how many of the latest commands the viewer reads
+const commandLogLimit = 500
+
+// CommandLogMsg is sent when the current instance's command log is read
+type CommandLogMsg struct {
+	Instance string
+	Entries  []history.CommandEntry
+	Error    error
+}
+
+// commandLogView holds the command log overlay: the commands run against
+// the current instance, newest first
+type commandLogView struct {
+	instance string
+	entries  []history.CommandEntry
+	offset   int
+	loading  bool
+	err      string
+}
+
+// enableCommandLog makes the CLI adapters log the commands they run to the
+// history store
+func (a *App) enableCommandLog() {
+	store := a.history
+	if store == nil {
+		return
+	}
+	for _, adapter := range a.adapters {
+		if cli, ok := adapter.(*gateway.CLIAdapter); ok {
+			cli.OnCommand = func(rec gateway.CommandRecord) {
+				entry := history.CommandEntry{
+					Time:       rec.Start,
+					Instance:   rec.Instance,
+					Host:       rec.Host,
+					Command:    rec.Command,
+					DurationMs: rec.Duration.Milliseconds(),
+					ExitCode:   rec.ExitCode,
+				}
+				if rec.Err != nil {
+					entry.Error = rec.Err.Error()
+				}
+				_ = store.RecordCommand(rec.Instance, entry)
+			}
+		}
+	}
+}
+
+// openCommandLog shows the command log overlay and reads the log
+func (a *App) openCommandLog() tea.Cmd {
+	adapter := a.cliAdapter()
+	if adapter == nil {
+		a.setFlash("Command log is only kept for CLI instances", true)
+		return nil
+	}
+	if a.history == nil {
+		a.setFlash("Command log unavailable: history store could not be opened", true)
+		return nil
+	}
+	a.mode = ModeCommandLog
+	return a.readCommandLog(adapter.GetInstanceName())
+}
+
+// readCommandLog reads the instance's latest logged commands
+func (a *App) readCommandLog(instance string) tea.Cmd {
+	a.commandLog = commandLogView{instance: instance, loading: true}
+	store := a.history
+	return func() tea.Msg {
+		entries, err := store.Commands(instance, commandLogLimit)
+		return CommandLogMsg{Instance: instance, Entries: entries, Error: err}
+	}
+}
+
+func (a *App) handleCommandLog(msg CommandLogMsg) {
+	v := &a.commandLog
+	if msg.Instance != v.instance {
+		return
+	}
+	*v = commandLogView{instance: msg.Instance}
+	if msg.Error != nil {
+		v.err = msg.Error.Error()
+		return
+	}
+	v.entries = msg.Entries
+}
+
+// commandLogRows is how many entries fit in the overlay
+func (a *App) commandLogRows() int {
+	return max(a.height-12, 3)
+}
+
+// handleCommandLogKey handles keys while the command log overlay is open
+func (a *App) handleCommandLogKey(msg tea.KeyMsg) tea.Cmd {
+	v := &a.commandLog
+	last := max(len(v.entries)-a.commandLogRows(), 0)
+	switch {
+	case key.Matches(msg, a.keys.Escape) || key.Matches(msg, a.keys.CommandLog) || msg.String() == "q":
+		a.mode = ModeNormal
+	case key.Matches(msg, a.keys.Up):
+		v.offset = max(v.offset-1, 0)
+	case key.Matches(msg, a.keys.Down):
+		v.offset = min(v.offset+1, last)
+	case key.Matches(msg, a.keys.PageUp):
+		v.offset = max(v.offset-a.commandLogRows(), 0)
+	case key.Matches(msg, a.keys.PageDown):
+		v.offset = min(v.offset+a.commandLogRows(), last)
+	case key.Matches(msg, a.keys.Reconnect):
+		return a.readCommandLog(v.instance)
+	}
+	return nil
+}
+
+// renderCommandLog renders the command log overlay
+func (a *App) renderCommandLog() string {
+	v := &a.commandLog
+	content := styles.HelpTitle.Render("Command Log: "+v.instance) + "\n\n"
+	width := max(a.width-12, 40)
+
+	switch {
+	case v.loading:
+		content += styles.Muted.Render("Reading the command log...") + "\n"
+	case v.err != "":
+		content += styles.LogError.Render(truncate(v.err, width)) + "\n"
+	case len(v.entries) == 0:
+		content += styles.Muted.Render("No commands logged for this instance yet") + "\n"
+	default:
+		header := fmt.Sprintf("%-19s %8s %4s %-20s %s", "TIME", "TOOK", "EXIT", "HOST", "COMMAND")
+		content += styles.HelpSection.Render(header) + "\n"
+		end := min(v.offset+a.commandLogRows(), len(v.entries))
+		for _, e := range v.entries[v.offset:end] {
+			took := (time.Duration(e.DurationMs) * time.Millisecond).String()
+			line := fmt.Sprintf("%-19s %8s %4d %-20s %s", e.Time.Local().Format("2006-01-02 15:04:05"),
+				took, e.ExitCode, truncate(e.Host, 20), e.Command)
+			line = truncate(line, width)
+			if e.Failed() {
+				content += styles.LogError.Render(line) + "\n"
+			} else {
+				content += line + "\n"
+			}
+		}
+		content += "\n" + styles.Muted.Render(fmt.Sprintf("%d-%d of %d", v.offset+1, end, len(v.entries))) + "\n"
+	}
+
+	content += "\n" + styles.HintKey.Render("j/k") + styles.Muted.Render(":scroll  ") +
+		styles.HintKey.Render("r") + styles.Muted.Render(":reload  ") +
+		styles.HintKey.Render("esc") + styles.Muted.Render(":close")
+
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// ConnectingMsg is sent as an instance with no status yet, or whose last
+// fetch failed, starts fetching one
+type ConnectingMsg struct {
+	Instance string
+}
+
+// ConnectProgressMsg is sent as such a fetch reaches a further stage
+type ConnectProgressMsg struct {
+	Instance string
+	Stage    gateway.ConnectStage
+}
+
+// ConnectFailedMsg is sent when such a fetch fails
+type ConnectFailedMsg struct {
+	Instance string
+	Error    error
+}
+
+// enableConnectProgress makes the CLI adapters report their connect stages
+// and returns a command relaying them to the UI
+func (a *App) enableConnectProgress() tea.Cmd {
+	a.connects = make(chan gateway.ConnectProgress, 64)
+	a.connectStages = make(map[string]gateway.ConnectStage)
+	a.applyConnectProgress()
+	return waitForConnect(a.connects)
+}
+
+// applyConnectProgress makes the CLI adapters report their connect stages
+// on the channel made by enableConnectProgress
+func (a *App) applyConnectProgress() {
+	ch := a.connects
+	for _, adapter := range a.adapters {
+		if cli, ok := adapter.(*gateway.CLIAdapter); ok {
+			cli.OnConnect = func(progress gateway.ConnectProgress) {
+				// Only a redraw is needed; drop it if the UI is behind
+				select {
+				case ch <- progress:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// waitForConnect waits for the next connect stage on ch
+func waitForConnect(ch chan gateway.ConnectProgress) tea.Cmd {
+	return func() tea.Msg {
+		progress := <-ch
+		switch progress.Stage {
+		case gateway.ConnectStarting:
+			return ConnectingMsg{Instance: progress.Instance}
+		case gateway.ConnectFailed:
+			return ConnectFailedMsg{Instance: progress.Instance, Error: progress.Err}
+		}
+		return ConnectProgressMsg{Instance: progress.Instance, Stage: progress.Stage}
+	}
+}
+
+// handleConnecting records a connect that started, unless a batched fetch
+// of the same instance already reported a further stage
+func (a *App) handleConnecting(msg ConnectingMsg) tea.Cmd {
+	if _, busy := a.connectStage(a.adapterNamed(msg.Instance)); !busy {
+		a.connectStages[msg.Instance] = gateway.ConnectStarting
+	}
+	return waitForConnect(a.connects)
+}
+
+func (a *App) handleConnectProgress(msg ConnectProgressMsg) tea.Cmd {
+	a.connectStages[msg.Instance] = msg.Stage
+	return waitForConnect(a.connects)
+}
+
+func (a *App) handleConnectFailed(msg ConnectFailedMsg) tea.Cmd {
+	delete(a.connectStages, msg.Instance)
+	return waitForConnect(a.connects)
+}
+
+// connectStage returns the stage an instance's connect has reached, if one
+// is under way: the adapter has no status yet, or its last fetch failed
+func (a *App) connectStage(adapter gateway.Adapter) (gateway.ConnectStage, bool) {
+	cli, ok := adapter.(*gateway.CLIAdapter)
+	if !ok {
+		return 0, false
+	}
+	stage, ok := a.connectStages[cli.GetInstanceName()]
+	if !ok || (cli.GetCachedStatus() != nil && cli.GetLastError() == nil) {
+		return 0, false
+	}
+	return stage, true
+}
+
+// adapterNamed returns the adapter of the named instance, or nil
+func (a *App) adapterNamed(name string) gateway.Adapter {
+	for _, adapter := range a.adapters {
+		if adapter.GetInstanceName() == name {
+			return adapter
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// transportShare is the fraction of a fetch's latency above which the
+// Connection section blames reaching the instance rather than the gateway
+const transportShare = 0.5
+
+// renderConnectionSection renders the current instance's status fetch
+// metrics, separating the time spent reaching the instance from the time
+// the gateway takes to answer
+func (a *App) renderConnectionSection(width int) []string {
+	cli := a.cliAdapter()
+	if cli == nil {
+		return nil
+	}
+	m := cli.Metrics()
+	lines := []string{styles.HelpSection.Render("Connection")}
+	target := cli.Target()
+	if m.Via != "" {
+		target += " (" + m.Via + ")"
+	}
+	lines = append(lines, "  Target:       "+truncate(target, width-16))
+	if m.Window == 0 {
+		return append(lines, "  Fetches:      "+styles.Muted.Render("none yet"), "")
+	}
+
+	if m.P50 > 0 {
+		lines = append(lines, fmt.Sprintf("  Fetch:        p50 %s · p95 %s", formatLatency(m.P50), formatLatency(m.P95)))
+	}
+	if m.GatewayP50 > 0 {
+		lines = append(lines, fmt.Sprintf("  Gateway:      p50 %s · p95 %s ", formatLatency(m.GatewayP50), formatLatency(m.GatewayP95))+
+			styles.Muted.Render("(connect latency it reports)"))
+	}
+
+	errors := fmt.Sprintf("%.0f%% of the last %d (%d of %d in total)", m.ErrorRate*100, m.Window, m.Errors, m.Fetches)
+	switch {
+	case m.ErrorRate >= 0.5:
+		errors = styles.LogError.Render(errors)
+	case m.ErrorRate > 0:
+		errors = styles.LogWarn.Render(errors)
+	}
+	lines = append(lines, "  Errors:       "+errors)
+
+	if m.LastSuccess.IsZero() {
+		lines = append(lines, "  Last success: "+styles.LogError.Render("never"))
+	} else {
+		lines = append(lines, "  Last success: "+formatAge(time.Since(m.LastSuccess).Milliseconds())+" ago")
+	}
+
+	// Most of the fetch spent outside the gateway points at the transport
+	if m.P50 > 0 && m.GatewayP50 > 0 {
+		outside := "starting the CLI"
+		switch {
+		case m.Via == "socket":
+			outside = "the control socket"
+		case cli.IsRemote():
+			outside = "the connection and starting the CLI"
+		}
+		if float64(m.P50-m.GatewayP50) > transportShare*float64(m.P50) {
+			lines = append(lines, styles.Muted.Render("  Most of each fetch is spent in "+outside+", not the gateway"))
+		} else {
+			lines = append(lines, styles.Muted.Render("  Most of each fetch is spent in the gateway"))
+		}
+	}
+	return append(lines, "")
+}
+
+// formatLatency formats a latency to a readable precision
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return d.Round(10 * time.Millisecond).String()
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/state"
+)
+
+// maxCrashMessages is how many recent messages a crash report lists
+const maxCrashMessages = 20
+
+// crashLog remembers the most recent messages so a crash report can show
+// what led up to a panic. Commands run on their own goroutines, so it is
+// locked.
+type crashLog struct {
+	mu      sync.Mutex
+	msgs    []string
+	written bool
+	path    string
+	err     error
+}
+
+func (c *crashLog) record(msg tea.Msg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.msgs = append(c.msgs, fmt.Sprintf("%s %T", time.Now().Format("15:04:05.000"), msg))
+	if len(c.msgs) > maxCrashMessages {
+		c.msgs = c.msgs[1:]
+	}
+}
+
+// recoverPanic writes a crash report for a panic in progress, then lets it
+// continue so Bubble Tea restores the terminal and Run returns an error.
+// It must be deferred directly.
+func (a *App) recoverPanic() {
+	if r := recover(); r != nil {
+		a.writeCrashReport(r, debug.Stack())
+		panic(r)
+	}
+}
+
+// guardCmd wraps cmd, and any commands it batches, so panics inside them are
+// reported too and the performance overlay can count them while they run
+func (a *App) guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		a.perf.pending.Add(1)
+		defer a.perf.pending.Add(-1)
+		defer a.recoverPanic()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i := range batch {
+				batch[i] = a.guardCmd(batch[i])
+			}
+		}
+		return msg
+	}
+}
+
+// CrashReportPath returns where the crash report was saved, or the error
+// that prevented saving it. Both are empty if nothing panicked.
+func (a *App) CrashReportPath() (string, error) {
+	a.crash.mu.Lock()
+	defer a.crash.mu.Unlock()
+	return a.crash.path, a.crash.err
+}
+
+// writeCrashReport saves the first panic's report; later panics (such as the
+// re-panic unwinding through an outer guard) are ignored
+func (a *App) writeCrashReport(r any, stack []byte) {
+	c := &a.crash
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.written {
+		return
+	}
+	c.written = true
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "lazyclaw crash report\n\n")
+	fmt.Fprintf(&b, "Time:  %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Go:    %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Panic: %v\n\n", r)
+	fmt.Fprintf(&b, "Stack:\n%s\n", stack)
+
+	fmt.Fprintf(&b, "Recent messages (oldest first):\n")
+	for _, msg := range c.msgs {
+		fmt.Fprintf(&b, "  %s\n", msg)
+	}
+	b.WriteString("\n")
+
+	a.writeCrashConfigSummary(&b)
+
+	c.path, c.err = state.SaveCrashReport(b.String())
+}
+
+// writeCrashConfigSummary describes the setup without hosts, paths or
+// anything else that could identify or authenticate against an instance
+func (a *App) writeCrashConfigSummary(b *strings.Builder) {
+	cfg := a.config
+	fmt.Fprintf(b, "Config:\n")
+	fmt.Fprintf(b, "  instances: %d, templates: %d\n", len(cfg.Instances), len(cfg.Templates))
+	for _, inst := range cfg.Instances {
+		mode := string(inst.Mode)
+		if mode == "" {
+			mode = "local"
+		}
+		fmt.Fprintf(b, "    - %s (%s)\n", inst.Name, mode)
+	}
+	fmt.Fprintf(b, "  ui: refresh_ms=%d log_tail_lines=%d tab_refresh=%v unfocused_refresh=%q\n",
+		cfg.UI.RefreshMs, cfg.UI.LogTailLines, cfg.UI.TabRefresh, cfg.UI.UnfocusedRefresh)
+	fmt.Fprintf(b, "  security: allow_write_scopes=%t\n", cfg.Security.AllowWriteScopes)
+	fmt.Fprintf(b, "  locked=%t encrypted=%t max_concurrent_commands=%d max_recent_sessions=%d\n\n",
+		cfg.Locked, cfg.Encryption.Enabled(), cfg.MaxConcurrentCommands, cfg.MaxRecentSessions)
+
+	fmt.Fprintf(b, "UI state:\n")
+	fmt.Fprintf(b, "  tab=%s instance=%d/%d mode=%d mock=%t debug=%t\n",
+		a.activeTab, a.selectedInstance, len(a.adapters), a.mode, a.mockMode, a.debug)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// noteStatusTimeout records whether a status fetch failed by timing out and
+// reports if it did. A timeout leaves the last good status in place, marked
+// stale, rather than treating the gateway as down.
+func (a *App) noteStatusTimeout(err error) bool {
+	if !errors.Is(err, gateway.ErrTimeout) {
+		a.statusTimeout = nil
+		return false
+	}
+	a.statusTimeout = err
+	if a.openclawStatus == nil {
+		// Nothing to fall back on
+		a.connectionState.LastError = err.Error()
+	}
+	return true
+}
+
+// renderDegradedBanner describes the stale data shown after a status fetch
+// timed out, or returns "" if the status is current
+func (a *App) renderDegradedBanner(width int) string {
+	if a.statusTimeout == nil || a.openclawStatus == nil {
+		return ""
+	}
+	text := fmt.Sprintf(" DEGRADED: %s · showing data from %s ago ",
+		a.statusTimeout, formatAge(time.Since(a.lastStatusAt).Milliseconds()))
+	return styles.StatusDegraded.Render(truncate(text, width))
+}
+
+// renderPartialBanner lists the status fields skipped for holding values of
+// an unexpected type, or returns "" if the status decoded whole
+func (a *App) renderPartialBanner(width int) string {
+	if a.openclawStatus == nil || len(a.openclawStatus.ParseErrors) == 0 {
+		return ""
+	}
+	skipped := a.openclawStatus.ParseErrors
+	text := fmt.Sprintf(" PARTIAL PARSE: %d status field(s) skipped · %s ", len(skipped), strings.Join(skipped, "; "))
+	return styles.StatusDegraded.Render(truncate(text, width))
+}
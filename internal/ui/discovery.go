@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/config"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// discoveryProbeTimeout bounds each SSH probe of a tailnet peer
+const discoveryProbeTimeout = 10 * time.Second
+
+// DiscoveryMsg is sent when a tailnet scan for openclaw hosts finishes
+type DiscoveryMsg struct {
+	Peers []gateway.TailscalePeer
+	Error error
+}
+
+// discoveryView holds the discovery overlay: the tailnet peers found running
+// openclaw that are not configured yet
+type discoveryView struct {
+	scanning   bool
+	candidates []gateway.TailscalePeer
+	offline    int // Peers not probed because they are offline
+	without    int // Online peers where no openclaw was found
+	configured int // Peers running openclaw that are already instances
+	cursor     int
+	err        string
+}
+
+// openDiscovery shows the discovery overlay and scans the tailnet
+func (a *App) openDiscovery() tea.Cmd {
+	if !a.config.Discovery.Tailscale.Enabled {
+		a.setFlash("Tailscale discovery is off; set discovery.tailscale.enabled in config.yml", true)
+		return nil
+	}
+	if !gateway.CheckTailscaleAvailable() {
+		a.setFlash("tailscale not found in PATH", true)
+		return nil
+	}
+	a.mode = ModeDiscovery
+	return a.scanTailnet()
+}
+
+// scanTailnet lists the tailnet's peers and probes them for openclaw
+func (a *App) scanTailnet() tea.Cmd {
+	if a.discovery.scanning {
+		return nil
+	}
+	a.discovery = discoveryView{scanning: true}
+	opts := gateway.TailscaleDiscovery{
+		Tag:     a.config.Discovery.Tailscale.Tag,
+		User:    a.config.Discovery.Tailscale.User,
+		Timeout: discoveryProbeTimeout,
+	}
+	opts.KnownHostsFile, _ = config.KnownHostsPath()
+	return func() tea.Msg {
+		peers, err := gateway.DiscoverTailscale(opts)
+		return DiscoveryMsg{Peers: peers, Error: err}
+	}
+}
+
+func (a *App) handleDiscovery(msg DiscoveryMsg) {
+	d := &a.discovery
+	*d = discoveryView{}
+	if msg.Error != nil {
+		d.err = msg.Error.Error()
+		return
+	}
+	for _, peer := range msg.Peers {
+		switch {
+		case !peer.Online:
+			d.offline++
+		case peer.Version == "":
+			d.without++
+		case a.peerConfigured(peer):
+			d.configured++
+		default:
+			d.candidates = append(d.candidates, peer)
+		}
+	}
+}
+
+// peerConfigured reports whether an SSH instance already points at peer
+func (a *App) peerConfigured(peer gateway.TailscalePeer) bool {
+	for _, inst := range a.config.ResolvedInstances() {
+		if inst.SSH == nil {
+			continue
+		}
+		host := inst.SSH.Host
+		if _, after, ok := strings.Cut(host, "@"); ok {
+			host = after
+		}
+		if host == peer.Host || host == peer.IP || host == peer.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// handleDiscoveryKey handles keys while the discovery overlay is open
+func (a *App) handleDiscoveryKey(msg tea.KeyMsg) tea.Cmd {
+	d := &a.discovery
+	switch {
+	case key.Matches(msg, a.keys.Escape) || msg.String() == "q":
+		a.mode = ModeNormal
+	case key.Matches(msg, a.keys.Up):
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case key.Matches(msg, a.keys.Down):
+		if d.cursor < len(d.candidates)-1 {
+			d.cursor++
+		}
+	case key.Matches(msg, a.keys.Reconnect):
+		return a.scanTailnet()
+	case key.Matches(msg, a.keys.Enter):
+		return a.addDiscoveredInstance()
+	}
+	return nil
+}
+
+// addDiscoveredInstance adds the selected peer to the config as an SSH
+// instance, saves it, and switches to it
+func (a *App) addDiscoveredInstance() tea.Cmd {
+	d := &a.discovery
+	if d.scanning || d.cursor >= len(d.candidates) {
+		return nil
+	}
+	peer := d.candidates[d.cursor]
+	settings := a.config.Discovery.Tailscale
+	inst := models.InstanceProfile{
+		Name:     a.uniqueInstanceName(peer.Name),
+		Tags:     []string{"tailscale"},
+		Template: settings.Template,
+		Mode:     models.ConnectionModeSSH,
+		SSH:      &models.SSHConfig{Host: peer.Host, User: settings.User},
+	}
+
+	// Without instances the adapters hold a stand-in local one
+	wasEmpty := len(a.config.Instances) == 0
+	a.config.AddInstance(inst)
+	if err := config.Save(a.config); err != nil {
+		a.config.Instances = a.config.Instances[:len(a.config.Instances)-1]
+		if errors.Is(err, config.ErrConfigLocked) {
+			a.setFlash("Not added: "+err.Error(), true)
+		} else {
+			a.setFlash("Saving config failed: "+err.Error(), true)
+		}
+		return nil
+	}
+
+	adapter := a.newInstanceAdapter(a.config.ResolveInstance(inst))
+	if wasEmpty {
+		a.adapters = nil
+	}
+	a.adapters = append(a.adapters, adapter)
+	a.enableBreakers()
+	a.applyRetryPolicy()
+	a.enableCommandLog()
+	a.applyConnectProgress()
+
+	d.candidates = append(d.candidates[:d.cursor], d.candidates[d.cursor+1:]...)
+	d.configured++
+	d.cursor = max(min(d.cursor, len(d.candidates)-1), 0)
+	a.mode = ModeNormal
+	a.setFlash(fmt.Sprintf("Added instance %s (%s)", inst.Name, peer.Host), false)
+
+	var cmds []tea.Cmd
+	a.selectedInstance = len(a.adapters) - 1
+	a.switchInstance(&cmds)
+	return tea.Batch(cmds...)
+}
+
+// uniqueInstanceName returns name, suffixed if an instance already has it
+func (a *App) uniqueInstanceName(name string) string {
+	unique := name
+	for i := 2; a.config.GetInstance(unique) != nil; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	return unique
+}
+
+// renderDiscovery renders the discovery overlay
+func (a *App) renderDiscovery() string {
+	d := &a.discovery
+	content := styles.HelpTitle.Render("Tailscale Discovery") + "\n\n"
+
+	switch {
+	case d.scanning:
+		content += styles.Muted.Render("Scanning the tailnet for openclaw...") + "\n"
+	case d.err != "":
+		content += styles.LogError.Render(truncate(d.err, a.width-12)) + "\n"
+	case len(d.candidates) == 0:
+		content += styles.Muted.Render("No new openclaw hosts found") + "\n"
+	default:
+		content += styles.HelpSection.Render("openclaw hosts not yet configured") + "\n"
+		for i, peer := range d.candidates {
+			line := fmt.Sprintf("%-20s %-36s %-8s %s", truncate(peer.Name, 20), truncate(peer.Host, 36), peer.OS, peer.Version)
+			if i == d.cursor {
+				content += styles.SelectedItem.Render("> "+line) + "\n"
+			} else {
+				content += styles.UnselectedItem.Render("  "+line) + "\n"
+			}
+		}
+	}
+
+	if !d.scanning && d.err == "" {
+		content += "\n" + styles.Muted.Render(fmt.Sprintf("Skipped: %d already configured, %d without openclaw, %d offline",
+			d.configured, d.without, d.offline)) + "\n"
+	}
+	content += "\n" + styles.HintKey.Render("enter") + styles.Muted.Render(":add to config  ") +
+		styles.HintKey.Render("r") + styles.Muted.Render(":rescan  ") +
+		styles.HintKey.Render("esc") + styles.Muted.Render(":close")
+
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// DoctorMsg is sent when an on-demand doctor run returns
+type DoctorMsg struct {
+	Instance string
+	Report   *models.DoctorReport
+	Error    error
+}
+
+// doctorView holds the doctor results overlay: the checks of the latest
+// on-demand `openclaw doctor` run on an instance
+type doctorView struct {
+	instance string
+	report   *models.DoctorReport
+	ranAt    time.Time
+	running  bool
+	err      string
+	offset   int // First line shown
+}
+
+// openDoctor shows the doctor results overlay and runs doctor on the
+// selected instance
+func (a *App) openDoctor() tea.Cmd {
+	if a.cliAdapter() == nil {
+		a.setFlash("Doctor can only run on CLI instances", true)
+		return nil
+	}
+	a.mode = ModeDoctor
+	return a.runDoctor()
+}
+
+// runDoctor runs doctor on the selected instance, unless a run is going
+func (a *App) runDoctor() tea.Cmd {
+	v := &a.doctor
+	adapter := a.cliAdapter()
+	if v.running || adapter == nil {
+		return nil
+	}
+	instance := adapter.GetInstanceName()
+	*v = doctorView{instance: instance, running: true}
+	return func() tea.Msg {
+		report, err := adapter.RunDoctor()
+		return DoctorMsg{Instance: instance, Report: report, Error: err}
+	}
+}
+
+func (a *App) handleDoctor(msg DoctorMsg) {
+	v := &a.doctor
+	if msg.Instance != v.instance {
+		return
+	}
+	v.running = false
+	v.ranAt = time.Now()
+	if msg.Error != nil {
+		v.err = msg.Error.Error()
+		return
+	}
+	v.report = msg.Report
+}
+
+// doctorLines renders the checks, a line each plus their messages
+func (a *App) doctorLines(width int) []string {
+	var lines []string
+	for _, check := range a.doctor.report.Checks {
+		lines = append(lines, doctorBadge(check.Status)+" "+truncate(check.Check, width-8))
+		for _, line := range strings.Split(check.Message, "\n") {
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, "       "+styles.Muted.Render(truncate(line, width-8)))
+			}
+		}
+	}
+	return lines
+}
+
+// doctorBadge renders a check status as a fixed-width badge
+func doctorBadge(status string) string {
+	switch strings.ToLower(status) {
+	case "pass", "ok":
+		return "[" + styles.StatusOK.Render("PASS") + "]"
+	case "warn", "warning":
+		return "[" + styles.StatusDegraded.Render("WARN") + "]"
+	case "fail", "error":
+		return "[" + styles.StatusDown.Render("FAIL") + "]"
+	}
+	return "[" + styles.Muted.Render(strings.ToUpper(status)) + "]"
+}
+
+// doctorSummary counts the checks by status
+func doctorSummary(checks []models.HealthDoctorItem) string {
+	var pass, warn, fail int
+	for _, check := range checks {
+		switch strings.ToLower(check.Status) {
+		case "pass", "ok":
+			pass++
+		case "warn", "warning":
+			warn++
+		case "fail", "error":
+			fail++
+		}
+	}
+	summary := styles.StatusOK.Render(fmt.Sprintf("%d passed", pass))
+	if warn > 0 {
+		summary += ", " + styles.StatusDegraded.Render(fmt.Sprintf("%d warnings", warn))
+	}
+	if fail > 0 {
+		summary += ", " + styles.StatusDown.Render(fmt.Sprintf("%d failed", fail))
+	}
+	return summary
+}
+
+// doctorRows is how many lines of checks fit in the overlay
+func (a *App) doctorRows() int {
+	return max(a.height-14, 3)
+}
+
+// handleDoctorKey handles keys while the doctor results overlay is open
+func (a *App) handleDoctorKey(msg tea.KeyMsg) tea.Cmd {
+	v := &a.doctor
+	last := 0
+	if v.report != nil {
+		last = max(len(a.doctorLines(max(a.width-12, 40)))-a.doctorRows(), 0)
+	}
+	switch {
+	case key.Matches(msg, a.keys.Escape) || key.Matches(msg, a.keys.Doctor) || msg.String() == "q":
+		a.mode = ModeNormal
+	case key.Matches(msg, a.keys.Up):
+		v.offset = max(v.offset-1, 0)
+	case key.Matches(msg, a.keys.Down):
+		v.offset = min(v.offset+1, last)
+	case key.Matches(msg, a.keys.PageUp):
+		v.offset = max(v.offset-a.doctorRows(), 0)
+	case key.Matches(msg, a.keys.PageDown):
+		v.offset = min(v.offset+a.doctorRows(), last)
+	case key.Matches(msg, a.keys.Reconnect):
+		return a.runDoctor()
+	}
+	return nil
+}
+
+// renderDoctor renders the doctor results overlay
+func (a *App) renderDoctor() string {
+	v := &a.doctor
+	content := styles.HelpTitle.Render("Doctor: "+v.instance) + "\n\n"
+	width := max(a.width-12, 40)
+
+	switch {
+	case v.running:
+		content += styles.Muted.Render("Running openclaw doctor...") + "\n"
+	case v.err != "":
+		content += styles.LogError.Render(truncate(v.err, width)) + "\n"
+	case v.report == nil || len(v.report.Checks) == 0:
+		content += styles.Muted.Render("Doctor reported no checks") + "\n"
+	default:
+		content += doctorSummary(v.report.Checks) + styles.Muted.Render(", ran at "+v.ranAt.Format("15:04:05")) + "\n\n"
+		lines := a.doctorLines(width)
+		end := min(v.offset+a.doctorRows(), len(lines))
+		content += strings.Join(lines[v.offset:end], "\n") + "\n"
+		if len(lines) > a.doctorRows() {
+			content += "\n" + styles.Muted.Render(fmt.Sprintf("%d-%d of %d lines", v.offset+1, end, len(lines))) + "\n"
+		}
+	}
+
+	content += "\n" + styles.HintKey.Render("j/k") + styles.Muted.Render(":scroll  ") +
+		styles.HintKey.Render("r") + styles.Muted.Render(":run again  ") +
+		styles.HintKey.Render("esc") + styles.Muted.Render(":close")
+
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
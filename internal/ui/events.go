@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// maxGatewayEvents caps the Events tab buffer, independently of the log tail
+const maxGatewayEvents = 1000
+
+// GatewayEventMsg carries one event from the gateway event stream
+type GatewayEventMsg struct {
+	Event models.GatewayEvent
+}
+
+// EventStreamClosedMsg is sent when the gateway event stream ends
+type EventStreamClosedMsg struct {
+	Error error
+}
+
+// eventStream holds the gateway event subscription and its buffer
+type eventStream struct {
+	ch       chan models.GatewayEvent
+	ctx      context.Context
+	cancel   context.CancelFunc
+	active   bool   // Subscription is running
+	received bool   // At least one event arrived, so the gateway supports the stream
+	closed   string // Why the stream ended, if it did
+	events   []models.GatewayEvent
+	severity string // Minimum severity shown: "", "warn" or "error"
+}
+
+// live returns true if the Events tab should show stream events rather than
+// events derived from the log tail
+func (e *eventStream) live() bool {
+	return e.received || (e.active && e.closed == "")
+}
+
+// startEventStream subscribes to the current instance's gateway events
+func (a *App) startEventStream() tea.Cmd {
+	a.stopEventStream()
+	adapter := a.cliAdapter()
+	if adapter == nil {
+		return nil
+	}
+
+	e := &a.events
+	e.ch = make(chan models.GatewayEvent, 100)
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+	e.active = true
+
+	ctx, ch := e.ctx, e.ch
+	return func() tea.Msg {
+		if err := adapter.FollowEvents(ctx, ch); err != nil {
+			return EventStreamClosedMsg{Error: err}
+		}
+		return waitForGatewayEvent(ctx, ch)()
+	}
+}
+
+// waitForGatewayEvent waits for the next event on ch
+func waitForGatewayEvent(ctx context.Context, ch chan models.GatewayEvent) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return EventStreamClosedMsg{}
+			}
+			return GatewayEventMsg{Event: event}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// stopEventStream cancels the current subscription, keeping the severity filter
+func (a *App) stopEventStream() {
+	if a.events.cancel != nil {
+		a.events.cancel()
+	}
+	a.events = eventStream{severity: a.events.severity}
+}
+
+func (a *App) handleGatewayEvent(msg GatewayEventMsg) tea.Cmd {
+	e := &a.events
+	if !e.active {
+		return nil
+	}
+	e.received = true
+	e.events = append(e.events, msg.Event)
+	if len(e.events) > maxGatewayEvents {
+		e.events = e.events[len(e.events)-maxGatewayEvents:]
+	}
+	return waitForGatewayEvent(e.ctx, e.ch)
+}
+
+func (a *App) handleEventStreamClosed(msg EventStreamClosedMsg) {
+	e := &a.events
+	if !e.active {
+		return
+	}
+	e.active = false
+	switch {
+	case msg.Error != nil:
+		e.closed = fmt.Sprintf("Gateway event stream unavailable (%v).", msg.Error)
+	case e.received:
+		e.closed = "Event stream ended. Press r to reconnect."
+	default:
+		e.closed = "Gateway event stream unavailable."
+	}
+}
+
+// cycleEventSeverity cycles the Events tab minimum severity
+func (a *App) cycleEventSeverity() {
+	a.events.severity = nextOption(a.events.severity, []string{"warn", "error"})
+}
+
+// eventSeverityRank orders event severities for filtering
+func eventSeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "error", "critical", "fatal":
+		return 2
+	case "warn", "warning":
+		return 1
+	}
+	return 0
+}
+
+// filteredGatewayEvents applies the severity filter and the / search filter,
+// which matches the type, source, channel, session and message
+func (a *App) filteredGatewayEvents() []models.GatewayEvent {
+	minRank := eventSeverityRank(a.events.severity)
+	filter := strings.ToLower(a.searchInput.Value())
+	var events []models.GatewayEvent
+	for _, ev := range a.events.events {
+		if eventSeverityRank(ev.Severity) < minRank {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(ev.Type+" "+ev.Source+" "+ev.Channel+" "+ev.Session+" "+ev.Message), filter) {
+			continue
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// renderGatewayEvents renders the live event stream
+func (a *App) renderGatewayEvents(width, height int) string {
+	e := &a.events
+	var lines []string
+
+	state := styles.StatusOK.Render("live")
+	if !e.active {
+		state = styles.Muted.Render("ended")
+	}
+	severity := "all"
+	if e.severity != "" {
+		severity = e.severity + "+"
+	}
+	header := styles.HelpSection.Render("System Events") + "  " + state + "  " +
+		styles.Muted.Render("severity=") + styles.LabelValueHighlight.Render(severity) + "  " +
+		styles.HintKey.Render("v") + styles.Muted.Render(":severity ") +
+		styles.HintKey.Render("/") + styles.Muted.Render(":filter")
+	lines = append(lines, header)
+	lines = append(lines, fmt.Sprintf("  %s gateway events (last %d kept)",
+		styles.LabelValueHighlight.Render(fmt.Sprintf("%d", len(e.events))), maxGatewayEvents))
+	if filter := a.searchInput.Value(); filter != "" {
+		lines = append(lines, "  "+styles.Muted.Render("filter: ")+styles.LabelValueHighlight.Render(filter))
+	}
+	if e.closed != "" {
+		lines = append(lines, "  "+styles.LogWarn.Render(e.closed))
+	}
+	lines = append(lines, "")
+
+	events := a.filteredGatewayEvents()
+	if len(events) == 0 {
+		if len(e.events) == 0 {
+			lines = append(lines, styles.Muted.Render("  Waiting for gateway events..."))
+		} else {
+			lines = append(lines, styles.Muted.Render("  No events match the current filters."))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	// Most recent events at the bottom
+	maxVisible := max(height-len(lines)-2, 1)
+	if len(events) > maxVisible {
+		events = events[len(events)-maxVisible:]
+	}
+	typeWidth := 18
+	for _, ev := range events {
+		var icon string
+		var msgStyle lipgloss.Style
+		switch eventSeverityRank(ev.Severity) {
+		case 2:
+			icon, msgStyle = styles.StatusDown.Render("✖"), styles.LogError
+		case 1:
+			icon, msgStyle = styles.StatusDegraded.Render("▲"), styles.LogWarn
+		default:
+			icon, msgStyle = styles.StatusOK.Render("●"), styles.LogInfo
+		}
+		ts := time.Now()
+		if ev.Timestamp > 0 {
+			ts = time.UnixMilli(ev.Timestamp)
+		}
+		message := ev.Message
+		var about []string
+		for _, field := range []string{ev.Channel, ev.Session, ev.Source} {
+			if field != "" {
+				about = append(about, field)
+			}
+		}
+		if len(about) > 0 {
+			message += " " + styles.Muted.Render("("+strings.Join(about, " · ")+")")
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %-*s %s",
+			styles.Muted.Render(ts.Format("15:04:05")),
+			icon,
+			typeWidth, truncate(ev.Type, typeWidth),
+			msgStyle.Render(truncate(message, max(width-typeWidth-16, 10)))))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
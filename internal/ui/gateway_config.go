@@ -0,0 +1,330 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// GatewayConfigMsg is sent when a gateway config fetch completes
+type GatewayConfigMsg struct {
+	Config map[string]interface{}
+	Error  error
+}
+
+// GatewayConfigSetMsg is sent when a gateway config edit has been applied
+type GatewayConfigSetMsg struct {
+	Key   string
+	Error error
+}
+
+func (m GatewayConfigSetMsg) failure() error { return m.Error }
+
+// configEntry is a single flattened gateway config value
+type configEntry struct {
+	Key   string // Dotted path, e.g. "agents.defaults.model"
+	Value string
+}
+
+// Group returns the top-level section of the key
+func (e configEntry) Group() string {
+	group, _, _ := strings.Cut(e.Key, ".")
+	return group
+}
+
+// gatewayConfigView holds the Config tab state
+type gatewayConfigView struct {
+	entries  []configEntry
+	loaded   bool
+	loading  bool
+	err      string
+	cursor   int // Index into the filtered entries
+	filter   textinput.Model
+	edit     textinput.Model
+	editKey  string
+	applying bool
+}
+
+func newGatewayConfigView() gatewayConfigView {
+	filter := textinput.New()
+	filter.Placeholder = "Filter keys and values..."
+	filter.CharLimit = 100
+
+	edit := textinput.New()
+	edit.CharLimit = 500
+
+	return gatewayConfigView{filter: filter, edit: edit}
+}
+
+func (a *App) fetchGatewayConfig() tea.Cmd {
+	if a.cliAdapter() == nil {
+		return nil
+	}
+	a.gatewayConfig.loading = true
+	adapter := a.cliAdapter()
+	return a.scoped(func() tea.Msg {
+		cfg, err := adapter.GetGatewayConfig()
+		return GatewayConfigMsg{Config: cfg, Error: err}
+	})
+}
+
+func (a *App) handleGatewayConfig(msg GatewayConfigMsg) {
+	v := &a.gatewayConfig
+	v.loading = false
+	if msg.Error != nil {
+		v.err = msg.Error.Error()
+		return
+	}
+	v.err = ""
+	v.loaded = true
+	v.entries = flattenConfig("", msg.Config, nil)
+	sort.Slice(v.entries, func(i, j int) bool { return v.entries[i].Key < v.entries[j].Key })
+	if v.cursor >= len(v.entries) {
+		v.cursor = max(len(v.entries)-1, 0)
+	}
+}
+
+// flattenConfig flattens nested JSON objects into dotted keys. Arrays are kept
+// as single JSON values.
+func flattenConfig(prefix string, value interface{}, entries []configEntry) []configEntry {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return append(entries, configEntry{Key: prefix, Value: formatConfigValue(value)})
+	}
+	if len(obj) == 0 && prefix != "" {
+		return append(entries, configEntry{Key: prefix, Value: "{}"})
+	}
+	for k, child := range obj {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		entries = flattenConfig(key, child, entries)
+	}
+	return entries
+}
+
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// isSecretKey returns true for keys whose values should not be displayed
+func isSecretKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, marker := range []string{"token", "secret", "password", "passwd", "apikey", "api_key", "privatekey", "credential"} {
+		if strings.Contains(k, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// filteredConfigEntries returns entries matching the Config tab filter
+func (a *App) filteredConfigEntries() []configEntry {
+	v := &a.gatewayConfig
+	filter := strings.ToLower(strings.TrimSpace(v.filter.Value()))
+	if filter == "" {
+		return v.entries
+	}
+	var filtered []configEntry
+	for _, e := range v.entries {
+		value := e.Value
+		if isSecretKey(e.Key) {
+			value = ""
+		}
+		if strings.Contains(strings.ToLower(e.Key), filter) || strings.Contains(strings.ToLower(value), filter) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// moveConfigCursor moves the Config tab selection by delta
+func (a *App) moveConfigCursor(delta int) {
+	v := &a.gatewayConfig
+	v.cursor += delta
+	if n := len(a.filteredConfigEntries()); v.cursor >= n {
+		v.cursor = n - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+// startConfigEdit opens the editor for the selected key if it is whitelisted
+func (a *App) startConfigEdit() tea.Cmd {
+	v := &a.gatewayConfig
+	entries := a.filteredConfigEntries()
+	if v.applying || v.cursor >= len(entries) {
+		return nil
+	}
+	entry := entries[v.cursor]
+	if !a.canWrite("Editing") {
+		return nil
+	}
+	if !a.config.Security.GatewayConfigKeyEditable(entry.Key) {
+		a.setFlash(entry.Key+" is not in security.gateway_config_editable", true)
+		return nil
+	}
+
+	v.editKey = entry.Key
+	v.edit.Reset()
+	if !isSecretKey(entry.Key) {
+		v.edit.SetValue(entry.Value)
+	}
+	v.edit.Focus()
+	a.mode = ModeConfigEdit
+	return textinput.Blink
+}
+
+// applyConfigEdit asks to confirm running `openclaw config set` for the key
+// being edited. Secret values are masked in the confirmation.
+func (a *App) applyConfigEdit() tea.Cmd {
+	v := &a.gatewayConfig
+	a.mode = ModeNormal
+	v.edit.Blur()
+	key, value := v.editKey, v.edit.Value()
+	v.editKey = ""
+	if a.cliAdapter() == nil {
+		return nil
+	}
+	shown := value
+	if isSecretKey(key) {
+		shown = strings.Repeat("*", 8)
+	}
+	return a.confirmWrite(writeRequest{
+		title:   "Set Gateway Config",
+		prompt:  fmt.Sprintf("Set %s on %s?", key, a.cliAdapter().GetInstanceName()),
+		preview: func(c *gateway.CLIAdapter) error { return c.SetGatewayConfig(key, shown) },
+		run:     func() tea.Cmd { return a.setConfigValue(key, value) },
+	})
+}
+
+// setConfigValue runs `openclaw config set` for the confirmed edit
+func (a *App) setConfigValue(key, value string) tea.Cmd {
+	a.gatewayConfig.applying = true
+	return func() tea.Msg {
+		adapter := a.cliAdapter()
+		if adapter == nil {
+			return GatewayConfigSetMsg{Key: key, Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		return GatewayConfigSetMsg{Key: key, Error: adapter.SetGatewayConfig(key, value)}
+	}
+}
+
+func (a *App) handleGatewayConfigSet(msg GatewayConfigSetMsg) tea.Cmd {
+	a.gatewayConfig.applying = false
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	a.setFlash("Applied "+msg.Key, false)
+	return a.fetchGatewayConfig()
+}
+
+func (a *App) renderGatewayConfigTab(width, height int) string {
+	v := &a.gatewayConfig
+	var lines []string
+
+	header := styles.HelpSection.Render("Gateway Config")
+	if v.loaded {
+		header += "  " + styles.Muted.Render(fmt.Sprintf("%d keys", len(v.entries)))
+	}
+	header += "  " + styles.HintKey.Render("/") + styles.Muted.Render(":filter")
+	if a.writesAllowed() && len(a.config.Security.GatewayConfigEditable) > 0 {
+		header += "  " + styles.HintKey.Render("enter") + styles.Muted.Render(":edit ✎ keys")
+	}
+	lines = append(lines, header)
+	if filter := v.filter.Value(); filter != "" {
+		lines = append(lines, "  "+styles.Muted.Render("filter: ")+styles.LabelValueHighlight.Render(filter))
+	}
+	lines = append(lines, "")
+
+	if v.err != "" {
+		lines = append(lines, "  "+styles.LogError.Render(v.err))
+		lines = append(lines, "")
+	}
+	if !v.loaded {
+		if v.loading {
+			lines = append(lines, styles.Muted.Render("  Loading..."))
+		} else if v.err == "" {
+			lines = append(lines, styles.Muted.Render("  No gateway config loaded"))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	entries := a.filteredConfigEntries()
+	if len(entries) == 0 {
+		lines = append(lines, styles.Muted.Render("  No matching keys"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	// Build grouped rows, remembering where the cursor lands
+	var rows []string
+	cursorRow := 0
+	keyWidth := min(max(width/2, 20), 50)
+	group := ""
+	for i, e := range entries {
+		if g := e.Group(); g != group {
+			group = g
+			rows = append(rows, styles.CardTitle.Render(g))
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(e.Key, group), ".")
+		if name == "" {
+			name = group
+		}
+		marker := " "
+		if a.config.Security.GatewayConfigKeyEditable(e.Key) {
+			marker = "✎"
+		}
+		value := e.Value
+		if isSecretKey(e.Key) {
+			value = "••••••"
+		}
+		row := fmt.Sprintf("  %s %-*s %s", marker, keyWidth, truncate(name, keyWidth), truncate(value, max(width-keyWidth-6, 10)))
+		if i == v.cursor {
+			cursorRow = len(rows)
+			row = styles.TableRowSelected.Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	// Keep the cursor in view
+	maxVisible := max(height-len(lines)-2, 1)
+	start := 0
+	if cursorRow >= maxVisible {
+		start = cursorRow - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(rows))
+	lines = append(lines, rows[start:end]...)
+	if end < len(rows) {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  ... %d more", len(rows)-end)))
+	}
+
+	if v.applying {
+		lines = append(lines, styles.Muted.Render("  Applying..."))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/history"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// HealthProbeMsg is sent when a manually triggered health probe completes
+type HealthProbeMsg struct {
+	Result     *models.HealthCheckResult
+	Components map[string]history.ComponentState
+	Duration   time.Duration // Wall-clock time of the probe, as seen by lazyclaw
+	Error      error
+}
+
+// healthProbe tracks manual health probes and status changes between probes
+type healthProbe struct {
+	running     bool
+	startedAt   time.Time
+	spinner     spinner.Model
+	duration    time.Duration
+	completedAt time.Time
+	err         string
+
+	// Components whose status changed in the latest result, keyed by
+	// healthComponentStatuses key, with the previous status as value
+	changed map[string]string
+}
+
+// startHealthProbe runs `openclaw health --json` now, outside the refresh cycle
+func (a *App) startHealthProbe() tea.Cmd {
+	p := &a.probe
+	if p.running || a.cliAdapter() == nil {
+		return nil
+	}
+
+	p.running = true
+	p.startedAt = time.Now()
+	p.err = ""
+	p.spinner = spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(styles.Primary))
+	return tea.Batch(p.spinner.Tick, func() tea.Msg {
+		adapter := a.cliAdapter()
+		if adapter == nil {
+			return HealthProbeMsg{Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		start := time.Now()
+		result, err := adapter.GetHealthSnapshot()
+		if err != nil {
+			return HealthProbeMsg{Duration: time.Since(start), Error: err}
+		}
+		return HealthProbeMsg{
+			Result:     result,
+			Components: a.recordHealth(adapter.GetInstanceName(), result),
+			Duration:   time.Since(start),
+		}
+	})
+}
+
+func (a *App) handleHealthProbe(msg HealthProbeMsg) {
+	p := &a.probe
+	p.running = false
+	p.duration = msg.Duration
+	p.completedAt = time.Now()
+	if msg.Error != nil {
+		p.err = msg.Error.Error()
+		return
+	}
+	a.setHealthResult(msg.Result, msg.Components)
+}
+
+// recordHealth persists component state transitions to the history store and
+// returns how long each component has held its status
+func (a *App) recordHealth(instance string, result *models.HealthCheckResult) map[string]history.ComponentState {
+	if result == nil {
+		return nil
+	}
+	states, _ := a.history.RecordHealth(instance, healthComponentStatuses(result))
+	return states
+}
+
+// setHealthResult stores a health result, recording which components
+// changed status since the previous one
+func (a *App) setHealthResult(result *models.HealthCheckResult, components map[string]history.ComponentState) {
+	a.healthComponents = components
+	if a.healthCheckResult != nil && result != nil {
+		before := healthComponentStatuses(a.healthCheckResult)
+		changed := make(map[string]string)
+		for key, status := range healthComponentStatuses(result) {
+			if prev, ok := before[key]; ok && prev != status {
+				changed[key] = prev
+			}
+		}
+		a.probe.changed = changed
+	}
+	a.healthCheckResult = result
+}
+
+// healthComponentStatuses flattens a health result into component key -> status
+func healthComponentStatuses(result *models.HealthCheckResult) map[string]string {
+	statuses := map[string]string{"overall": strings.ToLower(result.Overall)}
+	if result.Gateway != nil {
+		statuses["gateway"] = "unreachable"
+		if result.Gateway.Reachable {
+			statuses["gateway"] = "reachable"
+		}
+	}
+	for _, ch := range result.Channels {
+		statuses["channel:"+ch.ID] = strings.ToLower(ch.Status)
+	}
+	for _, svc := range result.Services {
+		statuses["service:"+svc.Name] = strings.ToLower(svc.Status)
+	}
+	for _, item := range result.Doctor {
+		statuses["doctor:"+item.Check] = strings.ToLower(item.Status)
+	}
+	return statuses
+}
+
+// healthChangeMarker returns how long a component has held its status, with
+// a "changed" badge if it changed since the previous probe
+func (a *App) healthChangeMarker(key string) string {
+	marker := ""
+	if state, ok := a.healthComponents[key]; ok {
+		marker = " " + a.renderHealthAge(state)
+	}
+	if prev, ok := a.probe.changed[key]; ok {
+		marker += " " + styles.BadgeWarning.Render("CHANGED") + " " + styles.Muted.Render("was "+prev)
+	}
+	return marker
+}
+
+// chronicFailureAge is how long a component must be failing to count as chronic
+const chronicFailureAge = 24 * time.Hour
+
+// renderHealthAge renders how long a component has been in its current state.
+// Failures older than chronicFailureAge are flagged as chronic.
+func (a *App) renderHealthAge(state history.ComponentState) string {
+	age := time.Since(state.Since)
+	text := "for " + formatAge(age.Milliseconds())
+	if state.Observed {
+		// Status may predate lazyclaw's first observation
+		text += "+"
+	}
+	if state.Changes > 0 {
+		text += fmt.Sprintf(", since %s", state.Since.Format("Jan 2 15:04"))
+	}
+	if !healthStatusOK(state.Status) && age >= chronicFailureAge {
+		return styles.LogError.Render("(chronic, " + text + ")")
+	}
+	return styles.Muted.Render("(" + text + ")")
+}
+
+// healthStatusOK returns true for statuses that represent a healthy component
+func healthStatusOK(status string) bool {
+	switch status {
+	case "ok", "healthy", "pass", "connected", "running", "reachable":
+		return true
+	}
+	return false
+}
+
+// renderProbeStatus renders the manual probe spinner or the last probe timing
+func (a *App) renderProbeStatus() string {
+	p := &a.probe
+	switch {
+	case p.running:
+		return fmt.Sprintf("  %s Probing... %s", p.spinner.View(),
+			styles.Muted.Render(fmt.Sprintf("%.1fs", time.Since(p.startedAt).Seconds())))
+	case p.err != "":
+		return "  Probe:   " + styles.LogError.Render(p.err)
+	case !p.completedAt.IsZero():
+		return fmt.Sprintf("  Probe:   %s at %s  %s", styles.LabelValueHighlight.Render(fmt.Sprintf("%dms", p.duration.Milliseconds())),
+			p.completedAt.Format("15:04:05"), styles.Muted.Render("(p: probe again)"))
+	}
+	return "  " + styles.HintKey.Render("p") + styles.Muted.Render(":probe now")
+}
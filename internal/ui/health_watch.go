@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/history"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// HealthPushMsg carries a health check result an instance's gateway pushed
+type HealthPushMsg struct {
+	Instance   string
+	Result     *models.HealthCheckResult
+	Components map[string]history.ComponentState
+
+	next tea.Cmd // Waits for the following push
+}
+
+// healthWatch holds the subscription to an instance's pushed health updates
+type healthWatch struct {
+	cancel context.CancelFunc
+}
+
+// startHealthWatch subscribes to the current instance's pushed health
+// updates, or returns nil if it has no gRPC API. Polling carries on beside
+// the subscription.
+func (a *App) startHealthWatch() tea.Cmd {
+	a.stopHealthWatch()
+	adapter := a.cliAdapter()
+	if adapter == nil || adapter.GRPC == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.healthWatch.cancel = cancel
+	ch := make(chan *models.HealthCheckResult, 4)
+	instance := adapter.GetInstanceName()
+	return func() tea.Msg {
+		if err := adapter.FollowHealth(ctx, ch); err != nil {
+			return nil
+		}
+		return a.waitForHealthPush(ctx, instance, ch)()
+	}
+}
+
+// stopHealthWatch cancels the current subscription, if any
+func (a *App) stopHealthWatch() {
+	if a.healthWatch.cancel != nil {
+		a.healthWatch.cancel()
+	}
+	a.healthWatch = healthWatch{}
+}
+
+// waitForHealthPush waits for the next pushed health check result on ch
+func (a *App) waitForHealthPush(ctx context.Context, instance string, ch chan *models.HealthCheckResult) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case result, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			return HealthPushMsg{
+				Instance:   instance,
+				Result:     result,
+				Components: a.recordHealth(instance, result),
+				next:       a.waitForHealthPush(ctx, instance, ch),
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleHealthPush shows a pushed result like a polled one, and waits for
+// the next
+func (a *App) handleHealthPush(msg HealthPushMsg) tea.Cmd {
+	if cli := a.cliAdapter(); cli == nil || cli.GetInstanceName() != msg.Instance {
+		return nil
+	}
+	a.loading.health = false
+	a.healthError = nil
+	a.setHealthResult(msg.Result, msg.Components)
+	return msg.next
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// minHeartbeatInterval rejects intervals short enough to flood the agent
+const minHeartbeatInterval = time.Minute
+
+// heartbeatIntervalPart matches one "<number><unit>" part of an interval
+var heartbeatIntervalPart = regexp.MustCompile(`(\d+)(ms|s|m|h|d)`)
+
+// HeartbeatSetMsg is sent when a heartbeat change has been applied
+type HeartbeatSetMsg struct {
+	Description string
+	Error       error
+}
+
+func (m HeartbeatSetMsg) failure() error { return m.Error }
+
+// parseHeartbeatInterval parses intervals such as "30m", "1h30m" or "1d"
+func parseHeartbeatInterval(every string) (time.Duration, error) {
+	if every == "" || heartbeatIntervalPart.ReplaceAllString(every, "") != "" {
+		return 0, fmt.Errorf("invalid interval %q (use e.g. 30m, 1h, 1h30m, 1d)", every)
+	}
+	var total time.Duration
+	for _, m := range heartbeatIntervalPart.FindAllStringSubmatch(every, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q", every)
+		}
+		unit := map[string]time.Duration{
+			"ms": time.Millisecond,
+			"s":  time.Second,
+			"m":  time.Minute,
+			"h":  time.Hour,
+			"d":  24 * time.Hour,
+		}[m[2]]
+		total += time.Duration(n) * unit
+	}
+	if total < minHeartbeatInterval {
+		return 0, fmt.Errorf("interval %q is below the %s minimum", every, minHeartbeatInterval)
+	}
+	return total, nil
+}
+
+// selectedHeartbeat returns the selected agent's ID and its heartbeat config,
+// if it has one
+func (a *App) selectedHeartbeat() (string, *models.HeartbeatAgent) {
+	if a.openclawStatus == nil || a.openclawStatus.Agents == nil {
+		return "", nil
+	}
+	agents := a.openclawStatus.Agents.Agents
+	if a.agentCursor < 0 || a.agentCursor >= len(agents) {
+		return "", nil
+	}
+	agentID := agents[a.agentCursor].ID
+	if hb := a.openclawStatus.Heartbeat; hb != nil {
+		for i := range hb.Agents {
+			if hb.Agents[i].AgentID == agentID {
+				return agentID, &hb.Agents[i]
+			}
+		}
+	}
+	return agentID, nil
+}
+
+// heartbeatWritable checks the write-scope gate, flashing an error if closed
+func (a *App) heartbeatWritable() bool {
+	return a.cliAdapter() != nil && a.canWrite("Editing heartbeats")
+}
+
+// runHeartbeatChange asks prompt to confirm the heartbeat command fn runs,
+// then runs it and reports the result as description
+func (a *App) runHeartbeatChange(prompt, description string, fn func(*gateway.CLIAdapter) error) tea.Cmd {
+	return a.confirmWrite(writeRequest{
+		title:   "Heartbeat",
+		prompt:  prompt + " on " + a.cliAdapter().GetInstanceName() + "?",
+		preview: fn,
+		run: func() tea.Cmd {
+			return func() tea.Msg {
+				adapter := a.cliAdapter()
+				if adapter == nil {
+					return HeartbeatSetMsg{Description: description, Error: fmt.Errorf("CLI adapter not initialized")}
+				}
+				return HeartbeatSetMsg{Description: description, Error: fn(adapter)}
+			}
+		},
+	})
+}
+
+// toggleHeartbeat enables or disables the selected agent's heartbeat
+func (a *App) toggleHeartbeat() tea.Cmd {
+	agentID, hb := a.selectedHeartbeat()
+	if agentID == "" || !a.heartbeatWritable() {
+		return nil
+	}
+	enable := hb == nil || !hb.Enabled
+	verb := "Disable"
+	if enable {
+		verb = "Enable"
+	}
+	prompt := fmt.Sprintf("%s the heartbeat of %s", verb, agentID)
+	return a.runHeartbeatChange(prompt, fmt.Sprintf("%sd heartbeat for %s", verb, agentID), func(c *gateway.CLIAdapter) error {
+		return c.SetHeartbeatEnabled(agentID, enable)
+	})
+}
+
+// setDefaultHeartbeatAgent makes the selected agent the default heartbeat agent
+func (a *App) setDefaultHeartbeatAgent() tea.Cmd {
+	agentID, _ := a.selectedHeartbeat()
+	if agentID == "" || !a.heartbeatWritable() {
+		return nil
+	}
+	if hb := a.openclawStatus.Heartbeat; hb != nil && hb.DefaultAgentID == agentID {
+		return nil
+	}
+	prompt := fmt.Sprintf("Make %s the default heartbeat agent", agentID)
+	return a.runHeartbeatChange(prompt, fmt.Sprintf("Default heartbeat agent is now %s", agentID), func(c *gateway.CLIAdapter) error {
+		return c.SetHeartbeatDefaultAgent(agentID)
+	})
+}
+
+// startHeartbeatEdit opens the interval editor for the selected agent
+func (a *App) startHeartbeatEdit() tea.Cmd {
+	agentID, hb := a.selectedHeartbeat()
+	if agentID == "" || !a.heartbeatWritable() {
+		return nil
+	}
+	a.heartbeatInput.Reset()
+	if hb != nil {
+		a.heartbeatInput.SetValue(hb.Every)
+	}
+	a.heartbeatInput.Focus()
+	a.mode = ModeHeartbeatEdit
+	return textinput.Blink
+}
+
+// applyHeartbeatEdit validates the entered interval and applies it
+func (a *App) applyHeartbeatEdit() tea.Cmd {
+	every := a.heartbeatInput.Value()
+	if _, err := parseHeartbeatInterval(every); err != nil {
+		// Keep the editor open so the value can be corrected
+		a.setFlash(err.Error(), true)
+		return nil
+	}
+	a.mode = ModeNormal
+	a.heartbeatInput.Blur()
+
+	agentID, _ := a.selectedHeartbeat()
+	if agentID == "" {
+		return nil
+	}
+	prompt := fmt.Sprintf("Run the heartbeat of %s every %s", agentID, every)
+	return a.runHeartbeatChange(prompt, fmt.Sprintf("Heartbeat for %s now every %s", agentID, every), func(c *gateway.CLIAdapter) error {
+		return c.SetHeartbeatInterval(agentID, every)
+	})
+}
+
+func (a *App) handleHeartbeatSet(msg HeartbeatSetMsg) tea.Cmd {
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	a.setFlash(msg.Description, false)
+	return a.fetchCLIStatus()
+}
+
+// renderHeartbeatSection renders heartbeat configuration, highlighting the selected agent
+func (a *App) renderHeartbeatSection() []string {
+	hb := a.openclawStatus.Heartbeat
+	if hb == nil {
+		return nil
+	}
+	selected, _ := a.selectedHeartbeat()
+
+	var lines []string
+	title := styles.HelpSection.Render("Heartbeat Configuration")
+	if a.writesAllowed() {
+		title += "  " + styles.Muted.Render("h: toggle  H: interval  D: make default")
+	}
+	lines = append(lines, title)
+	lines = append(lines, fmt.Sprintf("  Default Agent: %s", hb.DefaultAgentID))
+	for _, agent := range hb.Agents {
+		status := styles.Muted.Render("disabled")
+		if agent.Enabled {
+			status = styles.StatusOK.Render("enabled")
+		}
+		line := fmt.Sprintf("  - %s: %s (every %s)", agent.AgentID, status, agent.Every)
+		if agent.AgentID == selected && a.focusedPane == PaneDetails {
+			line = styles.TableRowSelected.Render(fmt.Sprintf("  - %s:", agent.AgentID)) +
+				fmt.Sprintf(" %s (every %s)", status, agent.Every)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
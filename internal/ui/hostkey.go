@@ -0,0 +1,110 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// HostKeyScannedMsg is sent when the current instance's SSH host keys have
+// been fetched for review
+type HostKeyScannedMsg struct {
+	Instance string
+	Key      *gateway.HostKey
+	Error    error
+}
+
+// HostKeyTrustedMsg is sent once a reviewed host key has been written to
+// lazyclaw's known_hosts
+type HostKeyTrustedMsg struct {
+	Host  string
+	Error error
+}
+
+// hostKeyReview holds the Health tab's review of an unknown SSH host key
+type hostKeyReview struct {
+	instance string // Instance the key was scanned for
+	key      *gateway.HostKey
+	scanning bool
+	err      string
+}
+
+// hostKeyRejected reports whether the current instance's last health check
+// failed because ssh does not trust the host key
+func (a *App) hostKeyRejected() bool {
+	return gateway.KindOf(a.healthError) == gateway.ErrorHostKey
+}
+
+// reviewHostKey scans the current instance's host key on the first press,
+// and trusts the key shown on the second
+func (a *App) reviewHostKey() tea.Cmd {
+	cli := a.cliAdapter()
+	r := &a.hostKey
+	if cli == nil || !a.hostKeyRejected() || r.scanning {
+		return nil
+	}
+
+	name := cli.GetInstanceName()
+	if r.key != nil && r.instance == name {
+		key := r.key
+		*r = hostKeyReview{}
+		return func() tea.Msg {
+			return HostKeyTrustedMsg{Host: key.Host, Error: cli.TrustHostKey(key)}
+		}
+	}
+
+	*r = hostKeyReview{instance: name, scanning: true}
+	return func() tea.Msg {
+		key, err := cli.ScanHostKey()
+		return HostKeyScannedMsg{Instance: name, Key: key, Error: err}
+	}
+}
+
+func (a *App) handleHostKeyScanned(msg HostKeyScannedMsg) {
+	r := &a.hostKey
+	if r.instance != msg.Instance {
+		return
+	}
+	r.scanning = false
+	if msg.Error != nil {
+		r.err = msg.Error.Error()
+		return
+	}
+	r.key = msg.Key
+	a.setFlash("Check the fingerprint, then press A again to trust it", false)
+}
+
+// handleHostKeyTrusted reports the result and, on success, fetches again
+// over the now trusted connection
+func (a *App) handleHostKeyTrusted(msg HostKeyTrustedMsg) tea.Cmd {
+	if msg.Error != nil {
+		a.setFlash("Saving host key failed: "+msg.Error.Error(), true)
+		return nil
+	}
+	a.setFlash("Trusted host key for "+msg.Host, false)
+	a.healthError = nil
+	return tea.Batch(a.fetchCLIStatus(), a.fetchCLIHealth())
+}
+
+// renderHostKeyReview shows the scanned host key fingerprints on the Health
+// tab, or how to scan them, while ssh rejects the host key
+func (a *App) renderHostKeyReview(width int) []string {
+	if !a.hostKeyRejected() {
+		return nil
+	}
+	r := &a.hostKey
+	switch {
+	case r.scanning:
+		return []string{styles.Muted.Render("  Scanning host key...")}
+	case r.err != "":
+		return []string{"  Scan: " + styles.LogError.Render(truncate(r.err, width-10))}
+	case r.key == nil || r.instance != a.cliAdapter().GetInstanceName():
+		return []string{"  " + styles.HintKey.Render("A") + styles.Muted.Render(":review host key")}
+	}
+
+	lines := []string{styles.HelpSection.Render("Host Key") + styles.Muted.Render(" ("+r.key.Host+")")}
+	for _, fp := range r.key.Fingerprints {
+		lines = append(lines, "  "+truncate(fp, width-4))
+	}
+	return append(lines, "  "+styles.HintKey.Render("A")+styles.Muted.Render(":trust this key"))
+}
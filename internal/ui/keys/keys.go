@@ -11,6 +11,7 @@ type KeyMap struct {
 	ShiftTab     key.Binding
 	Enter        key.Binding
 	Escape       key.Binding
+	Back         key.Binding
 	Actions      key.Binding
 	Up           key.Binding
 	Down         key.Binding
@@ -28,10 +29,79 @@ type KeyMap struct {
 	Tab8         key.Binding
 	Tab9         key.Binding
 	Tab10        key.Binding
+	Tab11        key.Binding
+	Tab12        key.Binding
+	Tab13        key.Binding
+	Tab14        key.Binding
 	ToggleFollow key.Binding
 	OpenConfig   key.Binding
 	EditConfig   key.Binding
 	Reconnect    key.Binding
+
+	// Health tab
+	Probe         key.Binding
+	AcceptHostKey key.Binding
+
+	// Sessions tab filters
+	FilterAgent key.Binding
+	FilterKind  key.Binding
+	FilterUsage key.Binding
+
+	// Security tab
+	FilterSeverity key.Binding
+	Export         key.Binding
+	ApplyFix       key.Binding
+
+	// Channels tab
+	ChannelLink    key.Binding
+	ChannelUnlink  key.Binding
+	ChannelRestart key.Binding
+	ChannelSend    key.Binding
+
+	// Sessions tab
+	KillSession    key.Binding
+	CompactSession key.Binding
+	ResetSession   key.Binding
+
+	// Agents tab
+	AgentSessions     key.Binding
+	HeartbeatToggle   key.Binding
+	HeartbeatInterval key.Binding
+	HeartbeatDefault  key.Binding
+	BootstrapAgent    key.Binding
+	NewAgent          key.Binding
+
+	// Memory tab
+	BrowseFiles key.Binding
+	Reindex     key.Binding
+	ClearCache  key.Binding
+
+	// System tab
+	UpdateGateway  key.Binding
+	UpdateChannel  key.Binding
+	ServiceStart   key.Binding
+	ServiceStop    key.Binding
+	ServiceRestart key.Binding
+	ServiceBoot    key.Binding
+	ServiceLogs    key.Binding
+	ProcessTerm    key.Binding
+	ProcessKill    key.Binding
+	CommandLog     key.Binding
+
+	// Usage tab
+	UsagePeriod key.Binding
+
+	// Hooks tab
+	TestWebhook key.Binding
+
+	// Performance overlay
+	PerfOverlay key.Binding
+
+	// Doctor results
+	Doctor key.Binding
+
+	// Instance discovery
+	Discover key.Binding
 }
 
 // DefaultKeyMap returns the default keybindings
@@ -65,6 +135,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "back/close"),
 		),
+		Back: key.NewBinding(
+			key.WithKeys("backspace"),
+			key.WithHelp("backspace", "back"),
+		),
 		Actions: key.NewBinding(
 			key.WithKeys("x"),
 			key.WithHelp("x", "actions"),
@@ -133,6 +207,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("0"),
 			key.WithHelp("0", "System"),
 		),
+		Tab11: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "Usage"),
+		),
+		Tab12: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "Config"),
+		),
+		Tab13: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "Hooks"),
+		),
+		Tab14: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "Queues"),
+		),
 		ToggleFollow: key.NewBinding(
 			key.WithKeys("f"),
 			key.WithHelp("f", "toggle follow"),
@@ -149,6 +239,162 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "reconnect"),
 		),
+		FilterAgent: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "filter by agent"),
+		),
+		FilterKind: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "filter by kind"),
+		),
+		FilterUsage: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "filter by usage"),
+		),
+		Probe: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "probe health now"),
+		),
+		AcceptHostKey: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "review SSH host key"),
+		),
+		FilterSeverity: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "filter by severity"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export report"),
+		),
+		ApplyFix: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "apply finding's fix"),
+		),
+		AgentSessions: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "agent sessions"),
+		),
+		HeartbeatToggle: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "toggle heartbeat"),
+		),
+		HeartbeatInterval: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "heartbeat interval"),
+		),
+		HeartbeatDefault: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "default heartbeat agent"),
+		),
+		BootstrapAgent: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "bootstrap agent"),
+		),
+		NewAgent: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "new agent"),
+		),
+		BrowseFiles: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "browse indexed files"),
+		),
+		Reindex: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "reindex memory"),
+		),
+		ClearCache: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "clear embedding cache"),
+		),
+		ChannelLink: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "link/relink channel (scan QR)"),
+		),
+		ChannelUnlink: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "unlink channel"),
+		),
+		ChannelRestart: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "restart channel"),
+		),
+		ChannelSend: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "send test message"),
+		),
+		KillSession: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "kill session"),
+		),
+		CompactSession: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "compact session now"),
+		),
+		ResetSession: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "reset session context"),
+		),
+		UpdateGateway: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "update gateway"),
+		),
+		UpdateChannel: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "switch update channel"),
+		),
+		ServiceStart: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "start service"),
+		),
+		ServiceStop: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "stop service"),
+		),
+		ServiceRestart: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "restart service"),
+		),
+		ServiceBoot: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "toggle start at boot"),
+		),
+		ServiceLogs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "service logs"),
+		),
+		ProcessTerm: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "SIGTERM process"),
+		),
+		ProcessKill: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "SIGKILL process"),
+		),
+		CommandLog: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "command log"),
+		),
+		UsagePeriod: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "cycle usage period"),
+		),
+		TestWebhook: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "test-fire webhook"),
+		),
+		PerfOverlay: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "performance overlay"),
+		),
+		Doctor: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "run openclaw doctor"),
+		),
+		Discover: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "discover instances"),
+		),
 	}
 }
 
@@ -163,7 +409,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down, k.PageUp, k.PageDown},
 		{k.Tab, k.ShiftTab, k.Enter, k.Escape},
 		{k.Tab1, k.Tab2, k.Tab3, k.Tab4, k.Tab5, k.Tab6, k.Tab7},
-		{k.Tab8, k.Tab9, k.Tab10},
+		{k.Tab8, k.Tab9, k.Tab10, k.Tab11, k.Tab12, k.Tab13, k.Tab14},
 		{k.Search, k.Actions, k.ToggleFollow, k.Help, k.Quit},
 	}
 }
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// defaultLogArchiveBytes is the archive budget when ui.log_archive_mb is unset
+const defaultLogArchiveBytes = 8 << 20
+
+// logChunkSize is how many evicted events are compressed together
+const logChunkSize = 1000
+
+// logSummary counts the entries of a stretch of log history by level
+type logSummary struct {
+	count    int
+	errors   int
+	warnings int
+	from, to time.Time
+}
+
+func (s *logSummary) add(event *models.LogEvent) {
+	if s.count == 0 {
+		s.from = event.Timestamp
+	}
+	s.to = event.Timestamp
+	s.count++
+	switch event.Level {
+	case "error":
+		s.errors++
+	case "warn", "warning":
+		s.warnings++
+	}
+}
+
+func (s *logSummary) merge(o logSummary) {
+	if o.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		s.from = o.from
+	}
+	s.to = o.to
+	s.count += o.count
+	s.errors += o.errors
+	s.warnings += o.warnings
+}
+
+// logChunk is a gzip-compressed run of archived events (as JSON lines, raw
+// lines dropped). The event-like entries the Events tab shows are kept
+// uncompressed alongside, so that tab never has to decompress anything.
+type logChunk struct {
+	data    []byte
+	summary logSummary
+	notable []models.LogEvent
+	size    int // Bytes charged against the archive budget
+}
+
+// logArchive keeps the events evicted from the hot log buffer. Events are
+// compressed in chunks; when the chunks outgrow the budget the oldest are
+// dropped and only their counts are kept.
+type logArchive struct {
+	budget  int
+	notable func(models.LogEvent) bool
+
+	pending []models.LogEvent // Not yet compressed, oldest first
+	chunks  []logChunk        // Oldest first
+	size    int
+	expired logSummary // Dropped chunks, as counts only
+
+	// The most recently decompressed chunk, so paging through history
+	// decompresses each chunk once
+	cacheChunk  *logChunk
+	cacheEvents []models.LogEvent
+}
+
+// logArchiveBudget converts ui.log_archive_mb to bytes
+func logArchiveBudget(mb int) int {
+	switch {
+	case mb == 0:
+		return defaultLogArchiveBytes
+	case mb < 0:
+		return 0
+	}
+	return mb << 20
+}
+
+func newLogArchive(budget int, notable func(models.LogEvent) bool) *logArchive {
+	return &logArchive{budget: budget, notable: notable}
+}
+
+// Add archives an event evicted from the hot buffer
+func (a *logArchive) Add(event models.LogEvent) {
+	if a.budget <= 0 {
+		a.expired.add(&event)
+		return
+	}
+	event.Raw = ""
+	a.pending = append(a.pending, event)
+	if len(a.pending) >= logChunkSize {
+		a.seal()
+	}
+}
+
+// seal compresses the pending events into a chunk and enforces the budget
+func (a *logArchive) seal() {
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	enc := json.NewEncoder(zw)
+	chunk := logChunk{}
+	for i := range a.pending {
+		event := &a.pending[i]
+		_ = enc.Encode(event)
+		chunk.summary.add(event)
+		if a.notable(*event) {
+			chunk.notable = append(chunk.notable, *event)
+			chunk.size += len(event.Message) + 64
+		}
+	}
+	_ = zw.Close()
+	chunk.data = buf.Bytes()
+	chunk.size += len(chunk.data)
+
+	a.chunks = append(a.chunks, chunk)
+	a.size += chunk.size
+	a.pending = nil
+
+	for a.size > a.budget && len(a.chunks) > 0 {
+		oldest := &a.chunks[0]
+		a.expired.merge(oldest.summary)
+		a.size -= oldest.size
+		if a.cacheChunk == oldest {
+			a.cacheChunk, a.cacheEvents = nil, nil
+		}
+		a.chunks = a.chunks[1:]
+	}
+}
+
+// decompress returns a chunk's events, oldest first
+func (a *logArchive) decompress(chunk *logChunk) []models.LogEvent {
+	if a.cacheChunk == chunk {
+		return a.cacheEvents
+	}
+	events := make([]models.LogEvent, 0, chunk.summary.count)
+	if zr, err := gzip.NewReader(bytes.NewReader(chunk.data)); err == nil {
+		dec := json.NewDecoder(zr)
+		for {
+			var event models.LogEvent
+			if dec.Decode(&event) != nil {
+				break
+			}
+			events = append(events, event)
+		}
+	}
+	a.cacheChunk, a.cacheEvents = chunk, events
+	return events
+}
+
+// Stored summarizes the archived events still retrievable
+func (a *logArchive) Stored() logSummary {
+	var s logSummary
+	for i := range a.chunks {
+		s.merge(a.chunks[i].summary)
+	}
+	for i := range a.pending {
+		s.add(&a.pending[i])
+	}
+	return s
+}
+
+// Expired summarizes the events dropped to stay within budget
+func (a *logArchive) Expired() logSummary {
+	return a.expired
+}
+
+// Size returns the archive's memory use in bytes, excluding pending events
+func (a *logArchive) Size() int {
+	return a.size
+}
+
+// Newest returns up to n of the newest archived events whose search key is
+// accepted by match, oldest first
+func (a *logArchive) Newest(n int, match func(key string) bool) []models.LogEvent {
+	var found []models.LogEvent // Newest first
+	collect := func(events []models.LogEvent) bool {
+		for i := len(events) - 1; i >= 0 && len(found) < n; i-- {
+			if match(logSearchKey(&events[i])) {
+				found = append(found, events[i])
+			}
+		}
+		return len(found) >= n
+	}
+	if !collect(a.pending) {
+		for i := len(a.chunks) - 1; i >= 0; i-- {
+			if collect(a.decompress(&a.chunks[i])) {
+				break
+			}
+		}
+	}
+	for i, j := 0, len(found)-1; i < j; i, j = i+1, j-1 {
+		found[i], found[j] = found[j], found[i]
+	}
+	return found
+}
+
+// Notable returns the archived event-like entries, oldest first
+func (a *logArchive) Notable() []models.LogEvent {
+	var events []models.LogEvent
+	for i := range a.chunks {
+		events = append(events, a.chunks[i].notable...)
+	}
+	for i := range a.pending {
+		if a.notable(a.pending[i]) {
+			events = append(events, a.pending[i])
+		}
+	}
+	return events
+}
+
+// renderLogArchiveSummary describes the history kept beyond the Logs tab
+// buffer, compressed or as counts only
+func (a *App) renderLogArchiveSummary() []string {
+	archive := a.logs.Archive()
+	stored, expired := archive.Stored(), archive.Expired()
+	if stored.count == 0 && expired.count == 0 {
+		return nil
+	}
+	lines := []string{""}
+	if stored.count > 0 {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  %s older lines archived since %s (%s compressed)",
+			formatNumber(stored.count), stored.from.Format("15:04:05"), formatBytes(int64(archive.Size())))))
+	}
+	if expired.count > 0 {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  %s earlier lines dropped (%d errors, %d warnings, %s-%s)",
+			formatNumber(expired.count), expired.errors, expired.warnings,
+			expired.from.Format("15:04"), expired.to.Format("15:04"))))
+	}
+	return lines
+}
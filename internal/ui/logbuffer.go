@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// defaultLogTailLines is used when ui.log_tail_lines is unset
+const defaultLogTailLines = 500
+
+// maxFilterIndexes is how many recent filters keep a live match index, so
+// switching back to an earlier filter costs nothing
+const maxFilterIndexes = 4
+
+// logBuffer is a fixed-capacity ring of log events. Each event's lower-cased
+// search key is computed once on ingest, and the match indexes of recent
+// filters are kept up to date as events arrive, so rendering only touches the
+// lines on screen, even with 50k+ line buffers at high ingest rates. Events
+// evicted from the ring move to a compressed archive.
+type logBuffer struct {
+	ring  []models.LogEvent
+	keys  []string // Search keys, parallel to ring
+	limit int
+	total int // Events ever appended; event n lives at ring[n%limit]
+
+	filter  string        // Lower-cased; "" matches everything
+	indexes []filterIndex // Most recently used first; [0] is filter's
+	errors  int           // Error-level events in the buffer
+	tail    []models.LogEvent
+
+	archive *logArchive
+}
+
+// filterIndex lists the numbers of the events matching filter, ascending
+type filterIndex struct {
+	filter  string
+	matches []int
+}
+
+// newLogBuffer creates a buffer holding limit events uncompressed and up to
+// archiveBudget bytes of compressed older history
+func newLogBuffer(limit, archiveBudget int) *logBuffer {
+	if limit <= 0 {
+		limit = defaultLogTailLines
+	}
+	return &logBuffer{limit: limit, archive: newLogArchive(archiveBudget, isEventLog)}
+}
+
+// Len returns the number of buffered events
+func (b *logBuffer) Len() int {
+	return min(b.total, b.limit)
+}
+
+// Errors returns the number of buffered error-level events
+func (b *logBuffer) Errors() int {
+	return b.errors
+}
+
+func (b *logBuffer) oldest() int {
+	return b.total - b.Len()
+}
+
+func (b *logBuffer) at(n int) *models.LogEvent {
+	return &b.ring[n%b.limit]
+}
+
+func (b *logBuffer) key(n int) string {
+	return b.keys[n%b.limit]
+}
+
+// Append adds an event, moving the oldest one to the archive when the buffer
+// is full. It reports whether the event matches the filter.
+func (b *logBuffer) Append(event models.LogEvent) bool {
+	if b.Len() == b.limit {
+		evicted := b.oldest()
+		if b.at(evicted).Level == "error" {
+			b.errors--
+		}
+		b.archive.Add(*b.at(evicted))
+		for i := range b.indexes {
+			idx := &b.indexes[i]
+			if len(idx.matches) > 0 && idx.matches[0] == evicted {
+				idx.matches = idx.matches[1:]
+			}
+		}
+	}
+
+	key := logSearchKey(&event)
+	if len(b.ring) < b.limit {
+		b.ring = append(b.ring, event)
+		b.keys = append(b.keys, key)
+	} else {
+		b.ring[b.total%b.limit] = event
+		b.keys[b.total%b.limit] = key
+	}
+	n := b.total
+	b.total++
+
+	if event.Level == "error" {
+		b.errors++
+	}
+	for i := range b.indexes {
+		idx := &b.indexes[i]
+		if strings.Contains(key, idx.filter) {
+			idx.matches = append(idx.matches, n)
+		}
+	}
+	if b.filter == "" {
+		return true
+	}
+	matches := b.indexes[0].matches
+	return len(matches) > 0 && matches[len(matches)-1] == n
+}
+
+// Reset drops all events, keeping the capacity, archive budget and filter
+func (b *logBuffer) Reset() {
+	*b = logBuffer{limit: b.limit, filter: b.filter, archive: newLogArchive(b.archive.budget, b.archive.notable)}
+	if b.filter != "" {
+		b.indexes = []filterIndex{{filter: b.filter}}
+	}
+}
+
+// SetFilter changes the filter. A recently used filter's index is reused;
+// a filter that narrows a recent one (as when typing) only rescans that
+// filter's matches.
+func (b *logBuffer) SetFilter(filter string) {
+	filter = strings.ToLower(filter)
+	if filter == b.filter {
+		return
+	}
+	b.filter = filter
+	if filter == "" {
+		return
+	}
+
+	for i, idx := range b.indexes {
+		if idx.filter == filter {
+			copy(b.indexes[1:i+1], b.indexes[:i])
+			b.indexes[0] = idx
+			return
+		}
+	}
+
+	// Narrowest recent filter the new one refines, if any
+	var base *filterIndex
+	for i := range b.indexes {
+		idx := &b.indexes[i]
+		if strings.Contains(filter, idx.filter) && (base == nil || len(idx.filter) > len(base.filter)) {
+			base = idx
+		}
+	}
+
+	var matches []int
+	if base != nil {
+		for _, n := range base.matches {
+			if strings.Contains(b.key(n), filter) {
+				matches = append(matches, n)
+			}
+		}
+	} else {
+		for n := b.oldest(); n < b.total; n++ {
+			if strings.Contains(b.key(n), filter) {
+				matches = append(matches, n)
+			}
+		}
+	}
+
+	if len(b.indexes) == maxFilterIndexes {
+		b.indexes = b.indexes[:maxFilterIndexes-1]
+	}
+	b.indexes = append([]filterIndex{{filter: filter, matches: matches}}, b.indexes...)
+}
+
+// MatchCount returns the number of events matching the filter
+func (b *logBuffer) MatchCount() int {
+	if b.filter == "" {
+		return b.Len()
+	}
+	return len(b.indexes[0].matches)
+}
+
+// Tail returns up to n of the newest events matching the filter, oldest
+// first. The slice is reused by the next call.
+func (b *logBuffer) Tail(n int) []models.LogEvent {
+	count := b.MatchCount()
+	b.tail = b.appendMatches(b.tail[:0], max(count-n, 0), count)
+	return b.tail
+}
+
+// appendMatches appends the from-th to (to-1)-th buffered events matching the
+// filter to events
+func (b *logBuffer) appendMatches(events []models.LogEvent, from, to int) []models.LogEvent {
+	if b.filter == "" {
+		for i := from; i < to; i++ {
+			events = append(events, *b.at(b.oldest() + i))
+		}
+		return events
+	}
+	for _, i := range b.indexes[0].matches[from:to] {
+		events = append(events, *b.at(i))
+	}
+	return events
+}
+
+// Page returns up to n events matching the filter, oldest first, ending skip
+// matches before the newest. Pages reaching past the buffer continue into
+// the archive.
+func (b *logBuffer) Page(skip, n int) []models.LogEvent {
+	count := b.MatchCount()
+	hotEnd := max(count-skip, 0)
+	hotStart := max(hotEnd-n, 0)
+	events := b.appendMatches(nil, hotStart, hotEnd)
+	remaining := n - len(events)
+	if remaining == 0 {
+		return events
+	}
+
+	archiveSkip := max(skip-count, 0)
+	filter := b.filter
+	older := b.archive.Newest(archiveSkip+remaining, func(key string) bool {
+		return strings.Contains(key, filter)
+	})
+	older = older[:max(len(older)-archiveSkip, 0)]
+	older = older[max(len(older)-remaining, 0):]
+	return append(older, events...)
+}
+
+// Archive returns the archive of events evicted from the buffer
+func (b *logBuffer) Archive() *logArchive {
+	return b.archive
+}
+
+// Each calls fn for every buffered event, oldest first
+func (b *logBuffer) Each(fn func(*models.LogEvent)) {
+	for n := b.oldest(); n < b.total; n++ {
+		fn(b.at(n))
+	}
+}
+
+// logSearchKey is the lower-cased text a filter is matched against: the
+// message and level, separated so a filter cannot match across them
+func logSearchKey(event *models.LogEvent) string {
+	return strings.ToLower(event.Message) + "\x00" + strings.ToLower(event.Level)
+}
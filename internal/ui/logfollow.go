@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// CLILogMsg is sent when a log event arrives from a log follower
+type CLILogMsg struct {
+	Follower int
+	Event    models.LogEvent
+}
+
+// LogBackfillMsg is sent once a follower has fetched the recent log tail
+// and started following. FollowError is set if following failed to start.
+type LogBackfillMsg struct {
+	Follower    int
+	Events      []models.LogEvent
+	FollowError error
+}
+
+// LogFollowEndedMsg is sent when a log follower's stream ends or fails to start
+type LogFollowEndedMsg struct {
+	Follower int
+	Error    error
+}
+
+// logFollower owns one `openclaw logs --follow` stream. Cancelling its context
+// terminates the process; the adapter then reaps it and closes ch, so every
+// goroutine and pipe of a follower ends with it. Each start gets a new ID and
+// messages from earlier followers are dropped, so a slow stream from the
+// previous instance can never leak into the current one.
+type logFollower struct {
+	id     int
+	ch     chan models.LogEvent
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Backfilled lines the stream may repeat as it starts, by raw line.
+	// Cleared at the first streamed line that is not one of them.
+	overlap map[string]bool
+}
+
+// wait returns a command delivering the follower's next event
+func (f *logFollower) wait() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event, ok := <-f.ch:
+			if !ok {
+				return LogFollowEndedMsg{Follower: f.id}
+			}
+			return CLILogMsg{Follower: f.id, Event: event}
+		case <-f.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// following reports whether a log follower is running
+func (a *App) following() bool {
+	return a.logFollower != nil
+}
+
+// startLogFollowing replaces any running follower with one for the current
+// adapter
+func (a *App) startLogFollowing() tea.Cmd {
+	a.stopLogFollowing()
+	adapter := a.getCurrentAdapter()
+	if adapter == nil {
+		return nil
+	}
+
+	a.logFollowerSeq++
+	f := &logFollower{
+		id: a.logFollowerSeq,
+		ch: make(chan models.LogEvent, 100),
+	}
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	a.logFollower = f
+
+	// Backfill the buffer from the CLI's log tail first, so the Logs tab is
+	// not empty until the next event. A failed tail just starts empty.
+	cli := a.cliAdapter()
+	tail := a.config.UI.LogTailLines
+	if tail <= 0 {
+		tail = defaultLogTailLines
+	}
+	return func() tea.Msg {
+		var backfill []models.LogEvent
+		if cli != nil {
+			backfill, _ = cli.TailLogs(tail)
+		}
+		if err := adapter.FollowLogs(f.ctx, f.ch); err != nil {
+			if backfill != nil {
+				return LogBackfillMsg{Follower: f.id, Events: backfill, FollowError: err}
+			}
+			return LogFollowEndedMsg{Follower: f.id, Error: err}
+		}
+		if backfill != nil {
+			return LogBackfillMsg{Follower: f.id, Events: backfill}
+		}
+		return f.wait()()
+	}
+}
+
+// handleLogBackfill buffers the log tail, then waits for streamed events
+func (a *App) handleLogBackfill(msg LogBackfillMsg) tea.Cmd {
+	f := a.logFollower
+	if f == nil || f.id != msg.Follower {
+		return nil
+	}
+	f.overlap = make(map[string]bool, len(msg.Events))
+	for _, event := range msg.Events {
+		a.appendLog(event)
+		f.overlap[event.Raw] = true
+	}
+	if msg.FollowError != nil {
+		a.handleLogFollowEnded(LogFollowEndedMsg{Follower: f.id, Error: msg.FollowError})
+		return nil
+	}
+	return f.wait()
+}
+
+// stopLogFollowing stops the current follower, if any
+func (a *App) stopLogFollowing() {
+	if a.logFollower != nil {
+		a.logFollower.cancel()
+		a.logFollower = nil
+	}
+}
+
+func (a *App) handleCLILog(msg CLILogMsg) tea.Cmd {
+	f := a.logFollower
+	if f == nil || f.id != msg.Follower {
+		return nil
+	}
+	// Skip lines the stream replays from the backfilled tail
+	if f.overlap != nil {
+		if f.overlap[msg.Event.Raw] {
+			return f.wait()
+		}
+		f.overlap = nil
+	}
+	a.appendLog(msg.Event)
+	return f.wait()
+}
+
+func (a *App) handleLogFollowEnded(msg LogFollowEndedMsg) {
+	f := a.logFollower
+	if f == nil || f.id != msg.Follower {
+		return
+	}
+	a.stopLogFollowing()
+	if msg.Error != nil {
+		// Log following failed to start - not fatal
+		a.appendLog(models.LogEvent{
+			Timestamp: time.Now(),
+			Level:     "warn",
+			Source:    "lazyclaw",
+			Message:   fmt.Sprintf("Could not start log following: %v", msg.Error),
+		})
+	}
+}
+
+// appendLog buffers a log event, keeping a scrolled-back Logs tab in place
+func (a *App) appendLog(event models.LogEvent) {
+	if a.logs.Append(event) && a.logScroll > 0 {
+		a.logScroll++
+	}
+}
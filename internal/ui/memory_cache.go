@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// CacheClearedMsg is sent when an embedding cache clear returns
+type CacheClearedMsg struct {
+	Entries int // Entries before the clear, as the status reported them
+	Error   error
+}
+
+func (m CacheClearedMsg) failure() error { return m.Error }
+
+// cacheClear holds the embedding cache clear running
+type cacheClear struct {
+	running bool
+}
+
+// confirmCacheClear asks to confirm clearing the embedding cache
+func (a *App) confirmCacheClear() tea.Cmd {
+	if a.cliAdapter() == nil || a.openclawStatus == nil || a.openclawStatus.Memory == nil || a.cacheClear.running {
+		return nil
+	}
+	cache := a.openclawStatus.Memory.Cache
+	if !a.canWrite("Clearing the embedding cache") {
+		return nil
+	}
+	if !cache.Enabled {
+		a.setFlash("The embedding cache is disabled", true)
+		return nil
+	}
+	entries := cache.Entries
+	return a.confirmWrite(writeRequest{
+		title:       "Clear Embedding Cache",
+		prompt:      fmt.Sprintf("Clear the %d cached embeddings on %s?", entries, a.cliAdapter().GetInstanceName()),
+		warning:     "They are computed again, at the provider's cost, as memory is indexed and searched.",
+		destructive: true,
+		preview:     func(c *gateway.CLIAdapter) error { return c.ClearEmbeddingCache() },
+		run:         func() tea.Cmd { return a.clearCache(entries) },
+	})
+}
+
+// clearCache clears the embedding cache, then refreshes the status so the
+// entry count shows the change
+func (a *App) clearCache(entries int) tea.Cmd {
+	a.cacheClear.running = true
+	adapter := a.cliAdapter()
+	return func() tea.Msg {
+		return CacheClearedMsg{Entries: entries, Error: adapter.ClearEmbeddingCache()}
+	}
+}
+
+func (a *App) handleCacheCleared(msg CacheClearedMsg) tea.Cmd {
+	a.cacheClear.running = false
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+	} else {
+		a.setFlash(fmt.Sprintf("Cleared %d embedding cache entries", msg.Entries), false)
+	}
+	return a.fetchCLIStatus()
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+	"github.com/mattn/go-runewidth"
+)
+
+// CLIMemoryFilesMsg is sent when the indexed file list fetch completes
+type CLIMemoryFilesMsg struct {
+	Files *models.MemoryFilesList
+	Error error
+}
+
+// memoryFileBrowser holds the Memory tab's indexed file browser state
+type memoryFileBrowser struct {
+	open    bool
+	loading bool
+	err     string
+	files   []models.MemoryFile // Sorted by source, then path
+	cursor  int
+}
+
+func (a *App) fetchMemoryFiles() tea.Cmd {
+	adapter := a.cliAdapter()
+	return a.scoped(func() tea.Msg {
+		if adapter == nil {
+			return CLIMemoryFilesMsg{Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		files, err := adapter.ListMemoryFiles()
+		return CLIMemoryFilesMsg{Files: files, Error: err}
+	})
+}
+
+// toggleMemoryBrowser opens the file browser (fetching the list) or closes it
+func (a *App) toggleMemoryBrowser() tea.Cmd {
+	b := &a.memoryBrowser
+	if b.open {
+		b.open = false
+		return nil
+	}
+	b.open = true
+	if a.cliAdapter() == nil {
+		return nil
+	}
+	b.loading = true
+	b.err = ""
+	return a.fetchMemoryFiles()
+}
+
+// handleMemoryFiles stores a fetched file list, grouped by source
+func (a *App) handleMemoryFiles(msg CLIMemoryFilesMsg) {
+	b := &a.memoryBrowser
+	b.loading = false
+	if msg.Error != nil {
+		b.err = msg.Error.Error()
+		return
+	}
+	files := append([]models.MemoryFile(nil), msg.Files.Files...)
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].Source != files[j].Source {
+			return files[i].Source < files[j].Source
+		}
+		return files[i].Path < files[j].Path
+	})
+	b.files = files
+	if b.cursor >= len(files) {
+		b.cursor = 0
+	}
+}
+
+// moveMemoryCursor moves the file browser selection by delta
+func (a *App) moveMemoryCursor(delta int) {
+	b := &a.memoryBrowser
+	b.cursor += delta
+	if b.cursor >= len(b.files) {
+		b.cursor = len(b.files) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+}
+
+// renderMemoryBrowser renders indexed files grouped by source
+func (a *App) renderMemoryBrowser(width, height int) string {
+	b := &a.memoryBrowser
+	var lines []string
+
+	lines = append(lines, styles.HelpSection.Render(fmt.Sprintf("Indexed Files (%d)", len(b.files)))+"  "+
+		styles.Muted.Render("j/k: move  b: back"))
+	lines = append(lines, "")
+
+	switch {
+	case b.loading:
+		lines = append(lines, styles.Muted.Render("  Loading indexed files..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	case b.err != "":
+		lines = append(lines, "  "+styles.LogError.Render(b.err))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	case len(b.files) == 0:
+		lines = append(lines, styles.Muted.Render("  No indexed files"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	// Build rows with a header per source; remember which row holds the cursor
+	type row struct {
+		text   string
+		header bool
+		file   int
+	}
+	var rows []row
+	source := ""
+	for i, f := range b.files {
+		if i == 0 || f.Source != source {
+			source = f.Source
+			count, chunks := 0, 0
+			for _, other := range b.files {
+				if other.Source == source {
+					count++
+					chunks += other.Chunks
+				}
+			}
+			rows = append(rows, row{
+				text:   fmt.Sprintf("  %s  %s", styles.CardTitle.Render(source), styles.Muted.Render(fmt.Sprintf("%d files, %d chunks", count, chunks))),
+				header: true,
+				file:   -1,
+			})
+		}
+
+		indexed := "-"
+		if f.IndexedAt > 0 {
+			indexed = formatAge(time.Since(time.UnixMilli(f.IndexedAt)).Milliseconds()) + " ago"
+		}
+		rows = append(rows, row{
+			text: fmt.Sprintf("    %s %7d %10s", runewidth.FillRight(truncatePath(f.Path, max(width-28, 10)), max(width-28, 10)), f.Chunks, indexed),
+			file: i,
+		})
+	}
+
+	// Scroll so the cursor row stays visible
+	cursorRow := 0
+	for i, r := range rows {
+		if r.file == b.cursor {
+			cursorRow = i
+			break
+		}
+	}
+	maxVisible := height - 5
+	if maxVisible < 1 {
+		maxVisible = 1
+	}
+	start := 0
+	if cursorRow >= maxVisible {
+		start = cursorRow - maxVisible + 1
+	}
+	end := start + maxVisible
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	lines = append(lines, styles.TableHeader.Render(fmt.Sprintf("    %-*s %7s %10s", max(width-28, 10), "Path", "Chunks", "Indexed")))
+	for _, r := range rows[start:end] {
+		if r.file == b.cursor {
+			lines = append(lines, styles.TableRowSelected.Render(r.text))
+		} else {
+			lines = append(lines, r.text)
+		}
+	}
+	if end < len(rows) {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  ... %d more", len(rows)-end)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
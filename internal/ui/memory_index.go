@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// reindexPollInterval is how often progress is polled while indexing
+const reindexPollInterval = time.Second
+
+// CLIMemoryIndexMsg is sent when a memory index status poll completes
+type CLIMemoryIndexMsg struct {
+	Status *models.MemoryIndexStatus
+	Error  error
+}
+
+// ReindexDoneMsg is sent when a lazyclaw-triggered reindex command returns
+type ReindexDoneMsg struct {
+	Error error
+}
+
+func (m ReindexDoneMsg) failure() error { return m.Error }
+
+// ReindexPollMsg triggers the next progress poll while indexing
+type ReindexPollMsg struct{}
+
+// reindexTracker follows memory reindex progress for the Memory tab
+type reindexTracker struct {
+	status      *models.MemoryIndexStatus
+	polling     bool // A fast poll chain is running
+	triggered   bool // lazyclaw started the reindex
+	err         string
+	completedAt time.Time
+	duration    time.Duration
+}
+
+// indexing returns true if a reindex is known to be in progress
+func (r *reindexTracker) indexing() bool {
+	return r.triggered || (r.status != nil && r.status.Indexing)
+}
+
+func (a *App) fetchMemoryIndexStatus() tea.Cmd {
+	adapter := a.cliAdapter()
+	return a.scoped(func() tea.Msg {
+		if adapter == nil {
+			return CLIMemoryIndexMsg{Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		status, err := adapter.GetMemoryIndexStatus()
+		return CLIMemoryIndexMsg{Status: status, Error: err}
+	})
+}
+
+func (a *App) scheduleReindexPoll() tea.Cmd {
+	return tea.Tick(reindexPollInterval, func(time.Time) tea.Msg {
+		return ReindexPollMsg{}
+	})
+}
+
+// startReindex asks to confirm triggering `openclaw memory index`, which
+// runReindex then does
+func (a *App) startReindex() tea.Cmd {
+	r := &a.reindex
+	if reason := a.writeRefusal("reindex"); reason != "" {
+		r.err = reason
+		return nil
+	}
+	if r.indexing() || a.cliAdapter() == nil {
+		return nil
+	}
+	return a.confirmWrite(writeRequest{
+		title:   "Reindex Memory",
+		prompt:  "Reindex memory on " + a.cliAdapter().GetInstanceName() + "?",
+		warning: "Changed files are embedded again, at the provider's cost.",
+		preview: func(c *gateway.CLIAdapter) error { return c.ReindexMemory() },
+		run:     a.runReindex,
+	})
+}
+
+// runReindex triggers `openclaw memory index`. The Memory tab's next
+// refresh sees the reindex triggered and polls its progress.
+func (a *App) runReindex() tea.Cmd {
+	r := &a.reindex
+	r.triggered = true
+	r.err = ""
+	return func() tea.Msg {
+		adapter := a.cliAdapter()
+		if adapter == nil {
+			return ReindexDoneMsg{Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		return ReindexDoneMsg{Error: adapter.ReindexMemory()}
+	}
+}
+
+// handleMemoryIndex processes a progress poll and decides whether to keep polling
+func (a *App) handleMemoryIndex(msg CLIMemoryIndexMsg) tea.Cmd {
+	r := &a.reindex
+	if msg.Error != nil {
+		// Older openclaw releases may not support `memory status`; stop fast polling
+		r.polling = false
+		return nil
+	}
+
+	wasIndexing := r.status != nil && r.status.Indexing
+	r.status = msg.Status
+
+	if wasIndexing && !msg.Status.Indexing && !r.triggered {
+		a.finishReindex()
+		// Pick up the new file/chunk counts and Dirty flag
+		return a.fetchCLIStatus()
+	}
+
+	if r.indexing() && a.activeTab == TabMemory {
+		r.polling = true
+		return a.scheduleReindexPoll()
+	}
+	r.polling = false
+	return nil
+}
+
+// handleReindexDone records completion of a lazyclaw-triggered reindex
+func (a *App) handleReindexDone(msg ReindexDoneMsg) tea.Cmd {
+	r := &a.reindex
+	r.triggered = false
+	if msg.Error != nil {
+		r.err = msg.Error.Error()
+		return nil
+	}
+	a.finishReindex()
+	return a.fetchCLIStatus()
+}
+
+func (a *App) finishReindex() {
+	r := &a.reindex
+	r.completedAt = time.Now()
+	r.duration = 0
+	if r.status != nil && r.status.StartedAt > 0 {
+		r.duration = r.completedAt.Sub(time.UnixMilli(r.status.StartedAt))
+	}
+	if r.status != nil {
+		r.status.Indexing = false
+	}
+}
+
+// renderReindexStatus renders the index status line(s) in the Memory tab
+func (a *App) renderReindexStatus(mem *models.MemoryInfo, width int) []string {
+	r := &a.reindex
+	var lines []string
+
+	switch {
+	case r.indexing():
+		st := r.status
+		if st == nil || st.FilesTotal == 0 {
+			lines = append(lines, "    Status: "+styles.BadgeWarning.Render("INDEXING")+" "+styles.Muted.Render("starting..."))
+			break
+		}
+		elapsed := ""
+		if st.StartedAt > 0 {
+			elapsed = ", " + formatAge(time.Since(time.UnixMilli(st.StartedAt)).Milliseconds()) + " elapsed"
+		}
+		lines = append(lines, fmt.Sprintf("    Status: %s %d/%d files, %d chunks%s",
+			styles.BadgeWarning.Render("INDEXING"), st.FilesProcessed, st.FilesTotal, st.ChunksWritten, elapsed))
+		lines = append(lines, "    "+renderProgressBar(st.FilesProcessed*100/st.FilesTotal, width-8))
+	case mem.Dirty:
+		status := "    Status: " + styles.LogWarn.Render("DIRTY (needs reindex)")
+		if a.writesAllowed() {
+			status += "  " + styles.Muted.Render("i: reindex")
+		}
+		lines = append(lines, status)
+	default:
+		line := "    Status: " + styles.StatusOK.Render("CLEAN")
+		if !r.completedAt.IsZero() {
+			done := fmt.Sprintf(" (reindexed %s ago", formatAge(time.Since(r.completedAt).Milliseconds()))
+			if r.duration > 0 {
+				done += " in " + formatAge(r.duration.Milliseconds())
+			}
+			line += styles.Muted.Render(done + ")")
+		}
+		lines = append(lines, line)
+	}
+
+	if r.err != "" {
+		lines = append(lines, "    "+styles.LogError.Render(r.err))
+	} else if r.status != nil && r.status.Error != "" {
+		lines = append(lines, "    "+styles.LogError.Render(r.status.Error))
+	}
+
+	return lines
+}
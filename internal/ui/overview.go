@@ -0,0 +1,400 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/config"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// maxLatencySamples is how many status refreshes the latency sparkline covers
+const maxLatencySamples = 60
+
+// recordLatency appends a gateway latency sample; unreachable polls are
+// recorded as -1 and drawn as gaps
+func (a *App) recordLatency(gw *models.GatewayInfo) {
+	sample := -1
+	if gw.Reachable {
+		sample = gw.ConnectLatencyMs
+	}
+	a.latencySamples = append(a.latencySamples, sample)
+	if len(a.latencySamples) > maxLatencySamples {
+		a.latencySamples = a.latencySamples[len(a.latencySamples)-maxLatencySamples:]
+	}
+}
+
+// renderOverviewWidget renders a single Overview widget by name
+func (a *App) renderOverviewWidget(widget string, width int) []string {
+	switch widget {
+	case config.WidgetQuickStatus:
+		return a.renderQuickStatusWidget()
+	case config.WidgetAlerts:
+		return a.renderAlertsWidget(width)
+	case config.WidgetChannels:
+		return a.renderChannelsWidget()
+	case config.WidgetModel:
+		return a.renderModelWidget()
+	case config.WidgetMemory:
+		return a.renderMemoryWidget()
+	case config.WidgetRecentSessions:
+		return a.renderRecentSessionsWidget()
+	case config.WidgetLatency:
+		return a.renderLatencyWidget(width)
+	case config.WidgetGauges:
+		return a.renderGaugesWidget(width)
+	}
+	return nil
+}
+
+func (a *App) renderQuickStatusWidget() []string {
+	var lines []string
+	status := a.openclawStatus
+
+	lines = append(lines, styles.HelpSection.Render("Quick Status"))
+	lines = append(lines, "")
+
+	// Gateway status with latency
+	if status.Gateway != nil {
+		gw := status.Gateway
+		if gw.Reachable {
+			lines = append(lines, fmt.Sprintf("  Gateway:    %s (%dms latency)",
+				styles.BadgeOK.Render("ONLINE"), gw.ConnectLatencyMs))
+		} else {
+			lines = append(lines, "  Gateway:    "+styles.BadgeError.Render("OFFLINE"))
+		}
+	}
+
+	// Service status compact
+	if status.GatewayService != nil && status.GatewayService.Installed {
+		if contains(status.GatewayService.RuntimeShort, "running") {
+			lines = append(lines, "  Service:    "+styles.BadgeOK.Render("RUNNING"))
+		} else {
+			lines = append(lines, "  Service:    "+styles.BadgeError.Render("STOPPED"))
+		}
+	}
+
+	// Sessions count
+	if status.Sessions != nil {
+		lines = append(lines, fmt.Sprintf("  Sessions:   %s active",
+			a.highlightChange("sessions", fmt.Sprintf("%d", status.Sessions.Count), styles.LabelValueHighlight)))
+	}
+
+	// Agents count
+	if status.Agents != nil {
+		lines = append(lines, fmt.Sprintf("  Agents:     %s configured (default: %s)",
+			a.highlightChange("agents", fmt.Sprintf("%d", len(status.Agents.Agents)), lipgloss.NewStyle()), status.Agents.DefaultID))
+	}
+
+	// Security summary with colored badges
+	if status.SecurityAudit != nil {
+		summary := status.SecurityAudit.Summary
+		secLine := "  Security:   "
+		if summary.Critical > 0 {
+			secLine += a.highlightChange("security.critical", fmt.Sprintf(" %d ", summary.Critical), styles.SeverityCritical)
+		}
+		if summary.Warn > 0 {
+			secLine += a.highlightChange("security.warn", fmt.Sprintf(" %d ", summary.Warn), styles.SeverityWarn)
+		}
+		if summary.Critical == 0 && summary.Warn == 0 {
+			secLine += styles.BadgeOK.Render("OK")
+		}
+		lines = append(lines, secLine)
+	}
+	return append(lines, "")
+}
+
+// renderAlertsWidget lists conditions that need attention across all tabs
+func (a *App) renderAlertsWidget(width int) []string {
+	status := a.openclawStatus
+	var errs, warns []string
+
+	if status.Gateway != nil && !status.Gateway.Reachable {
+		errs = append(errs, "Gateway unreachable")
+	}
+	if status.GatewayService != nil && status.GatewayService.Installed &&
+		!contains(status.GatewayService.RuntimeShort, "running") {
+		errs = append(errs, "Gateway service stopped")
+	}
+	if status.SecurityAudit != nil && status.SecurityAudit.Summary.Critical > 0 {
+		errs = append(errs, fmt.Sprintf("%d critical security findings", status.SecurityAudit.Summary.Critical))
+	}
+	if lc := status.LinkChannel; lc != nil {
+		if !lc.Linked {
+			errs = append(errs, fmt.Sprintf("%s not linked", lc.Label))
+		} else if days := a.config.Channels.AuthExpiryDays; days > 0 {
+			expiryMs := (time.Duration(days) * 24 * time.Hour).Milliseconds()
+			if int64(lc.AuthAgeMs) >= expiryMs {
+				errs = append(errs, fmt.Sprintf("%s auth expired", lc.Label))
+			} else if int64(lc.AuthAgeMs)*100/expiryMs >= 80 {
+				warns = append(warns, fmt.Sprintf("%s needs re-auth in %s", lc.Label, formatAge(expiryMs-int64(lc.AuthAgeMs))))
+			}
+		}
+	}
+	if status.Sessions != nil {
+		full := 0
+		for _, sess := range status.Sessions.Recent {
+			if sess.PercentUsed >= 80 {
+				full++
+			}
+		}
+		if full > 0 {
+			warns = append(warns, fmt.Sprintf("%d sessions above 80%% context", full))
+		}
+	}
+	if status.Memory != nil && status.Memory.Dirty {
+		warns = append(warns, "Memory index needs refresh")
+	}
+	if latest := a.availableUpdate(); latest != "" {
+		warns = append(warns, fmt.Sprintf("Gateway update available (%s)", latest))
+	}
+
+	lines := []string{styles.HelpSection.Render("Alerts")}
+	if len(errs) == 0 && len(warns) == 0 {
+		lines = append(lines, "  "+styles.StatusOK.Render("●")+" "+styles.Muted.Render("Nothing needs attention"))
+		return append(lines, "")
+	}
+	for _, e := range errs {
+		lines = append(lines, "  "+styles.StatusDown.Render("●")+" "+truncate(e, width-6))
+	}
+	for _, w := range warns {
+		lines = append(lines, "  "+styles.LogWarn.Render("●")+" "+truncate(w, width-6))
+	}
+	return append(lines, "")
+}
+
+func (a *App) renderChannelsWidget() []string {
+	status := a.openclawStatus
+	if len(status.ChannelSummary) == 0 {
+		return nil
+	}
+
+	lines := []string{styles.HelpSection.Render("Channels")}
+	for _, ch := range status.ChannelSummary {
+		if ch != "" && ch[0] != ' ' {
+			// Colorize based on status
+			if contains(ch, "linked") {
+				lines = append(lines, "  "+styles.StatusOK.Render("●")+" "+ch)
+			} else if contains(ch, "configured") {
+				lines = append(lines, "  "+styles.StatusOK.Render("●")+" "+ch)
+			} else {
+				lines = append(lines, "  "+styles.Muted.Render("○")+" "+ch)
+			}
+		}
+	}
+	return append(lines, "")
+}
+
+func (a *App) renderModelWidget() []string {
+	status := a.openclawStatus
+	if status.Sessions == nil {
+		return nil
+	}
+
+	var lines []string
+	lines = append(lines, styles.HelpSection.Render("Model Configuration"))
+	lines = append(lines, fmt.Sprintf("  Model:   %s", styles.LabelValueHighlight.Render(status.Sessions.Defaults.Model)))
+	lines = append(lines, fmt.Sprintf("  Context: %s tokens", formatNumber(status.Sessions.Defaults.ContextTokens)))
+	return append(lines, "")
+}
+
+func (a *App) renderMemoryWidget() []string {
+	status := a.openclawStatus
+	if status.Memory == nil {
+		return nil
+	}
+
+	lines := []string{styles.HelpSection.Render("Memory (RAG)")}
+	features := []string{}
+	if status.Memory.Vector.Enabled && status.Memory.Vector.Available {
+		features = append(features, "vector")
+	}
+	if status.Memory.FTS.Enabled && status.Memory.FTS.Available {
+		features = append(features, "FTS")
+	}
+	if status.Memory.Cache.Enabled {
+		features = append(features, "cache")
+	}
+	lines = append(lines, fmt.Sprintf("  %d files, %d chunks [%s]",
+		status.Memory.Files, status.Memory.Chunks, strings.Join(features, ", ")))
+	if status.Memory.Dirty {
+		lines = append(lines, "  "+styles.LogWarn.Render("Index needs refresh"))
+	}
+	return append(lines, "")
+}
+
+func (a *App) renderRecentSessionsWidget() []string {
+	status := a.openclawStatus
+	if status.Sessions == nil || len(status.Sessions.Recent) == 0 {
+		return nil
+	}
+
+	lines := []string{styles.HelpSection.Render("Recent Activity")}
+	maxRecent := 5
+	if len(status.Sessions.Recent) < maxRecent {
+		maxRecent = len(status.Sessions.Recent)
+	}
+	for _, sess := range status.Sessions.Recent[:maxRecent] {
+		age := formatAge(sess.Age)
+		pct := sess.PercentUsed
+
+		// Mini progress indicator
+		var pctStyle lipgloss.Style
+		if pct >= 80 {
+			pctStyle = styles.LogError
+		} else if pct >= 50 {
+			pctStyle = styles.LogWarn
+		} else {
+			pctStyle = styles.Muted
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s %s (%s ago) %s",
+			styles.Muted.Render("●"),
+			truncate(sess.Key, 40),
+			age,
+			pctStyle.Render(fmt.Sprintf("%d%%", pct))))
+	}
+	return append(lines, "")
+}
+
+// renderLatencyWidget renders a sparkline of recent gateway latency samples
+func (a *App) renderLatencyWidget(width int) []string {
+	lines := []string{styles.HelpSection.Render("Gateway Latency")}
+	if len(a.latencySamples) == 0 {
+		lines = append(lines, styles.Muted.Render("  Collecting samples..."))
+		return append(lines, "")
+	}
+
+	samples := a.latencySamples
+	if maxWidth := width - 4; maxWidth > 0 && len(samples) > maxWidth {
+		samples = samples[len(samples)-maxWidth:]
+	}
+
+	lo, hi, sum, n := -1, 0, 0, 0
+	for _, s := range samples {
+		if s < 0 {
+			continue
+		}
+		if lo < 0 || s < lo {
+			lo = s
+		}
+		if s > hi {
+			hi = s
+		}
+		sum += s
+		n++
+	}
+
+	lines = append(lines, "  "+sparkline(samples, lo, hi))
+	if n > 0 {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  min %dms  avg %dms  max %dms  last %s",
+			lo, sum/n, hi, formatLatencySample(samples[len(samples)-1]))))
+	} else {
+		lines = append(lines, "  "+styles.LogError.Render("Gateway unreachable"))
+	}
+	return append(lines, "")
+}
+
+// memoryStaleAfter is the index age at which the freshness gauge is full
+const memoryStaleAfter = 24 * time.Hour
+
+// renderGaugesWidget renders compact gauges for aggregate context usage,
+// memory index staleness and channel auth age. Fuller bars are worse.
+func (a *App) renderGaugesWidget(width int) []string {
+	status := a.openclawStatus
+	lines := []string{styles.HelpSection.Render("Gauges")}
+	barWidth := min(max(width-40, 12), 40)
+	gauge := func(label string, pct int, caption string) {
+		pct = min(max(pct, 0), 100)
+		lines = append(lines, fmt.Sprintf("  %-9s %s  %s", label, renderProgressBar(pct, barWidth), styles.Muted.Render(caption)))
+	}
+
+	// Aggregate context usage across sessions with a known context window
+	used, capacity, n := 0, 0, 0
+	for _, sess := range allSessions(status.Sessions) {
+		if sess.ContextTokens > 0 {
+			used += sess.TotalTokens
+			capacity += sess.ContextTokens
+			n++
+		}
+	}
+	if capacity > 0 {
+		gauge("Context", used*100/capacity, fmt.Sprintf("%s of %s tokens, %d sessions",
+			formatNumber(used), formatNumber(capacity), n))
+	}
+
+	// Memory index staleness, aged from the last known index time
+	if status.Memory != nil {
+		pct, caption := 0, "clean"
+		if status.Memory.Dirty {
+			pct, caption = 100, "needs refresh"
+		}
+		if indexed := a.memoryLastIndexed(); !indexed.IsZero() {
+			age := time.Since(indexed)
+			if !status.Memory.Dirty {
+				pct = int(age * 100 / memoryStaleAfter)
+			}
+			caption = fmt.Sprintf("indexed %s ago, %s", formatAge(age.Milliseconds()), caption)
+		}
+		gauge("Memory", pct, caption)
+	}
+
+	// Channel auth age against the configured expiry
+	if lc := status.LinkChannel; lc != nil && lc.Linked && a.config.Channels.AuthExpiryDays > 0 {
+		expiryMs := (time.Duration(a.config.Channels.AuthExpiryDays) * 24 * time.Hour).Milliseconds()
+		remaining := expiryMs - int64(lc.AuthAgeMs)
+		caption := "re-auth in " + formatAge(remaining)
+		if remaining <= 0 {
+			caption = "auth expired"
+		}
+		gauge("Auth", int(int64(lc.AuthAgeMs)*100/expiryMs), lc.Label+" "+caption)
+	}
+
+	if len(lines) == 1 {
+		return nil
+	}
+	return append(lines, "")
+}
+
+// memoryLastIndexed returns the most recent known memory index time: a
+// reindex completed in this session or the newest indexed file, if browsed
+func (a *App) memoryLastIndexed() time.Time {
+	last := a.reindex.completedAt
+	for _, f := range a.memoryBrowser.files {
+		if f.IndexedAt > 0 {
+			if t := time.UnixMilli(f.IndexedAt); t.After(last) {
+				last = t
+			}
+		}
+	}
+	return last
+}
+
+// sparkline renders samples as block characters scaled between lo and hi;
+// negative samples render as gaps
+func sparkline(samples []int, lo, hi int) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	var b strings.Builder
+	for _, s := range samples {
+		if s < 0 {
+			b.WriteString(styles.LogError.Render("·"))
+			continue
+		}
+		idx := 0
+		if hi > lo {
+			idx = (s - lo) * (len(blocks) - 1) / (hi - lo)
+		}
+		b.WriteString(styles.Primary.Render(string(blocks[idx])))
+	}
+	return b.String()
+}
+
+func formatLatencySample(ms int) string {
+	if ms < 0 {
+		return "unreachable"
+	}
+	return fmt.Sprintf("%dms", ms)
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// pairingPollInterval is how often the link status is fetched while the
+// pairing modal is open
+const pairingPollInterval = 3 * time.Second
+
+// pairingOutputLimit is how many of the pairing command's latest text lines
+// the modal shows
+const pairingOutputLimit = 6
+
+// qrQuietZone is the light margin drawn around a QR code, in modules
+const qrQuietZone = 2
+
+// PairingOutputMsg carries something the running pairing command printed
+type PairingOutputMsg struct {
+	Event gateway.PairingEvent
+
+	seq  int
+	next tea.Cmd // Waits for the following event or the result
+}
+
+// PairingDoneMsg is sent when the pairing command exits
+type PairingDoneMsg struct {
+	Error error
+
+	seq int
+}
+
+// pairingPollMsg asks for the link status while pairing
+type pairingPollMsg struct {
+	seq int
+}
+
+// channelPairing holds the pairing modal: the running pairing command, the
+// latest QR code it drew and the rest of its output
+type channelPairing struct {
+	seq    int // Bumped per pairing, to drop a closed one's messages
+	cancel context.CancelFunc
+
+	instance string
+	channel  string
+	label    string
+	unlinked bool // Seen unlinked, so a linked status is news
+	qr       [][]bool
+	output   []string
+	exited   bool
+	err      error
+}
+
+// confirmPairing asks to confirm linking target, then pairs it
+func (a *App) confirmPairing(target channelTarget) tea.Cmd {
+	return a.confirmWrite(writeRequest{
+		title:   "Link " + target.label,
+		prompt:  fmt.Sprintf("Link %s on %s?", target.label, a.cliAdapter().GetInstanceName()),
+		details: []string{"Its QR code is shown to scan while the command runs."},
+		preview: func(c *gateway.CLIAdapter) error {
+			return c.PairChannel(context.Background(), target.id, func(gateway.PairingEvent) {})
+		},
+		run:       func() tea.Cmd { return a.startPairing(target) },
+		ownOutput: true,
+	})
+}
+
+// startPairing runs the pairing command of target in the pairing modal,
+// which shows its QR code and polls the link status until the channel is
+// linked
+func (a *App) startPairing(target channelTarget) tea.Cmd {
+	adapter := a.cliAdapter()
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &a.pairing
+	*p = channelPairing{
+		seq:      p.seq + 1,
+		cancel:   cancel,
+		instance: adapter.GetInstanceName(),
+		channel:  target.id,
+		label:    target.label,
+		unlinked: !target.linked,
+	}
+	a.mode = ModePairing
+
+	seq, channel := p.seq, p.channel
+	ch := make(chan tea.Msg, 16)
+	run := func() tea.Msg {
+		go func() {
+			err := adapter.PairChannel(ctx, channel, func(event gateway.PairingEvent) {
+				ch <- PairingOutputMsg{Event: event, seq: seq}
+			})
+			ch <- PairingDoneMsg{Error: err, seq: seq}
+		}()
+		return waitForPairing(ch)()
+	}
+	return tea.Batch(run, schedulePairingPoll(seq))
+}
+
+// waitForPairing waits for the next thing the pairing command prints, or its
+// result
+func waitForPairing(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg := <-ch
+		if out, ok := msg.(PairingOutputMsg); ok {
+			out.next = waitForPairing(ch)
+			return out
+		}
+		return msg
+	}
+}
+
+func schedulePairingPoll(seq int) tea.Cmd {
+	return tea.Tick(pairingPollInterval, func(time.Time) tea.Msg {
+		return pairingPollMsg{seq: seq}
+	})
+}
+
+func (a *App) handlePairingOutput(msg PairingOutputMsg) tea.Cmd {
+	p := &a.pairing
+	if msg.seq == p.seq {
+		if msg.Event.QR != nil {
+			p.qr = msg.Event.QR
+		} else if strings.TrimSpace(msg.Event.Line) != "" {
+			p.output = append(p.output, msg.Event.Line)
+			if len(p.output) > pairingOutputLimit {
+				p.output = p.output[len(p.output)-pairingOutputLimit:]
+			}
+		}
+	}
+	// Keep draining a closed pairing's output until its command ends
+	return msg.next
+}
+
+func (a *App) handlePairingDone(msg PairingDoneMsg) {
+	p := &a.pairing
+	if msg.seq != p.seq || errors.Is(msg.Error, gateway.ErrInterrupted) {
+		return
+	}
+	p.exited = true
+	p.err = msg.Error
+	if a.mode != ModePairing && msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+	}
+}
+
+// pollPairing fetches the link status while the pairing modal is open
+func (a *App) pollPairing(msg pairingPollMsg) tea.Cmd {
+	if msg.seq != a.pairing.seq || a.mode != ModePairing {
+		return nil
+	}
+	cmds := []tea.Cmd{a.fetchCLIStatus(), schedulePairingPoll(msg.seq)}
+	if a.channelsList != nil {
+		cmds = append(cmds, a.fetchCLIChannels())
+	}
+	return tea.Batch(cmds...)
+}
+
+// checkPairing closes the pairing modal once the status or channel list
+// reports the channel linked. Relinking, that only counts after they have
+// shown it unlinked.
+func (a *App) checkPairing() {
+	p := &a.pairing
+	if a.mode != ModePairing {
+		return
+	}
+	linked, known := a.channelLinked(p.channel)
+	if !known {
+		return
+	}
+	if !linked {
+		p.unlinked = true
+		return
+	}
+	if p.unlinked {
+		a.closePairing()
+		a.setFlash(p.label+" linked", false)
+	}
+}
+
+// closePairing closes the pairing modal, stopping the pairing command
+func (a *App) closePairing() {
+	a.pairing.cancel()
+	a.mode = ModeNormal
+}
+
+// handlePairingKey handles keys while the pairing modal is open
+func (a *App) handlePairingKey(msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, a.keys.Escape) || msg.String() == "q" {
+		a.closePairing()
+	}
+	return nil
+}
+
+// renderPairingModal renders the pairing modal
+func (a *App) renderPairingModal() string {
+	p := &a.pairing
+	content := styles.HelpTitle.Render(fmt.Sprintf("Link %s on %s", p.label, p.instance)) + "\n\n"
+	width := max(a.width-12, 40)
+
+	switch {
+	case p.qr != nil:
+		content += "Scan this code in " + p.label + " (Settings > Linked devices):\n\n"
+		content += renderQR(p.qr) + "\n\n"
+	case !p.exited:
+		content += styles.Muted.Render("Waiting for a QR code...") + "\n\n"
+	}
+	for _, line := range p.output {
+		content += styles.Muted.Render(truncate(line, width)) + "\n"
+	}
+
+	switch {
+	case p.err != nil:
+		content += "\n" + styles.LogError.Render(truncate(p.err.Error(), width)) + "\n"
+	case p.exited:
+		content += "\n" + styles.Muted.Render("Pairing command finished; checking link status...") + "\n"
+	default:
+		content += "\n" + styles.Muted.Render(fmt.Sprintf("Checking link status every %s...", pairingPollInterval)) + "\n"
+	}
+
+	content += "\n" + styles.HintKey.Render("esc") + styles.Muted.Render(":cancel")
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// renderQR draws a QR code with half blocks, two rows of modules per line,
+// inside a quiet zone
+func renderQR(modules [][]bool) string {
+	n := len(modules) + 2*qrQuietZone
+	dark := func(r, c int) bool {
+		r, c = r-qrQuietZone, c-qrQuietZone
+		return r >= 0 && c >= 0 && r < len(modules) && c < len(modules) && modules[r][c]
+	}
+	lines := make([]string, 0, (n+1)/2)
+	for r := 0; r < n; r += 2 {
+		var b strings.Builder
+		for c := range n {
+			switch top, bottom := dark(r, c), dark(r+1, c); {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		lines = append(lines, styles.QRCode.Render(b.String()))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// KeyCheckedMsg is sent once an instance whose SSH login failed has had its
+// identity file checked for a passphrase
+type KeyCheckedMsg struct {
+	Instance string
+	Needed   bool
+}
+
+// KeyUnlockedMsg is sent when a passphrase has been tried on an instance's
+// identity file
+type KeyUnlockedMsg struct {
+	Instance string
+	Error    error
+}
+
+// passphrasePrompt asks for the passphrase of an instance's identity file,
+// which ssh cannot ask for itself as it runs in batch mode
+type passphrasePrompt struct {
+	input    textinput.Model
+	instance string          // Instance the prompt is open for
+	checked  map[string]bool // Instances whose key was checked; each is prompted for once
+	retry    bool            // The last passphrase was wrong
+}
+
+func newPassphrasePrompt() passphrasePrompt {
+	in := textinput.New()
+	in.EchoMode = textinput.EchoPassword
+	in.EchoCharacter = '•'
+	in.CharLimit = 1024
+	return passphrasePrompt{input: in, checked: make(map[string]bool)}
+}
+
+// checkPassphrase checks, once per instance, whether an SSH login failure
+// is due to a passphrase-protected key
+func (a *App) checkPassphrase(err error) tea.Cmd {
+	cli := a.cliAdapter()
+	if cli == nil || gateway.KindOf(err) != gateway.ErrorSSHAuth {
+		return nil
+	}
+	name := cli.GetInstanceName()
+	if a.passphrase.checked[name] {
+		return nil
+	}
+	a.passphrase.checked[name] = true
+	return func() tea.Msg {
+		return KeyCheckedMsg{Instance: name, Needed: cli.KeyNeedsPassphrase()}
+	}
+}
+
+// handleKeyChecked opens the prompt if the key needs a passphrase and the
+// user is still on that instance and not typing elsewhere
+func (a *App) handleKeyChecked(msg KeyCheckedMsg) tea.Cmd {
+	cli := a.cliAdapter()
+	if !msg.Needed || cli == nil || cli.GetInstanceName() != msg.Instance || a.mode != ModeNormal {
+		return nil
+	}
+	return a.openPassphrasePrompt(msg.Instance, false)
+}
+
+func (a *App) openPassphrasePrompt(instance string, retry bool) tea.Cmd {
+	p := &a.passphrase
+	p.instance = instance
+	p.retry = retry
+	p.input.Reset()
+	p.input.Focus()
+	a.mode = ModePassphrase
+	return textinput.Blink
+}
+
+// closePassphrasePrompt closes the prompt, wiping what was typed
+func (a *App) closePassphrasePrompt() {
+	a.passphrase.input.Reset()
+	a.passphrase.input.Blur()
+	a.mode = ModeNormal
+}
+
+// submitPassphrase unlocks the key with the typed passphrase
+func (a *App) submitPassphrase() tea.Cmd {
+	passphrase := a.passphrase.input.Value()
+	instance := a.passphrase.instance
+	a.closePassphrasePrompt()
+
+	cli := a.cliAdapter()
+	if cli == nil || cli.GetInstanceName() != instance || passphrase == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		return KeyUnlockedMsg{Instance: instance, Error: cli.UnlockKey(passphrase)}
+	}
+}
+
+// handleKeyUnlocked asks again after a wrong passphrase, and otherwise
+// fetches again now that ssh can use the key
+func (a *App) handleKeyUnlocked(msg KeyUnlockedMsg) tea.Cmd {
+	cli := a.cliAdapter()
+	current := cli != nil && cli.GetInstanceName() == msg.Instance
+	switch {
+	case errors.Is(msg.Error, gateway.ErrBadPassphrase):
+		if current && a.mode == ModeNormal {
+			return a.openPassphrasePrompt(msg.Instance, true)
+		}
+		return nil
+	case msg.Error != nil:
+		a.setFlash("Unlocking key failed: "+msg.Error.Error(), true)
+		return nil
+	}
+	a.setFlash("Key unlocked for "+msg.Instance, false)
+	if !current {
+		return nil
+	}
+	return tea.Batch(a.fetchCLIStatus(), a.fetchCLIHealth())
+}
+
+// passphraseLabel is the prompt shown before the passphrase input
+func (a *App) passphraseLabel() string {
+	file := a.passphrase.instance
+	if cli := a.cliAdapter(); cli != nil && cli.SSHConfig != nil {
+		file = cli.SSHConfig.IdentityFile
+	}
+	if a.passphrase.retry {
+		return "Wrong passphrase. Passphrase for " + file + ": "
+	}
+	return "Passphrase for " + file + ": "
+}
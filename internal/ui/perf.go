@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// perfWindow is how many recent frames the performance overlay summarizes
+const perfWindow = 60
+
+// perfMaxCommands is how many of the slowest CLI commands the overlay lists
+const perfMaxCommands = 4
+
+// perfStats measures the UI loop for the performance overlay. Update and View
+// run on the Bubble Tea goroutine; only the command counter is shared.
+type perfStats struct {
+	update []time.Duration // Most recent last
+	view   []time.Duration
+
+	// Commands started but not yet returned, including timers and stream
+	// reads waiting for their next message
+	pending atomic.Int32
+
+	msgs      int // Messages since rateStart
+	rateStart time.Time
+	rate      int // Messages in the last full second
+}
+
+func addFrameSample(samples *[]time.Duration, d time.Duration) {
+	*samples = append(*samples, d)
+	if len(*samples) > perfWindow {
+		*samples = (*samples)[1:]
+	}
+}
+
+// timeUpdate records an Update call that began at start. It must be deferred.
+func (p *perfStats) timeUpdate(start time.Time) {
+	addFrameSample(&p.update, time.Since(start))
+	p.msgs++
+	if elapsed := start.Sub(p.rateStart); elapsed >= time.Second {
+		p.rate = int(float64(p.msgs) / elapsed.Seconds())
+		p.msgs, p.rateStart = 0, start
+	}
+}
+
+// timeView records a View call that began at start. It must be deferred.
+func (p *perfStats) timeView(start time.Time) {
+	addFrameSample(&p.view, time.Since(start))
+}
+
+// renderFrameTimes summarizes a frame duration series on one line
+func renderFrameTimes(label string, samples []time.Duration) string {
+	if len(samples) == 0 {
+		return fmt.Sprintf("  %-7s -", label)
+	}
+	var total, worst time.Duration
+	ms := make([]int, len(samples))
+	for i, d := range samples {
+		total += d
+		worst = max(worst, d)
+		ms[i] = int(d.Microseconds())
+	}
+	avg := total / time.Duration(len(samples))
+	return fmt.Sprintf("  %-7s last %-8s avg %-8s max %-8s %s", label,
+		formatFrameTime(samples[len(samples)-1]), formatFrameTime(avg), formatFrameTime(worst),
+		sparkline(ms, 0, int(worst.Microseconds())))
+}
+
+func formatFrameTime(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+	return d.Round(100 * time.Microsecond).String()
+}
+
+// renderPerfOverlay renders the performance panel shown above the bottom bar
+func (a *App) renderPerfOverlay() string {
+	p := &a.perf
+	lines := []string{
+		styles.HelpSection.Render("Performance") + "  " + styles.Muted.Render("("+a.keys.PerfOverlay.Help().Key+" to close)"),
+		renderFrameTimes("update", p.update),
+		renderFrameTimes("view", p.view),
+	}
+
+	pool := gateway.Stats()
+	lines = append(lines, fmt.Sprintf("  msgs/s %d · cmds pending %d · CLI %d/%d running, %d queued · goroutines %d",
+		p.rate, p.pending.Load(), pool.Running, pool.Size, pool.Queued, runtime.NumGoroutine()))
+
+	// The commands costing the most time overall
+	stats := gateway.CommandStats()
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Total > stats[j].Total })
+	for _, s := range stats[:min(len(stats), perfMaxCommands)] {
+		line := fmt.Sprintf("  %-18s %4d calls  avg %-8s max %-8s last %s",
+			truncate(s.Name, 18), s.Calls, s.Avg().Round(time.Millisecond),
+			s.Max.Round(time.Millisecond), s.Last.Round(time.Millisecond))
+		if s.Errors > 0 {
+			line += styles.LogWarn.Render(fmt.Sprintf("  %d failed", s.Errors))
+		}
+		lines = append(lines, line)
+	}
+	if len(stats) == 0 {
+		lines = append(lines, styles.Muted.Render("  No CLI commands run yet"))
+	}
+
+	return styles.PaneBorder.Width(a.width - 2).Render(strings.Join(lines, "\n"))
+}
+
+// layoutWithPerfOverlay returns the content height left for the panes and
+// the overlay to show below them, if enabled
+func (a *App) layoutWithPerfOverlay(contentHeight int) (int, string) {
+	if !a.showPerf {
+		return contentHeight, ""
+	}
+	overlay := a.renderPerfOverlay()
+	return max(contentHeight-lipgloss.Height(overlay), 3), overlay
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// ProcessesMsg is sent when a process listing completes
+type ProcessesMsg struct {
+	Processes []models.ProcessInfo
+	Error     error
+}
+
+// ProcessSignalMsg is sent when a signal has been sent to a process
+type ProcessSignalMsg struct {
+	PID    int
+	Signal string
+	Error  error
+}
+
+func (m ProcessSignalMsg) failure() error { return m.Error }
+
+// processList holds the System tab process listing
+type processList struct {
+	procs  []models.ProcessInfo
+	loaded bool
+	err    string
+}
+
+func (a *App) fetchProcesses() tea.Cmd {
+	adapter := a.cliAdapter()
+	if adapter == nil {
+		return nil
+	}
+	return a.scoped(func() tea.Msg {
+		procs, err := adapter.ListProcesses()
+		return ProcessesMsg{Processes: procs, Error: err}
+	})
+}
+
+func (a *App) handleProcesses(msg ProcessesMsg) {
+	p := &a.processes
+	if msg.Error != nil {
+		p.err = msg.Error.Error()
+		return
+	}
+	p.err = ""
+	p.loaded = true
+	p.procs = msg.Processes
+	// Keep the selection inside the list when processes exit
+	a.moveSystemCursor(0)
+}
+
+// selectedProcess returns the selected process, or nil if a service is selected
+func (a *App) selectedProcess() *models.ProcessInfo {
+	idx := a.services.cursor - len(managedServices)
+	if idx < 0 || idx >= len(a.processes.procs) {
+		return nil
+	}
+	return &a.processes.procs[idx]
+}
+
+// signalProcess asks to confirm sending signal ("TERM" or "KILL") to the
+// selected process, by typing the instance name
+func (a *App) signalProcess(signal string) tea.Cmd {
+	proc := a.selectedProcess()
+	if proc == nil || a.cliAdapter() == nil {
+		return nil
+	}
+	if !a.canWrite("Signalling processes") {
+		return nil
+	}
+	pid := proc.PID
+	req := writeRequest{
+		title:       "Send SIG" + signal,
+		prompt:      fmt.Sprintf("Send SIG%s to process %d on %s?", signal, pid, a.cliAdapter().GetInstanceName()),
+		details:     []string{proc.Command},
+		destructive: true,
+		preview:     func(c *gateway.CLIAdapter) error { return c.SignalProcess(pid, signal) },
+		run:         func() tea.Cmd { return a.sendSignal(pid, signal) },
+	}
+	if signal == "KILL" {
+		req.warning = "It is killed at once, without a chance to clean up."
+	}
+	return a.confirmWrite(req)
+}
+
+// sendSignal sends the confirmed signal
+func (a *App) sendSignal(pid int, signal string) tea.Cmd {
+	return func() tea.Msg {
+		adapter := a.cliAdapter()
+		if adapter == nil {
+			return ProcessSignalMsg{PID: pid, Signal: signal, Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		return ProcessSignalMsg{PID: pid, Signal: signal, Error: adapter.SignalProcess(pid, signal)}
+	}
+}
+
+func (a *App) handleProcessSignal(msg ProcessSignalMsg) tea.Cmd {
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	a.setFlash(fmt.Sprintf("Sent SIG%s to %d", msg.Signal, msg.PID), false)
+	return a.fetchProcesses()
+}
+
+// renderProcessesSection renders openclaw-related processes on the host
+func (a *App) renderProcessesSection(width int) []string {
+	p := &a.processes
+	title := styles.HelpSection.Render("Processes")
+	if a.writesAllowed() {
+		title += "  " + styles.Muted.Render("T: SIGTERM  K: SIGKILL")
+	}
+	lines := []string{title}
+
+	if p.err != "" {
+		lines = append(lines, "  "+styles.LogError.Render(truncate(p.err, width-4)))
+		return append(lines, "")
+	}
+	if !p.loaded {
+		lines = append(lines, styles.Muted.Render("  Loading..."))
+		return append(lines, "")
+	}
+	if len(p.procs) == 0 {
+		lines = append(lines, styles.Muted.Render("  No openclaw processes found"))
+		return append(lines, "")
+	}
+
+	lines = append(lines, styles.TableHeader.Render(fmt.Sprintf("  %7s %6s %9s %9s  %s", "PID", "CPU%", "RSS", "UPTIME", "COMMAND")))
+	selected := a.selectedProcess()
+	for i := range p.procs {
+		proc := &p.procs[i]
+		row := fmt.Sprintf("  %7d %6.1f %9s %9s  %s", proc.PID, proc.CPU,
+			formatBytes(proc.RSSKB*1024), formatAge(proc.Uptime.Milliseconds()),
+			truncate(proc.Command, max(width-40, 10)))
+		if proc == selected && a.focusedPane == PaneDetails {
+			row = styles.TableRowSelected.Render(row)
+		}
+		lines = append(lines, row)
+	}
+	return append(lines, "")
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// maxQueueSamples is how many status refreshes each depth sparkline covers
+const maxQueueSamples = 60
+
+// queueStaleAge is the oldest-item age at which a queue counts as stuck
+const queueStaleAge = 5 * time.Minute
+
+// recordQueueDepths appends a depth sample for every reported queue. Queues
+// no longer reported keep their history until the instance changes.
+func (a *App) recordQueueDepths(queues []models.QueueInfo) {
+	if len(queues) == 0 {
+		return
+	}
+	if a.queueDepths == nil {
+		a.queueDepths = make(map[string][]int)
+	}
+	for _, q := range queues {
+		samples := append(a.queueDepths[q.Name], q.Depth)
+		if len(samples) > maxQueueSamples {
+			samples = samples[len(samples)-maxQueueSamples:]
+		}
+		a.queueDepths[q.Name] = samples
+	}
+}
+
+// queues returns the queues reported in status
+func (a *App) queues() []models.QueueInfo {
+	if a.openclawStatus == nil {
+		return nil
+	}
+	return a.openclawStatus.Queues
+}
+
+// staleQueues counts queues whose oldest pending item exceeds queueStaleAge
+func (a *App) staleQueues() int {
+	n := 0
+	for _, q := range a.queues() {
+		if q.Depth > 0 && time.Duration(q.OldestAgeMs)*time.Millisecond >= queueStaleAge {
+			n++
+		}
+	}
+	return n
+}
+
+// queueTrend describes depth growth over the sampled window
+func queueTrend(samples []int) string {
+	if len(samples) < 2 {
+		return ""
+	}
+	delta := samples[len(samples)-1] - samples[0]
+	switch {
+	case delta > 0:
+		return styles.LogWarn.Render(fmt.Sprintf("▲ +%d", delta))
+	case delta < 0:
+		return styles.StatusOK.Render(fmt.Sprintf("▼ %d", delta))
+	}
+	return styles.Muted.Render("= 0")
+}
+
+func (a *App) renderQueuesTab(width, height int) string {
+	var lines []string
+	lines = append(lines, styles.HelpSection.Render("Queues & Backlogs"))
+	lines = append(lines, "")
+
+	if a.openclawStatus == nil {
+		lines = append(lines, styles.Muted.Render("  No status loaded"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+	queues := a.queues()
+	if len(queues) == 0 {
+		lines = append(lines, styles.Muted.Render("  No queues reported by the gateway."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	nameWidth := 20
+	sparkWidth := min(max(width-nameWidth-40, 10), maxQueueSamples)
+	lines = append(lines, styles.TableHeader.Render(fmt.Sprintf("  %-*s %7s %7s %10s  %s",
+		nameWidth, "Queue", "Depth", "Active", "Oldest", "Depth over time")))
+	for _, q := range queues {
+		oldest := styles.Muted.Render(fmt.Sprintf("%10s", "-"))
+		if q.Depth > 0 && q.OldestAgeMs > 0 {
+			age := fmt.Sprintf("%10s", formatAge(q.OldestAgeMs))
+			if time.Duration(q.OldestAgeMs)*time.Millisecond >= queueStaleAge {
+				oldest = styles.LogError.Render(age)
+			} else {
+				oldest = age
+			}
+		}
+
+		samples := a.queueDepths[q.Name]
+		if len(samples) > sparkWidth {
+			samples = samples[len(samples)-sparkWidth:]
+		}
+		lo, hi := 0, 0
+		for _, s := range samples {
+			hi = max(hi, s)
+		}
+		spark := sparkline(samples, lo, hi) + " " + queueTrend(samples)
+
+		line := fmt.Sprintf("  %s %7d %7d %s  %s", padRight(q.Name, nameWidth), q.Depth, q.InFlight, oldest, spark)
+		lines = append(lines, line)
+		if q.Failed > 0 {
+			lines = append(lines, "    "+styles.LogError.Render(fmt.Sprintf("%d failed items", q.Failed)))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, styles.Muted.Render(fmt.Sprintf("  Oldest items past %s are highlighted; sparklines cover the last %d refreshes.",
+		formatAge(queueStaleAge.Milliseconds()), maxQueueSamples)))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// RemediationMsg is sent when a finding's remediation returns
+type RemediationMsg struct {
+	Instance string
+	Finding  models.SecurityAuditFinding
+	Error    error
+}
+
+func (m RemediationMsg) failure() error { return m.Error }
+
+// securityRemediation holds the Security tab selection and the applied fix
+// awaiting a fresh audit
+type securityRemediation struct {
+	cursor int // Index into the filtered findings
+	shown  int // Cursor last scrolled into view
+
+	// The finding whose fix is running or being verified
+	instance  string
+	finding   models.SecurityAuditFinding
+	running   bool
+	verifying bool
+}
+
+// moveSecurityCursor moves the Security tab selection by delta
+func (a *App) moveSecurityCursor(delta int) {
+	r := &a.remediation
+	r.cursor = min(max(r.cursor+delta, 0), max(len(a.filteredFindings())-1, 0))
+}
+
+// selectedFinding returns the finding selected in the Security tab
+func (a *App) selectedFinding() (models.SecurityAuditFinding, bool) {
+	findings := a.filteredFindings()
+	if len(findings) == 0 {
+		return models.SecurityAuditFinding{}, false
+	}
+	return findings[min(a.remediation.cursor, len(findings)-1)], true
+}
+
+// sameFinding reports whether a and b are the same finding, as audit runs
+// report it
+func sameFinding(a, b models.SecurityAuditFinding) bool {
+	return a.CheckID == b.CheckID && a.Title == b.Title
+}
+
+// confirmRemediation asks to confirm running the selected finding's
+// remediation, which runs in the host's shell
+func (a *App) confirmRemediation() tea.Cmd {
+	r := &a.remediation
+	finding, ok := a.selectedFinding()
+	if a.cliAdapter() == nil || !ok || r.running || r.verifying {
+		return nil
+	}
+	if finding.Remediation == "" {
+		a.setFlash("The finding has no remediation", true)
+		return nil
+	}
+	if !a.canWrite("Applying remediations") {
+		return nil
+	}
+	return a.confirmWrite(writeRequest{
+		title:       "Apply Fix",
+		prompt:      finding.Title,
+		warning:     "It runs in the host's shell, exactly as shown.",
+		destructive: true,
+		preview: func(c *gateway.CLIAdapter) error {
+			_, err := c.RunRemediation(finding.Remediation)
+			return err
+		},
+		run: func() tea.Cmd { return a.runRemediation(finding) },
+	})
+}
+
+// runRemediation runs the confirmed remediation, whose result is flashed
+func (a *App) runRemediation(finding models.SecurityAuditFinding) tea.Cmd {
+	r := &a.remediation
+	adapter := a.cliAdapter()
+	r.instance, r.finding, r.running = adapter.GetInstanceName(), finding, true
+	instance := r.instance
+	return func() tea.Msg {
+		_, err := adapter.RunRemediation(finding.Remediation)
+		return RemediationMsg{Instance: instance, Finding: finding, Error: err}
+	}
+}
+
+// handleRemediation re-runs the audit after a remediation, which
+// checkRemediation then looks for the finding in
+func (a *App) handleRemediation(msg RemediationMsg) tea.Cmd {
+	r := &a.remediation
+	if adapter := a.cliAdapter(); adapter == nil || adapter.GetInstanceName() != msg.Instance {
+		return nil
+	}
+	r.running = false
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return a.fetchCLIStatus()
+	}
+	r.verifying = true
+	a.setFlash("Applied the fix for "+msg.Finding.Title+"; re-running the audit...", false)
+	return a.fetchCLIStatus()
+}
+
+// checkRemediation reports whether the first audit after a remediation
+// still has its finding
+func (a *App) checkRemediation(msg CLIStatusMsg) {
+	r := &a.remediation
+	if !r.verifying || a.openclawStatus.SecurityAudit == nil ||
+		slices.Contains(msg.Excluded, gateway.StatusSectionSecurityAudit) {
+		return
+	}
+	r.verifying = false
+	if slices.ContainsFunc(a.openclawStatus.SecurityAudit.Findings, func(f models.SecurityAuditFinding) bool {
+		return sameFinding(f, r.finding)
+	}) {
+		a.setFlash(r.finding.Title+" is still reported after its fix", true)
+		return
+	}
+	a.setFlash(r.finding.Title+" cleared", false)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// renderCache memoizes the active tab's rendered content. An entry is reused
+// while the tab, its size and the state it depends on are unchanged. Entries
+// also expire every second so relative times ("5m ago") stay current, except
+// while the terminal is unfocused.
+type renderCache struct {
+	valid         bool
+	tab           Tab
+	width, height int
+	version       uint64
+	second        int64
+	content       string
+}
+
+// tabUsesStreams reports whether a tab renders log or event stream data
+func tabUsesStreams(t Tab) bool {
+	return t == TabLogs || t == TabEvents
+}
+
+// noteUpdate bumps the state versions a message may change. Stream messages
+// only dirty the tabs that show streams, so a busy log does not re-render
+// the other tabs; ticks that only schedule work change nothing on screen.
+// Status fetches dirty only the tabs whose sections changed (see
+// applyStatusDelta).
+// While the terminal is unfocused streams keep collecting but do not dirty
+// anything; regaining focus re-renders with everything that arrived.
+func (a *App) noteUpdate(msg tea.Msg) {
+	switch m := msg.(type) {
+	case RefreshTickMsg, BackgroundPollMsg, BackgroundStatusMsg:
+	case CLIStatusMsg:
+		if m.Error != nil {
+			a.stateVersion++
+		}
+	case spinner.TickMsg:
+		if a.probe.running {
+			a.stateVersion++
+		}
+	case CLILogMsg, LogBackfillMsg, LogFollowEndedMsg, gateway.LogMsg, GatewayEventMsg, EventStreamClosedMsg:
+		if !a.blurred {
+			a.streamVersion++
+		}
+	default:
+		a.stateVersion++
+	}
+}
+
+// cachedTabContent returns the active tab's content, rendering it only if
+// the cached copy is stale
+func (a *App) cachedTabContent(width, height int) string {
+	version := a.stateVersion + a.statusVersion(a.activeTab)
+	if tabUsesStreams(a.activeTab) {
+		version += a.streamVersion
+	}
+	now := time.Now().Unix()
+
+	c := &a.tabCache
+	if c.valid && c.tab == a.activeTab && c.width == width && c.height == height &&
+		c.version == version && (c.second == now || a.blurred) {
+		return c.content
+	}
+
+	*c = renderCache{
+		valid:   true,
+		tab:     a.activeTab,
+		width:   width,
+		height:  height,
+		version: version,
+		second:  now,
+		content: a.renderTabContent(width, height),
+	}
+	return c.content
+}
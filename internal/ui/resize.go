@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// resizeSettleDelay is how long the terminal size must stay put before the
+// layout is recomputed. Dragging a window edge sends a burst of sizes; only
+// the last is laid out. Frames in between keep the previous layout, which
+// the terminal clips, rather than re-wrapping everything on every step.
+const resizeSettleDelay = 50 * time.Millisecond
+
+// leftPaneWidth is the width of the instances pane
+const leftPaneWidth = 25
+
+// ResizeSettledMsg is sent resizeSettleDelay after a terminal resize
+type ResizeSettledMsg struct {
+	Seq int
+}
+
+// paneLayout holds the pane dimensions derived from the terminal size
+type paneLayout struct {
+	leftWidth     int
+	rightWidth    int
+	contentHeight int // Pane height, excluding the bottom bar and borders
+}
+
+// handleResize records a new terminal size. The first size is applied at
+// once so the first frame can render; later ones once they settle.
+func (a *App) handleResize(msg tea.WindowSizeMsg) tea.Cmd {
+	a.pendingWidth, a.pendingHeight = msg.Width, msg.Height
+	if a.width == 0 || a.height == 0 {
+		a.applySize()
+		return nil
+	}
+	a.resizeSeq++
+	seq := a.resizeSeq
+	return tea.Tick(resizeSettleDelay, func(time.Time) tea.Msg {
+		return ResizeSettledMsg{Seq: seq}
+	})
+}
+
+// handleResizeSettled applies the last size once no newer resize followed it
+func (a *App) handleResizeSettled(msg ResizeSettledMsg) {
+	if msg.Seq == a.resizeSeq {
+		a.applySize()
+	}
+}
+
+// applySize lays the UI out for the pending terminal size
+func (a *App) applySize() {
+	a.width, a.height = a.pendingWidth, a.pendingHeight
+	a.layout = paneLayout{
+		leftWidth:     leftPaneWidth,
+		rightWidth:    a.width - leftPaneWidth - 3, // Account for borders
+		contentHeight: a.height - 4,
+	}
+	a.sizeInputs()
+}
+
+// sizeInputs fits the bottom-bar text inputs to the terminal width, so long
+// input scrolls within its line instead of wrapping the layout
+func (a *App) sizeInputs() {
+	// Leave room for the longest prompt ("Heartbeat interval for <agent>: ")
+	width := max(a.width-48, 10)
+	a.searchInput.Width = width
+	a.memorySearchInput.Width = width
+	a.heartbeatInput.Width = width
+	a.passphrase.input.Width = width
+	a.gatewayConfig.filter.Width = width
+	a.gatewayConfig.edit.Width = width
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// RetryMsg is sent as an adapter begins retrying a status or health fetch
+type RetryMsg struct {
+	State gateway.RetryState
+}
+
+// enableRetries applies the configured retry policy to the CLI adapters and
+// returns a command relaying their retries to the UI
+func (a *App) enableRetries() tea.Cmd {
+	a.retries = make(chan gateway.RetryState, 16)
+	a.applyRetryPolicy()
+	return waitForRetry(a.retries)
+}
+
+// applyRetryPolicy sets the configured retry policy on the CLI adapters,
+// reporting their retries on the channel made by enableRetries
+func (a *App) applyRetryPolicy() {
+	backoff, maxBackoff := a.config.Retry.Delays()
+	policy := gateway.RetryPolicy{
+		Attempts:   a.config.Retry.MaxAttempts(),
+		Backoff:    backoff,
+		MaxBackoff: maxBackoff,
+		Jitter:     a.config.Retry.Spread(),
+	}
+
+	ch := a.retries
+	for _, adapter := range a.adapters {
+		if cli, ok := adapter.(*gateway.CLIAdapter); ok {
+			cli.Retry = policy
+			cli.OnRetry = func(state gateway.RetryState) {
+				// Only a redraw is needed; drop it if one is already queued
+				select {
+				case ch <- state:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// waitForRetry waits for the next retry on ch
+func waitForRetry(ch chan gateway.RetryState) tea.Cmd {
+	return func() tea.Msg {
+		return RetryMsg{State: <-ch}
+	}
+}
+
+// retryState returns the fetch being retried for the current instance, if any
+func (a *App) retryState() *gateway.RetryState {
+	if cli := a.cliAdapter(); cli != nil {
+		return cli.Retrying()
+	}
+	return nil
+}
+
+// renderRetryBanner describes a status or health fetch being retried for
+// the current instance, or returns "" if none is
+func (a *App) renderRetryBanner(width int) string {
+	state := a.retryState()
+	if state == nil {
+		return ""
+	}
+	text := fmt.Sprintf(" %s retrying (%d/%d): %s ", state.Command, state.Attempt, state.Attempts, state.Err)
+	return styles.StatusDegraded.Render(truncate(text, width))
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// instanceMsg carries the result of a fetch made for the instance shown at
+// the time. Results arriving after a switch away from it are dropped.
+type instanceMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+// instanceScope tracks the instance whose data is shown
+type instanceScope struct {
+	gen   int                 // Bumped on every switch
+	shown *gateway.CLIAdapter // Interrupted when switched away from
+}
+
+// scoped ties cmd's result to the instance currently shown
+func (a *App) scoped(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	gen := a.scope.gen
+	return func() tea.Msg {
+		msg := cmd()
+		if msg == nil {
+			return nil
+		}
+		return instanceMsg{gen: gen, msg: msg}
+	}
+}
+
+// unscope returns the message msg carries, or nil if it is the result of a
+// fetch for an instance since switched away from
+func (a *App) unscope(msg instanceMsg) tea.Msg {
+	if msg.gen != a.scope.gen {
+		return nil
+	}
+	return msg.msg
+}
+
+// leaveInstance starts a new scope for the selected instance. Whatever the
+// previous instance's adapter still runs is interrupted, so it neither holds
+// worker slots nor lands results over the new instance's.
+func (a *App) leaveInstance() {
+	a.scope.gen++
+	current := a.cliAdapter()
+	if a.scope.shown != nil && a.scope.shown != current {
+		a.scope.shown.Interrupt()
+	}
+	a.scope.shown = current
+}
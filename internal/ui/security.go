@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/config"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// Security tab severity filters
+const (
+	severityAll      = ""
+	severityCritical = "critical"
+	severityWarn     = "warn" // warn and above
+)
+
+// severityRank orders severities for filtering and sorting
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 2
+	case "warn":
+		return 1
+	}
+	return 0
+}
+
+// filteredFindings returns findings matching the Security tab severity filter
+func (a *App) filteredFindings() []models.SecurityAuditFinding {
+	if a.openclawStatus == nil || a.openclawStatus.SecurityAudit == nil {
+		return nil
+	}
+	minRank := 0
+	switch a.securitySeverity {
+	case severityCritical:
+		minRank = 2
+	case severityWarn:
+		minRank = 1
+	}
+	var findings []models.SecurityAuditFinding
+	for _, f := range a.openclawStatus.SecurityAudit.Findings {
+		if severityRank(f.Severity) >= minRank {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// checkCount is the number of findings reported by a single check
+type checkCount struct {
+	CheckID  string
+	Count    int
+	Severity string // Highest severity among the check's findings
+}
+
+// countByCheck groups findings by check ID, most severe and most frequent first
+func countByCheck(findings []models.SecurityAuditFinding) []checkCount {
+	index := make(map[string]int)
+	var counts []checkCount
+	for _, f := range findings {
+		i, ok := index[f.CheckID]
+		if !ok {
+			i = len(counts)
+			index[f.CheckID] = i
+			counts = append(counts, checkCount{CheckID: f.CheckID})
+		}
+		counts[i].Count++
+		if severityRank(f.Severity) > severityRank(counts[i].Severity) || counts[i].Severity == "" {
+			counts[i].Severity = f.Severity
+		}
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		if severityRank(counts[i].Severity) != severityRank(counts[j].Severity) {
+			return severityRank(counts[i].Severity) > severityRank(counts[j].Severity)
+		}
+		return counts[i].Count > counts[j].Count
+	})
+	return counts
+}
+
+// renderAuditDiff renders what changed since the previous audit run
+func (a *App) renderAuditDiff(width int) []string {
+	diff := a.auditDiff
+	if diff == nil {
+		return nil
+	}
+
+	var lines []string
+	since := diff.PreviousTime.Format("2006-01-02 15:04")
+	if diff.Empty() {
+		lines = append(lines, styles.HelpSection.Render("Since Last Run")+"  "+styles.Muted.Render(since))
+		lines = append(lines, "  "+styles.Muted.Render("No changes"))
+		return append(lines, "")
+	}
+
+	lines = append(lines, styles.HelpSection.Render("Since Last Run")+"  "+styles.Muted.Render(fmt.Sprintf(
+		"%s: +%d new, -%d resolved, %d changed", since, len(diff.New), len(diff.Resolved), len(diff.Changed))))
+	for _, f := range diff.New {
+		lines = append(lines, "  "+styles.LogError.Render("+ ")+truncate(fmt.Sprintf("[%s] %s", f.Severity, f.Title), width-6))
+	}
+	for _, f := range diff.Resolved {
+		lines = append(lines, "  "+styles.StatusOK.Render("- ")+styles.Muted.Render(truncate(fmt.Sprintf("[%s] %s", f.Severity, f.Title), width-6)))
+	}
+	for _, c := range diff.Changed {
+		marker := styles.LogWarn.Render("~ ")
+		if severityRank(c.Finding.Severity) < severityRank(c.From) {
+			marker = styles.StatusOK.Render("~ ")
+		}
+		lines = append(lines, "  "+marker+truncate(fmt.Sprintf("%s: %s -> %s", c.Finding.Title, c.From, c.Finding.Severity), width-6))
+	}
+	return append(lines, "")
+}
+
+// renderSecurityFilter renders the severity filter indicator for the tab header
+func (a *App) renderSecurityFilter() string {
+	value := "all"
+	switch a.securitySeverity {
+	case severityCritical:
+		value = "critical"
+	case severityWarn:
+		value = "warn+"
+	}
+	v := styles.Muted.Render(value)
+	if a.securitySeverity != severityAll {
+		v = styles.LabelValueHighlight.Render(value)
+	}
+	return styles.HintKey.Render("v") + styles.Muted.Render(":severity=") + v + "  " +
+		styles.HintKey.Render("E") + styles.Muted.Render(":export")
+}
+
+// exportSecurityAudit writes the current audit as Markdown and CSV files under
+// the config dir's exports/ directory and returns the Markdown path
+func (a *App) exportSecurityAudit() (string, error) {
+	if a.openclawStatus == nil || a.openclawStatus.SecurityAudit == nil {
+		return "", fmt.Errorf("no security audit to export")
+	}
+	audit := a.openclawStatus.SecurityAudit
+
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "exports")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	instance := "local"
+	if adapter := a.cliAdapter(); adapter != nil {
+		instance = adapter.GetInstanceName()
+	}
+	base := filepath.Join(dir, fmt.Sprintf("security-%s-%s", sanitizeFilename(instance), time.Now().Format("20060102-150405")))
+
+	if err := os.WriteFile(base+".md", []byte(securityMarkdown(instance, audit)), 0644); err != nil {
+		return "", err
+	}
+	if err := writeSecurityCSV(base+".csv", audit); err != nil {
+		return "", err
+	}
+	return base + ".md", nil
+}
+
+// securityMarkdown formats an audit as a Markdown report
+func securityMarkdown(instance string, audit *models.SecurityAudit) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Security Audit: %s\n\n", instance)
+	if audit.Timestamp > 0 {
+		fmt.Fprintf(&b, "Audit time: %s\n\n", time.UnixMilli(audit.Timestamp).Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "| Critical | Warn | Info |\n|---|---|---|\n| %d | %d | %d |\n\n",
+		audit.Summary.Critical, audit.Summary.Warn, audit.Summary.Info)
+
+	b.WriteString("## Findings by Check\n\n| Check | Severity | Count |\n|---|---|---|\n")
+	for _, c := range countByCheck(audit.Findings) {
+		fmt.Fprintf(&b, "| %s | %s | %d |\n", c.CheckID, c.Severity, c.Count)
+	}
+
+	b.WriteString("\n## Findings\n")
+	for _, f := range audit.Findings {
+		fmt.Fprintf(&b, "\n### [%s] %s\n\n", strings.ToUpper(f.Severity), f.Title)
+		fmt.Fprintf(&b, "- Check: `%s`\n", f.CheckID)
+		if f.Detail != "" {
+			fmt.Fprintf(&b, "- Detail: %s\n", f.Detail)
+		}
+		if f.Remediation != "" {
+			fmt.Fprintf(&b, "- Remediation: `%s`\n", f.Remediation)
+		}
+	}
+	return b.String()
+}
+
+// writeSecurityCSV writes one row per finding
+func writeSecurityCSV(path string, audit *models.SecurityAudit) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"check_id", "severity", "title", "detail", "remediation"})
+	for _, finding := range audit.Findings {
+		_ = w.Write([]string{finding.CheckID, finding.Severity, finding.Title, finding.Detail, finding.Remediation})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// sanitizeFilename replaces characters that are awkward in file names
+func sanitizeFilename(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,246 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// managedServices are the services shown in the System tab, in display order.
+// Name is also the openclaw subcommand that controls the service.
+var managedServices = []struct {
+	Name  string
+	Label string
+}{
+	{"gateway", "Gateway Service"},
+	{"node", "Node Service"},
+}
+
+// serviceActions describe the service actions: the verb titling the output
+// viewer and what the service is said to be doing while it runs
+var serviceActions = map[string]struct {
+	verb     string
+	progress string
+}{
+	"start":   {"Start", "starting"},
+	"stop":    {"Stop", "stopping"},
+	"restart": {"Restart", "restarting"},
+	"enable":  {"Enable", "enabling start at boot"},
+	"disable": {"Disable", "disabling start at boot"},
+}
+
+// ServiceActionMsg is sent when a service start/stop/restart returns
+type ServiceActionMsg struct {
+	Service string
+	Action  string
+	Error   error
+}
+
+func (m ServiceActionMsg) failure() error { return m.Error }
+
+// serviceControl holds the System tab selection and the service action
+// running, if any
+type serviceControl struct {
+	cursor  int    // Index into managedServices, then into the process list
+	running string // Action in progress, e.g. "restart"
+}
+
+// serviceInfo returns the status of the named service, if reported
+func (a *App) serviceInfo(name string) *models.ServiceInfo {
+	if a.openclawStatus == nil {
+		return nil
+	}
+	if name == "node" {
+		return a.openclawStatus.NodeService
+	}
+	return a.openclawStatus.GatewayService
+}
+
+// moveSystemCursor moves the System tab selection by delta, through the
+// services and then the process list
+func (a *App) moveSystemCursor(delta int) {
+	s := &a.services
+	s.cursor = min(max(s.cursor+delta, 0), len(managedServices)+len(a.processes.procs)-1)
+}
+
+// selectedService returns the selected service, or false if a process is selected
+func (a *App) selectedService() (int, bool) {
+	return a.services.cursor, a.services.cursor < len(managedServices)
+}
+
+// serviceRunning returns true if a service reports it is running
+func serviceRunning(info *models.ServiceInfo) bool {
+	return contains(info.RuntimeShort, "running")
+}
+
+// serviceBoot returns whether a service starts at boot, from its loaded line
+// (systemd's "enabled" or "disabled"), or false if the line does not say
+func serviceBoot(info *models.ServiceInfo) (enabled, known bool) {
+	switch {
+	case contains(info.LoadedText, "disabled"):
+		return false, true
+	case contains(info.LoadedText, "enabled"):
+		return true, true
+	}
+	return false, false
+}
+
+// toggleService starts the selected service if it is stopped, or stops it
+// if it is running
+func (a *App) toggleService() tea.Cmd {
+	idx, ok := a.selectedService()
+	if !ok {
+		return nil
+	}
+	if info := a.serviceInfo(managedServices[idx].Name); info != nil && serviceRunning(info) {
+		return a.controlService("stop")
+	}
+	return a.controlService("start")
+}
+
+// toggleServiceBoot disables starting the selected service at boot if it
+// is enabled, and enables it otherwise
+func (a *App) toggleServiceBoot() tea.Cmd {
+	idx, ok := a.selectedService()
+	if !ok {
+		return nil
+	}
+	if info := a.serviceInfo(managedServices[idx].Name); info != nil {
+		if enabled, _ := serviceBoot(info); enabled {
+			return a.controlService("disable")
+		}
+	}
+	return a.controlService("enable")
+}
+
+// controlService asks to confirm action on the selected service, then runs
+// it in the output viewer. Stopping and disabling are confirmed by typing
+// the instance name.
+func (a *App) controlService(action string) tea.Cmd {
+	s := &a.services
+	idx, ok := a.selectedService()
+	if !ok {
+		return nil
+	}
+	svc := managedServices[idx]
+	info := a.serviceInfo(svc.Name)
+	if a.cliAdapter() == nil || s.running != "" || info == nil {
+		return nil
+	}
+	if !a.canWrite("Controlling services") {
+		return nil
+	}
+	if !info.Installed {
+		a.setFlash(svc.Label+" is not installed", true)
+		return nil
+	}
+
+	verb := serviceActions[action].verb
+	req := writeRequest{
+		title:  verb + " " + svc.Label,
+		prompt: fmt.Sprintf("%s the %s on %s?", verb, strings.ToLower(svc.Label), a.cliAdapter().GetInstanceName()),
+		preview: func(c *gateway.CLIAdapter) error {
+			_, err := c.ControlService(svc.Name, action)
+			return err
+		},
+		run: func() tea.Cmd { return a.runServiceAction(svc.Name, svc.Label, action) },
+	}
+	switch action {
+	case "stop":
+		req.warning, req.destructive = "It stays down until started again.", true
+	case "disable":
+		req.warning, req.destructive = "It will not start when the host boots.", true
+	}
+	return a.confirmWrite(req)
+}
+
+// runServiceAction runs the confirmed service action
+func (a *App) runServiceAction(service, label, action string) tea.Cmd {
+	a.services.running = action
+	adapter := a.cliAdapter()
+	return func() tea.Msg {
+		_, err := adapter.ControlService(service, action)
+		return ServiceActionMsg{Service: label, Action: action, Error: err}
+	}
+}
+
+func (a *App) handleServiceAction(msg ServiceActionMsg) tea.Cmd {
+	a.services.running = ""
+	if a.mode != ModeActionOutput {
+		// The output viewer was closed while the action ran
+		if msg.Error != nil {
+			a.setFlash(msg.Error.Error(), true)
+		} else {
+			a.setFlash(fmt.Sprintf("%s: %s done", msg.Service, msg.Action), false)
+		}
+	}
+	// Failed actions may have changed the service too
+	return a.fetchCLIStatus()
+}
+
+// showServiceLogs switches to the Logs tab filtered to the selected service
+func (a *App) showServiceLogs() {
+	idx, ok := a.selectedService()
+	if !ok {
+		return
+	}
+	a.searchInput.SetValue(managedServices[idx].Name)
+	a.activeTab = TabLogs
+}
+
+// renderServicesSection renders runtime details for the gateway and node
+// services, highlighting the selected one
+func (a *App) renderServicesSection(width int) []string {
+	s := &a.services
+	title := styles.HelpSection.Render("Services")
+	if a.writesAllowed() {
+		title += "  " + styles.Muted.Render("j/k: select  enter: start/stop  R: restart  B: boot  L: logs")
+	} else {
+		title += "  " + styles.Muted.Render("j/k: select  L: logs")
+	}
+	lines := []string{title}
+
+	for i, svc := range managedServices {
+		info := a.serviceInfo(svc.Name)
+		if info == nil {
+			continue
+		}
+		badge := styles.BadgeMuted.Render("NOT INSTALLED")
+		if info.Installed {
+			if serviceRunning(info) {
+				badge = styles.BadgeOK.Render("RUNNING")
+			} else {
+				badge = styles.BadgeError.Render("STOPPED")
+			}
+			if enabled, known := serviceBoot(info); known && enabled {
+				badge += " " + styles.Muted.Render("starts at boot")
+			} else if known {
+				badge += " " + styles.Muted.Render("manual start")
+			}
+		}
+		name := "  " + padRight(svc.Label+":", 16)
+		if i == s.cursor && a.focusedPane == PaneDetails {
+			name = styles.TableRowSelected.Render(name)
+		}
+		line := name + " " + badge
+		if i == s.cursor && s.running != "" {
+			line += "  " + styles.Muted.Render(serviceActions[s.running].progress+"...")
+		}
+		lines = append(lines, line)
+
+		if info.Label != "" {
+			lines = append(lines, fmt.Sprintf("    Label:   %s", truncate(info.Label, width-15)))
+		}
+		if info.LoadedText != "" {
+			lines = append(lines, fmt.Sprintf("    Loaded:  %s", truncate(info.LoadedText, width-15)))
+		}
+		if info.RuntimeShort != "" {
+			lines = append(lines, fmt.Sprintf("    Runtime: %s", styles.Muted.Render(truncate(info.RuntimeShort, width-15))))
+		}
+	}
+	return append(lines, "")
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+)
+
+// sessionActions describe the session kill and reset: the verb titling
+// the confirmation, what it warns of and what the result flash says
+var sessionActions = map[string]struct {
+	verb    string
+	warning string
+	done    string
+}{
+	"kill":  {"Kill", "Its current run is aborted and the session closed.", "Killed session %s"},
+	"reset": {"Reset", "Its conversation history is cleared; the agent starts over without it.", "Reset the context of session %s"},
+}
+
+// SessionActionMsg is sent when a session kill or reset returns
+type SessionActionMsg struct {
+	Key    string
+	Action string
+	Error  error
+}
+
+func (m SessionActionMsg) failure() error { return m.Error }
+
+// SessionCompactedMsg is sent when a session compaction returns
+type SessionCompactedMsg struct {
+	Key    string
+	Before int // Tokens before, as the status reported them
+	Result *models.SessionCompaction
+	Error  error
+}
+
+func (m SessionCompactedMsg) failure() error { return m.Error }
+
+// selectedSession returns the session selected in the Sessions tab
+func (a *App) selectedSession() (models.Session, bool) {
+	sessions := a.filteredSessions()
+	if len(sessions) == 0 {
+		return models.Session{}, false
+	}
+	return sessions[min(a.sessionCursor, len(sessions)-1)], true
+}
+
+// sessionKey returns the key the CLI knows a session by
+func sessionKey(sess models.Session) string {
+	return cmp.Or(sess.Key, sess.SessionID)
+}
+
+// confirmSessionAction asks to confirm action on the selected session
+func (a *App) confirmSessionAction(action string) tea.Cmd {
+	sess, ok := a.selectedSession()
+	if a.cliAdapter() == nil || !ok || sessionKey(sess) == "" || a.sessionRunning != "" {
+		return nil
+	}
+	if !a.canWrite("Controlling sessions") {
+		return nil
+	}
+	spec := sessionActions[action]
+	agentID, sessKey := sess.AgentID, sessionKey(sess)
+	return a.confirmWrite(writeRequest{
+		title:  spec.verb + " Session",
+		prompt: fmt.Sprintf("%s session %s of %s on %s?", spec.verb, sessKey, agentID, a.cliAdapter().GetInstanceName()),
+		details: []string{fmt.Sprintf("%s, %s old, %s tokens (%d%% of context)",
+			cmp.Or(sess.Kind, "unknown kind"), formatAge(sess.Age), formatNumber(sess.TotalTokens), sess.PercentUsed)},
+		warning:     spec.warning,
+		destructive: true,
+		preview:     func(c *gateway.CLIAdapter) error { return c.ControlSession(agentID, sessKey, action) },
+		run:         func() tea.Cmd { return a.runSessionAction(agentID, sessKey, action) },
+	})
+}
+
+// runSessionAction runs the confirmed action, flashing the result and
+// refreshing the sessions
+func (a *App) runSessionAction(agentID, sessKey, action string) tea.Cmd {
+	adapter := a.cliAdapter()
+	a.sessionRunning = sessKey
+	return func() tea.Msg {
+		return SessionActionMsg{Key: sessKey, Action: action, Error: adapter.ControlSession(agentID, sessKey, action)}
+	}
+}
+
+func (a *App) handleSessionAction(msg SessionActionMsg) tea.Cmd {
+	a.sessionRunning = ""
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+	} else {
+		a.setFlash(fmt.Sprintf(sessionActions[msg.Action].done, msg.Key), false)
+	}
+	return a.fetchCLIStatus()
+}
+
+// compactSession has the gateway compact the selected session, once
+// confirmed, flashing its token counts before and after
+func (a *App) compactSession() tea.Cmd {
+	sess, ok := a.selectedSession()
+	if a.cliAdapter() == nil || !ok || sessionKey(sess) == "" || a.compacting != "" {
+		return nil
+	}
+	if !a.canWrite("Compacting sessions") {
+		return nil
+	}
+	agentID, sessKey, before := sess.AgentID, sessionKey(sess), sess.TotalTokens
+	return a.confirmWrite(writeRequest{
+		title:   "Compact Session",
+		prompt:  fmt.Sprintf("Compact session %s of %s on %s?", sessKey, agentID, a.cliAdapter().GetInstanceName()),
+		details: []string{fmt.Sprintf("%s tokens (%d%% of context)", formatNumber(before), sess.PercentUsed)},
+		warning: "Its history is summarized; the messages themselves are no longer in its context.",
+		preview: func(c *gateway.CLIAdapter) error {
+			_, err := c.CompactSession(agentID, sessKey)
+			return err
+		},
+		run: func() tea.Cmd { return a.runCompaction(agentID, sessKey, before) },
+	})
+}
+
+// runCompaction compacts the confirmed session
+func (a *App) runCompaction(agentID, sessKey string, before int) tea.Cmd {
+	adapter := a.cliAdapter()
+	a.compacting = sessKey
+	a.setFlash("Compacting session "+sessKey+"...", false)
+	return func() tea.Msg {
+		result, err := adapter.CompactSession(agentID, sessKey)
+		return SessionCompactedMsg{Key: sessKey, Before: before, Result: result, Error: err}
+	}
+}
+
+func (a *App) handleSessionCompacted(msg SessionCompactedMsg) tea.Cmd {
+	a.compacting = ""
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	before := cmp.Or(msg.Result.TokensBefore, msg.Before)
+	a.setFlash(fmt.Sprintf("Compacted session %s: %s → %s tokens", msg.Key,
+		formatNumber(before), formatNumber(msg.Result.TokensAfter)), false)
+	return a.fetchCLIStatus()
+}
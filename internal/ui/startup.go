@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// loadingState tracks the first status and health fetches for the selected
+// instance, so tabs waiting on them show a placeholder rather than looking
+// empty (over slow SSH the first status can take many seconds)
+type loadingState struct {
+	since  time.Time
+	status bool
+	health bool
+}
+
+// startLoading marks the selected instance's status and health as pending
+func (a *App) startLoading() {
+	a.loading = loadingState{since: time.Now(), status: true, health: true}
+}
+
+// tabDataCmds returns the fetches the active tab needs when it opens, beyond
+// the status and health every tab shares
+func (a *App) tabDataCmds() []tea.Cmd {
+	var cmds []tea.Cmd
+	switch a.activeTab {
+	case TabChannels:
+		if a.cliAdapter() != nil {
+			cmds = append(cmds, a.fetchCLIChannels())
+		}
+	case TabMemory:
+		if a.cliAdapter() != nil && !a.reindex.polling {
+			cmds = append(cmds, a.fetchMemoryIndexStatus())
+		}
+	case TabSystem:
+		cmds = append(cmds, a.fetchChangelog(), a.fetchProcesses())
+	case TabUsage:
+		if a.cliAdapter() != nil {
+			cmds = append(cmds, a.fetchUsage())
+		}
+	case TabConfig:
+		if !a.gatewayConfig.loaded {
+			cmds = append(cmds, a.fetchGatewayConfig())
+		}
+	}
+	// Sections left out of status polls are fetched as soon as a tab
+	// showing them opens
+	if a.getCurrentAdapter() != nil && a.activeTabStale() {
+		cmds = append(cmds, a.fetchCLIStatus())
+	}
+	return cmds
+}
+
+// waitingOn returns what the active tab is still waiting for before it has
+// anything to show, or "" if it can render
+func (a *App) waitingOn() string {
+	switch a.activeTab {
+	case TabHealth:
+		if a.loading.health && a.healthCheckResult == nil && a.openclawStatus == nil {
+			return "health check"
+		}
+	case TabChannels:
+		if a.loading.status && a.channelsList == nil && a.channelsStatus == nil && a.openclawStatus == nil {
+			return "channels"
+		}
+	case TabOverview, TabAgents, TabSessions, TabMemory, TabSecurity, TabSystem, TabHooks, TabQueues:
+		if a.loading.status && a.openclawStatus == nil {
+			return "status"
+		}
+	}
+	return ""
+}
+
+// renderLoadingPlaceholder renders a skeleton of the active tab while its
+// first data is on the way, or "" once there is something to show
+func (a *App) renderLoadingPlaceholder(width int) string {
+	what := a.waitingOn()
+	if what == "" {
+		return ""
+	}
+	instance := "instance"
+	if adapter := a.getCurrentAdapter(); adapter != nil {
+		instance = adapter.GetInstanceName()
+	}
+	elapsed := time.Since(a.loading.since).Truncate(time.Second)
+	progress := ""
+	if stage, ok := a.connectStage(a.getCurrentAdapter()); ok {
+		progress = stage.String() + "… "
+	}
+
+	lines := []string{
+		styles.HelpSection.Render(a.activeTab.String()),
+		"",
+		styles.Muted.Render(fmt.Sprintf("  Loading %s from %s... %s%s", what, instance, progress, elapsed)),
+		"",
+	}
+	// Bars of varying length hint at the layout to come
+	barWidth := max(min(width-4, 48), 8)
+	for _, frac := range []int{10, 7, 9, 5, 8, 6} {
+		lines = append(lines, "  "+styles.Muted.Render(strings.Repeat("░", barWidth*frac/10)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// prefetchInstance returns a command fetching the sections a freshly loaded
+// instance needs in a single remote invocation, or nil if the instance is
+// local. It must be called before those sections' fetch commands are created.
+func (a *App) prefetchInstance() tea.Cmd {
+	adapter := a.cliAdapter()
+	if adapter == nil {
+		return nil
+	}
+	sections := []gateway.Section{gateway.SectionStatus, gateway.SectionHealth}
+	if a.activeTab == TabChannels {
+		sections = append(sections, gateway.SectionChannels)
+	}
+	if adapter.CachedVersion() == "" {
+		// Checked by the status and health fetches before they run
+		sections = append(sections, gateway.SectionVersion)
+	}
+	run := adapter.Prefetch(sections...)
+	if run == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		run()
+		return nil
+	}
+}
+
+// prefetchRefresh returns a command fetching a refresh's status poll and
+// health check in a single remote invocation (ui.batch_refresh), or nil if
+// batching is off or the instance is local. It must be called before the
+// status and health fetch commands are created.
+func (a *App) prefetchRefresh() tea.Cmd {
+	adapter := a.cliAdapter()
+	if adapter == nil || !a.config.UI.BatchRefresh {
+		return nil
+	}
+	if adapter.CachedVersion() != "" && !adapter.Supports(gateway.CapHealthJSON) {
+		// Releases without health --json poll status alone, as unbatched
+		return nil
+	}
+	run := adapter.PrefetchStatus(a.statusExclusions(), gateway.SectionHealth)
+	if run == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		run()
+		return nil
+	}
+}
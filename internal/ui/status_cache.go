@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/state"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// restoreCachedStatus shows the selected instance's last known status,
+// marked stale, until the first fetch replaces it: the adapter's, if it has
+// fetched one this run, else the one saved on a previous run
+func (a *App) restoreCachedStatus() {
+	maxAge := a.config.UI.StatusCacheMaxAge()
+	cli := a.cliAdapter()
+	if maxAge == 0 || cli == nil || a.openclawStatus != nil {
+		return
+	}
+	cached := &state.CachedStatus{Status: cli.GetCachedStatus(), FetchedAt: time.Now().Add(-cli.GetStatusAge())}
+	if cached.Status == nil {
+		var err error
+		if cached, err = state.LoadStatus(cli.GetInstanceName(), maxAge); err != nil || cached == nil {
+			return
+		}
+	}
+	a.openclawStatus = cached.Status
+	a.lastStatusAt = cached.FetchedAt
+	a.statusCached = true
+}
+
+// saveStatusCache saves each instance's last status for the next launch.
+// The selected instance's is taken from the UI, which keeps sections left
+// out of recent fetches.
+func (a *App) saveStatusCache() {
+	if a.config.UI.StatusCacheMaxAge() == 0 {
+		return
+	}
+	selected := a.cliAdapter()
+	for _, adapter := range a.adapters {
+		cli, ok := adapter.(*gateway.CLIAdapter)
+		if !ok {
+			continue
+		}
+		cached := state.CachedStatus{Status: cli.GetCachedStatus(), FetchedAt: time.Now().Add(-cli.GetStatusAge())}
+		if cli == selected && a.openclawStatus != nil {
+			cached = state.CachedStatus{Status: a.openclawStatus, FetchedAt: a.lastStatusAt}
+		}
+		if cached.Status == nil {
+			continue
+		}
+		_ = state.SaveStatus(cli.GetInstanceName(), cached) // Best effort, like the UI state
+	}
+}
+
+// renderCachedBanner marks the status restored from the cache as stale, or
+// returns "" once a fetch has replaced it
+func (a *App) renderCachedBanner(width int) string {
+	if !a.statusCached || a.openclawStatus == nil {
+		return ""
+	}
+	text := fmt.Sprintf(" STALE (%s old): showing the last known status while fresh data loads ",
+		formatAge(time.Since(a.lastStatusAt).Milliseconds()))
+	return styles.StatusDegraded.Render(truncate(text, width))
+}
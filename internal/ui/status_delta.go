@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// statusChangeHighlight is how long a changed figure stays highlighted
+const statusChangeHighlight = 5 * time.Second
+
+// tabStatusSections lists the status sections (by JSON name) a tab renders,
+// for tabs that need only some of them; such tabs are redrawn only when one
+// of their sections changes. Tabs not listed redraw on every status poll, as
+// most chart history sampled per poll.
+var tabStatusSections = map[Tab][]string{
+	TabLogs:     nil,
+	TabEvents:   nil,
+	TabConfig:   nil,
+	TabAgents:   {"agents", "heartbeat"},
+	TabSessions: {"sessions"},
+	TabMemory:   {"memory"},
+	TabSecurity: {"securityAudit"},
+	TabHooks:    {"webhooks"},
+	TabChannels: {"linkChannel", "channelSummary"},
+}
+
+// statusFigures are the figures highlighted for a while after they change
+var statusFigures = map[string]func(*models.OpenClawStatus) (int, bool){
+	"sessions": func(s *models.OpenClawStatus) (int, bool) {
+		if s.Sessions == nil {
+			return 0, false
+		}
+		return s.Sessions.Count, true
+	},
+	"agents": func(s *models.OpenClawStatus) (int, bool) {
+		if s.Agents == nil {
+			return 0, false
+		}
+		return len(s.Agents.Agents), true
+	},
+	"security.critical": func(s *models.OpenClawStatus) (int, bool) {
+		if s.SecurityAudit == nil {
+			return 0, false
+		}
+		return s.SecurityAudit.Summary.Critical, true
+	},
+	"security.warn": func(s *models.OpenClawStatus) (int, bool) {
+		if s.SecurityAudit == nil {
+			return 0, false
+		}
+		return s.SecurityAudit.Summary.Warn, true
+	},
+}
+
+// statusDeltas tracks what changed between consecutive status fetches
+type statusDeltas struct {
+	versions map[string]uint64 // Per section, bumped when it changes
+	polls    uint64            // Bumped on every status fetch
+
+	changed map[string]figureChange // By statusFigures name
+}
+
+// figureChange records when a figure last changed and in which direction
+type figureChange struct {
+	at   time.Time
+	rise bool
+}
+
+// changedSections returns the JSON names of the top-level sections that
+// differ between two statuses
+func changedSections(prev, next *models.OpenClawStatus) []string {
+	pv, nv := reflect.ValueOf(prev).Elem(), reflect.ValueOf(next).Elem()
+	t := nv.Type()
+	var changed []string
+	for i := 0; i < t.NumField(); i++ {
+		if !reflect.DeepEqual(pv.Field(i).Interface(), nv.Field(i).Interface()) {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// applyStatusDelta compares a newly fetched status with the current one,
+// bumping the versions of the sections that changed and noting changed
+// figures. It must be called before the new status is stored.
+func (a *App) applyStatusDelta(next *models.OpenClawStatus) {
+	d := &a.statusDeltas
+	d.polls++
+	if d.versions == nil {
+		d.versions = make(map[string]uint64)
+		d.changed = make(map[string]figureChange)
+	}
+
+	prev := a.openclawStatus
+	if prev == nil {
+		// Everything is new; nothing to highlight
+		for _, sections := range tabStatusSections {
+			for _, section := range sections {
+				d.versions[section]++
+			}
+		}
+		return
+	}
+	for _, section := range changedSections(prev, next) {
+		d.versions[section]++
+	}
+
+	now := time.Now()
+	for name, figure := range statusFigures {
+		was, ok1 := figure(prev)
+		is, ok2 := figure(next)
+		if ok1 && ok2 && was != is {
+			d.changed[name] = figureChange{at: now, rise: is > was}
+		}
+	}
+}
+
+// statusVersion returns a number that changes whenever status data tab t
+// renders changes
+func (a *App) statusVersion(t Tab) uint64 {
+	sections, ok := tabStatusSections[t]
+	if !ok {
+		return a.statusDeltas.polls
+	}
+	var v uint64
+	for _, section := range sections {
+		v += a.statusDeltas.versions[section]
+	}
+	return v
+}
+
+// highlightChange renders text in style, or highlighted with an arrow
+// showing the direction if the named figure changed within
+// statusChangeHighlight
+func (a *App) highlightChange(figure, text string, style lipgloss.Style) string {
+	c, ok := a.statusDeltas.changed[figure]
+	if !ok || time.Since(c.at) > statusChangeHighlight {
+		return style.Render(text)
+	}
+	arrow := "▼"
+	if c.rise {
+		arrow = "▲"
+	}
+	return styles.ValueChanged.Render(fmt.Sprintf(" %s %s ", strings.TrimSpace(text), arrow))
+}
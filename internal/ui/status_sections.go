@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+)
+
+// statusSectionTabs lists, for each expensive status section, the tabs that
+// show it. Status polls leave a section out unless one of its tabs is active.
+var statusSectionTabs = map[string][]Tab{
+	gateway.StatusSectionSecurityAudit: {TabOverview, TabSecurity, TabHealth},
+	gateway.StatusSectionMemory:        {TabOverview, TabMemory},
+	gateway.StatusSectionAgents:        {TabOverview, TabAgents},
+}
+
+// statusExclusions returns the sections the next status poll can leave out.
+// Until a full status has arrived for the instance nothing is left out, so
+// badges and the health level have every section to work from.
+func (a *App) statusExclusions() []string {
+	if a.openclawStatus == nil {
+		return nil
+	}
+	var exclude []string
+	for section, tabs := range statusSectionTabs {
+		if !slices.Contains(tabs, a.activeTab) {
+			exclude = append(exclude, section)
+		}
+	}
+	slices.Sort(exclude)
+	return exclude
+}
+
+// keepExcludedSections copies the sections left out of a status poll from
+// the previous status, so they keep showing their last known values, and
+// marks them stale
+func (a *App) keepExcludedSections(msg *CLIStatusMsg) {
+	status, excluded, prev := msg.Status, msg.Excluded, a.openclawStatus
+	if a.staleSections == nil {
+		a.staleSections = make(map[string]bool)
+	}
+	for _, section := range excluded {
+		switch section {
+		case gateway.StatusSectionSecurityAudit:
+			if status.SecurityAudit == nil && prev != nil {
+				status.SecurityAudit = prev.SecurityAudit
+				msg.AuditDiff = a.auditDiff
+			}
+		case gateway.StatusSectionMemory:
+			if status.Memory == nil && prev != nil {
+				status.Memory = prev.Memory
+			}
+		case gateway.StatusSectionAgents:
+			if status.Agents == nil && prev != nil {
+				status.Agents = prev.Agents
+			}
+		}
+	}
+	for section := range statusSectionTabs {
+		a.staleSections[section] = slices.Contains(excluded, section)
+	}
+}
+
+// activeTabStale reports whether the active tab shows a section the last
+// status poll left out, so switching to it should fetch the status straight
+// away rather than wait for the next poll
+func (a *App) activeTabStale() bool {
+	for section, stale := range a.staleSections {
+		if stale && slices.Contains(statusSectionTabs[section], a.activeTab) {
+			return true
+		}
+	}
+	return false
+}
@@ -62,6 +62,15 @@ var (
 	InactiveTab = lipgloss.NewStyle().
 			Foreground(ColorMuted).
 			Padding(0, 2)
+
+	// Attention counters on tab labels
+	TabBadgeError = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(ColorHealthDown)
+
+	TabBadgeWarn = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(ColorHealthDegraded)
 )
 
 // Status badge styles
@@ -246,6 +255,12 @@ var (
 	LabelValueHighlight = lipgloss.NewStyle().
 				Bold(true).
 				Foreground(ColorPrimary)
+
+	// A figure that changed since the previous refresh
+	ValueChanged = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(ColorBackground).
+			Background(ColorSecondary)
 )
 
 // Divider
@@ -253,3 +268,10 @@ var (
 	Divider = lipgloss.NewStyle().
 		Foreground(ColorMuted)
 )
+
+// QR codes, drawn dark on light whatever the theme so phones can scan them
+var (
+	QRCode = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#000000")).
+		Background(lipgloss.Color("#FFFFFF"))
+)
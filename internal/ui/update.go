@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// maxChangelogLines caps how much of the changelog the System tab shows
+const maxChangelogLines = 40
+
+// updateVersionChecks is how many statuses after an update or update
+// channel switch may report the previous version or channel, as the gateway
+// restarts, before it is said not to have taken
+const updateVersionChecks = 3
+
+// ChangelogMsg is sent when a changelog fetch completes
+type ChangelogMsg struct {
+	Version string // Latest version the changelog was fetched for
+	Text    string
+	Error   error
+}
+
+// UpdateDoneMsg is sent when a lazyclaw-triggered gateway update returns
+type UpdateDoneMsg struct {
+	Version string
+	Error   error
+}
+
+func (m UpdateDoneMsg) failure() error { return m.Error }
+
+// updateTracker holds the System tab update state and the latest update:
+// its result and the version the gateway reports afterwards
+type updateTracker struct {
+	changelogFor string // Latest version the changelog belongs to
+	changelog    string
+	loading      bool
+	err          string
+	running      bool
+
+	// The latest update
+	from      string // Version before the update
+	target    string
+	verifying bool   // Waiting for a status to report the new version
+	checks    int    // Statuses since the update that reported the old one
+	reported  string // Version the gateway reports since the update
+}
+
+// installedVersion returns the running gateway version, if known
+func (a *App) installedVersion() string {
+	if a.openclawStatus != nil && a.openclawStatus.Gateway != nil && a.openclawStatus.Gateway.Self.Version != "" {
+		return a.openclawStatus.Gateway.Self.Version
+	}
+	return a.connectionState.GatewayVersion
+}
+
+// availableUpdate returns the registry's latest version if it is newer than
+// the installed one, or "" if no update is available
+func (a *App) availableUpdate() string {
+	if a.openclawStatus == nil || a.openclawStatus.Update == nil {
+		return ""
+	}
+	latest := a.openclawStatus.Update.Registry.LatestVersion
+	if latest == "" || !versionNewer(latest, a.installedVersion()) {
+		return ""
+	}
+	return latest
+}
+
+// versionNewer reports whether version a is newer than b. Versions are
+// compared numerically by dot-separated component; a leading "v" and any
+// pre-release suffix are ignored. An unknown installed version never
+// compares as older.
+func versionNewer(a, b string) bool {
+	if b == "" {
+		return false
+	}
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+ "); i != -1 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// fetchChangelog fetches release notes for the available update, once per version
+func (a *App) fetchChangelog() tea.Cmd {
+	latest := a.availableUpdate()
+	u := &a.update
+	if latest == "" || a.cliAdapter() == nil || u.loading || u.changelogFor == latest {
+		return nil
+	}
+
+	u.loading = true
+	u.err = ""
+	since := a.installedVersion()
+	adapter := a.cliAdapter()
+	return a.scoped(func() tea.Msg {
+		text, err := adapter.GetChangelog(since)
+		return ChangelogMsg{Version: latest, Text: text, Error: err}
+	})
+}
+
+func (a *App) handleChangelog(msg ChangelogMsg) {
+	u := &a.update
+	u.loading = false
+	u.changelogFor = msg.Version
+	if msg.Error != nil {
+		u.err = msg.Error.Error()
+		u.changelog = ""
+		return
+	}
+	u.changelog = msg.Text
+}
+
+// startUpdate asks to confirm updating the gateway to the latest version,
+// which runUpdate then does in the output viewer
+func (a *App) startUpdate() tea.Cmd {
+	latest := a.availableUpdate()
+	if latest == "" || a.update.running || a.cliAdapter() == nil {
+		return nil
+	}
+	if !a.canWrite("Updating") {
+		return nil
+	}
+	return a.confirmWrite(writeRequest{
+		title:   "Update Gateway",
+		prompt:  fmt.Sprintf("Update %s from %s to %s?", a.cliAdapter().GetInstanceName(), a.installedVersion(), latest),
+		preview: func(c *gateway.CLIAdapter) error { return c.RunUpdate() },
+		run:     func() tea.Cmd { return a.runUpdate(latest) },
+		status:  a.updateStatus,
+	})
+}
+
+// runUpdate runs `openclaw update`
+func (a *App) runUpdate(latest string) tea.Cmd {
+	u := &a.update
+	adapter := a.cliAdapter()
+	u.running = true
+	u.from, u.target = a.installedVersion(), latest
+	u.verifying, u.checks, u.reported = false, 0, ""
+	return func() tea.Msg {
+		return UpdateDoneMsg{Version: latest, Error: adapter.RunUpdate()}
+	}
+}
+
+// handleUpdateDone reports the update result and refreshes status, which
+// checkUpdate then reads the new version from
+func (a *App) handleUpdateDone(msg UpdateDoneMsg) tea.Cmd {
+	u := &a.update
+	u.running = false
+	if msg.Error != nil {
+		if a.mode != ModeActionOutput {
+			a.setFlash(msg.Error.Error(), true)
+		}
+		// A failed update may have changed the gateway too
+		return a.fetchCLIStatus()
+	}
+	u.verifying = true
+	// The changelog was for the previous version
+	u.changelogFor, u.changelog = "", ""
+	return a.fetchCLIStatus()
+}
+
+// checkUpdate reads the version statuses report after an update until it
+// is the new one, or updateVersionChecks of them still report an older one.
+// If the output viewer was closed, the outcome is flashed.
+func (a *App) checkUpdate() {
+	u := &a.update
+	if !u.verifying {
+		return
+	}
+	u.reported = a.installedVersion()
+	stale := versionNewer(u.target, u.reported)
+	if u.checks++; stale && u.checks < updateVersionChecks {
+		return
+	}
+	u.verifying = false
+	if a.mode == ModeActionOutput {
+		return
+	}
+	if stale {
+		a.setFlash(fmt.Sprintf("Gateway still reports %s after updating to %s", u.reported, u.target), true)
+	} else {
+		a.setFlash(fmt.Sprintf("Gateway updated to %s", cmp.Or(u.reported, u.target)), false)
+	}
+}
+
+// updateStatus reports, under a finished update in the output viewer, the
+// version the gateway reports since
+func (a *App) updateStatus() string {
+	u := &a.update
+	switch {
+	case u.running:
+		return ""
+	case u.verifying:
+		return styles.Muted.Render("Checking the gateway version...")
+	case u.reported == "":
+		return ""
+	case versionNewer(u.target, u.reported):
+		return styles.LogWarn.Render(fmt.Sprintf("The gateway still reports %s", u.reported))
+	}
+	return styles.StatusOK.Render(fmt.Sprintf("Gateway updated from %s to %s", u.from, u.reported))
+}
+
+// renderUpdateBanner renders the System tab "update available" banner and changelog
+func (a *App) renderUpdateBanner(width int) []string {
+	latest := a.availableUpdate()
+	if latest == "" {
+		return nil
+	}
+	u := &a.update
+
+	var lines []string
+	banner := styles.BadgeWarning.Render("UPDATE AVAILABLE") + "  " +
+		styles.Muted.Render(a.installedVersion()+" -> ") + styles.LabelValueHighlight.Render(latest)
+	switch {
+	case u.running:
+		banner += "  " + styles.Muted.Render("updating...")
+	case a.writesAllowed():
+		banner += "  " + styles.HintKey.Render("U") + styles.Muted.Render(":update")
+	case !a.config.Security.AllowWriteScopes:
+		banner += "  " + styles.Muted.Render("(enable write scopes to update from here)")
+	}
+	lines = append(lines, banner)
+	lines = append(lines, "")
+
+	lines = append(lines, styles.HelpSection.Render("Changelog"))
+	switch {
+	case u.loading:
+		lines = append(lines, styles.Muted.Render("  Loading..."))
+	case u.err != "":
+		lines = append(lines, "  "+styles.LogError.Render(truncate(u.err, width-4)))
+	case strings.TrimSpace(u.changelog) == "":
+		lines = append(lines, styles.Muted.Render("  No changelog available"))
+	default:
+		changelog := strings.Split(strings.ReplaceAll(u.changelog, "\t", "    "), "\n")
+		for i, line := range changelog {
+			if i == maxChangelogLines {
+				lines = append(lines, styles.Muted.Render(fmt.Sprintf("  ... %d more lines", len(changelog)-i)))
+				break
+			}
+			lines = append(lines, "  "+truncate(line, width-4))
+		}
+	}
+	return append(lines, "")
+}
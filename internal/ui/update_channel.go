@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// updateChannels are the release channels the gateway can update from
+var updateChannels = []string{"stable", "beta", "nightly"}
+
+// UpdateChannelMsg is sent when an update channel switch returns
+type UpdateChannelMsg struct {
+	Instance string
+	Channel  string
+	Error    error
+}
+
+func (m UpdateChannelMsg) failure() error { return m.Error }
+
+// updateChannelSwitch holds the update channel picker and the latest switch,
+// whose registry version difference the System tab shows
+type updateChannelSwitch struct {
+	cursor int // Index into updateChannels
+
+	// The latest switch
+	instance   string
+	from       string // Channel before the switch
+	fromLatest string // Registry's latest version on it
+	to         string
+	running    bool
+	verifying  bool // Waiting for a status to report the new channel
+	checks     int  // Statuses since the switch that reported the old one
+}
+
+// openUpdateChannels opens the update channel picker on the current channel
+func (a *App) openUpdateChannels() {
+	c := &a.updateChannel
+	if a.cliAdapter() == nil || a.openclawStatus == nil || c.running || c.verifying {
+		return
+	}
+	if !a.canWrite("Switching the update channel") {
+		return
+	}
+	c.cursor = max(slices.Index(updateChannels, a.openclawStatus.UpdateChannel), 0)
+	a.mode = ModeUpdateChannel
+}
+
+// switchUpdateChannel asks to confirm switching to the channel selected in
+// the picker
+func (a *App) switchUpdateChannel() tea.Cmd {
+	a.mode = ModeNormal
+	from, to := a.openclawStatus.UpdateChannel, updateChannels[a.updateChannel.cursor]
+	if to == from {
+		return nil
+	}
+	return a.confirmWrite(writeRequest{
+		title:   "Update Channel",
+		prompt:  fmt.Sprintf("Switch %s from %s to %s?", a.cliAdapter().GetInstanceName(), cmp.Or(from, "unknown"), to),
+		preview: func(c *gateway.CLIAdapter) error { return c.SetUpdateChannel(to) },
+		run:     func() tea.Cmd { return a.runUpdateChannelSwitch(to) },
+	})
+}
+
+// runUpdateChannelSwitch switches to the confirmed channel
+func (a *App) runUpdateChannelSwitch(to string) tea.Cmd {
+	c := &a.updateChannel
+	adapter := a.cliAdapter()
+	c.instance = adapter.GetInstanceName()
+	c.from, c.fromLatest, c.to = a.openclawStatus.UpdateChannel, a.latestVersion(), to
+	c.running, c.checks = true, 0
+	instance := c.instance
+	return func() tea.Msg {
+		return UpdateChannelMsg{Instance: instance, Channel: to, Error: adapter.SetUpdateChannel(to)}
+	}
+}
+
+// latestVersion returns the registry's latest version, if reported
+func (a *App) latestVersion() string {
+	if a.openclawStatus == nil || a.openclawStatus.Update == nil {
+		return ""
+	}
+	return a.openclawStatus.Update.Registry.LatestVersion
+}
+
+// handleUpdateChannel refreshes the status after a switch, which
+// checkUpdateChannel then reads the new channel's latest version from
+func (a *App) handleUpdateChannel(msg UpdateChannelMsg) tea.Cmd {
+	c := &a.updateChannel
+	if msg.Instance != c.instance {
+		return nil
+	}
+	c.running = false
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	c.verifying = true
+	return a.fetchCLIStatus()
+}
+
+// checkUpdateChannel waits, as checkUpdate does, for a status to report the
+// new channel, then flashes how its latest version differs from the old one's
+func (a *App) checkUpdateChannel() {
+	c := &a.updateChannel
+	if !c.verifying {
+		return
+	}
+	switched := a.openclawStatus.UpdateChannel == c.to
+	if c.checks++; !switched && c.checks < updateVersionChecks {
+		return
+	}
+	c.verifying = false
+	if !switched {
+		a.setFlash(fmt.Sprintf("Gateway still reports update channel %s after switching to %s", a.openclawStatus.UpdateChannel, c.to), true)
+		return
+	}
+	a.setFlash(fmt.Sprintf("Update channel %s -> %s: latest %s", c.from, c.to, a.latestVersionChange()), false)
+}
+
+// latestVersionChange describes the registry's latest version since the
+// switch, next to the one on the previous channel
+func (a *App) latestVersionChange() string {
+	c := &a.updateChannel
+	latest := a.latestVersion()
+	switch {
+	case latest == "":
+		return "unknown"
+	case c.fromLatest == "" || c.fromLatest == latest:
+		return latest
+	}
+	return c.fromLatest + " -> " + latest
+}
+
+// switchedChannel reports whether the status shows the channel the latest
+// switch moved to
+func (a *App) switchedChannel() bool {
+	c := &a.updateChannel
+	return c.to != "" && !c.running && !c.verifying && a.openclawStatus != nil &&
+		a.openclawStatus.UpdateChannel == c.to && a.cliAdapter() != nil && a.cliAdapter().GetInstanceName() == c.instance
+}
+
+// handleUpdateChannelKey handles keys while the update channel picker is open
+func (a *App) handleUpdateChannelKey(msg tea.KeyMsg) tea.Cmd {
+	c := &a.updateChannel
+	switch {
+	case key.Matches(msg, a.keys.Escape) || msg.String() == "q":
+		a.mode = ModeNormal
+	case key.Matches(msg, a.keys.Up):
+		c.cursor = max(c.cursor-1, 0)
+	case key.Matches(msg, a.keys.Down):
+		c.cursor = min(c.cursor+1, len(updateChannels)-1)
+	case key.Matches(msg, a.keys.Enter):
+		return a.switchUpdateChannel()
+	}
+	return nil
+}
+
+// renderUpdateChannelPicker renders the update channel picker
+func (a *App) renderUpdateChannelPicker() string {
+	c := &a.updateChannel
+	content := styles.HelpTitle.Render("Update Channel: "+a.cliAdapter().GetInstanceName()) + "\n\n"
+	for i, channel := range updateChannels {
+		item := styles.UnselectedItem.Render("  " + channel)
+		if i == c.cursor {
+			item = styles.SelectedItem.Render("> " + channel)
+		}
+		if channel == a.openclawStatus.UpdateChannel {
+			item += styles.Muted.Render("  (current)")
+		}
+		content += item + "\n"
+	}
+
+	content += "\n" + styles.HintKey.Render("enter") + styles.Muted.Render(":switch  ") +
+		styles.HintKey.Render("j/k") + styles.Muted.Render(":select  ") +
+		styles.HintKey.Render("esc") + styles.Muted.Render(":close")
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}
+
+// renderUpdateChannelHint renders what follows the channel in the System
+// tab: a running switch, or the key to start one
+func (a *App) renderUpdateChannelHint() string {
+	c := &a.updateChannel
+	switch {
+	case c.running || c.verifying:
+		return "  " + styles.Muted.Render("switching to "+c.to+"...")
+	case a.writesAllowed():
+		return "  " + styles.HintKey.Render("V") + styles.Muted.Render(":switch")
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/history"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// usagePeriods are the selectable Usage tab periods, in cycle order
+var usagePeriods = []struct {
+	Label string
+	Days  int // 0 = all time; 1 = since local midnight
+}{
+	{"today", 1},
+	{"7 days", 7},
+	{"30 days", 30},
+	{"all time", 0},
+}
+
+// UsageMsg is sent when usage records have been read from the history store
+type UsageMsg struct {
+	Records []history.UsageRecord
+	Error   error
+}
+
+// usageState holds the Usage tab state
+type usageState struct {
+	period  int // Index into usagePeriods
+	records []history.UsageRecord
+	err     string
+	scroll  int
+}
+
+// usageTotals accumulates tokens and estimated cost for one group
+type usageTotals struct {
+	Name     string
+	Input    int
+	Output   int
+	Cost     float64
+	Unpriced int // Tokens from models without configured pricing
+}
+
+// add adds a record's tokens; cost is the record's estimated cost, if priced
+func (t *usageTotals) add(rec history.UsageRecord, cost float64, priced bool) {
+	t.Input += rec.DeltaInput
+	t.Output += rec.DeltaOutput
+	if priced {
+		t.Cost += cost
+	} else {
+		t.Unpriced += rec.DeltaInput + rec.DeltaOutput
+	}
+}
+
+// allSessions returns every session in the status, deduplicated across the
+// global recent list and the per-agent lists
+func allSessions(sessions *models.Sessions) []models.Session {
+	if sessions == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var all []models.Session
+	add := func(list []models.Session) {
+		for _, sess := range list {
+			key := sess.SessionID
+			if key == "" {
+				key = sess.Key
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			all = append(all, sess)
+		}
+	}
+	add(sessions.Recent)
+	for _, group := range sessions.ByAgent {
+		add(group.Recent)
+	}
+	return all
+}
+
+// usageSince returns the start of the selected period
+func (a *App) usageSince() time.Time {
+	days := usagePeriods[a.usage.period].Days
+	if days == 0 {
+		return time.Time{}
+	}
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return midnight.AddDate(0, 0, -(days - 1))
+}
+
+func (a *App) fetchUsage() tea.Cmd {
+	since := a.usageSince()
+	adapter := a.cliAdapter()
+	return a.scoped(func() tea.Msg {
+		if adapter == nil {
+			return UsageMsg{Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		records, err := a.history.Usage(adapter.GetInstanceName(), since)
+		return UsageMsg{Records: records, Error: err}
+	})
+}
+
+// cycleUsagePeriod selects the next period and re-reads usage
+func (a *App) cycleUsagePeriod() tea.Cmd {
+	a.usage.period = (a.usage.period + 1) % len(usagePeriods)
+	return a.fetchUsage()
+}
+
+func (a *App) handleUsage(msg UsageMsg) {
+	if msg.Error != nil {
+		a.usage.err = msg.Error.Error()
+		return
+	}
+	a.usage.err = ""
+	a.usage.records = msg.Records
+}
+
+// modelCost returns the estimated cost of a record's tokens, if the model is priced
+func (a *App) modelCost(rec history.UsageRecord) (float64, bool) {
+	price, ok := a.config.Usage.Pricing[rec.Model]
+	if !ok {
+		return 0, false
+	}
+	return price.Cost(rec.DeltaInput, rec.DeltaOutput), true
+}
+
+func (a *App) renderUsageTab(width, height int) string {
+	var lines []string
+	u := &a.usage
+
+	period := styles.LabelValueHighlight.Render(usagePeriods[u.period].Label)
+	lines = append(lines, styles.HelpSection.Render("Usage & Costs")+"  "+
+		styles.Muted.Render("period=")+period+"  "+styles.HintKey.Render("p")+styles.Muted.Render(":cycle period"))
+	lines = append(lines, "")
+
+	if u.err != "" {
+		lines = append(lines, "  "+styles.LogError.Render(u.err))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+	if len(u.records) == 0 {
+		lines = append(lines, styles.Muted.Render("  No usage recorded for this period yet."))
+		lines = append(lines, styles.Muted.Render("  lazyclaw records session token usage while it runs."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	var total usageTotals
+	byAgent := make(map[string]*usageTotals)
+	byKind := make(map[string]*usageTotals)
+	byDay := make(map[string]*usageTotals)
+	group := func(m map[string]*usageTotals, name string) *usageTotals {
+		if name == "" {
+			name = "(unknown)"
+		}
+		if m[name] == nil {
+			m[name] = &usageTotals{Name: name}
+		}
+		return m[name]
+	}
+	for _, rec := range u.records {
+		cost, priced := a.modelCost(rec)
+		total.add(rec, cost, priced)
+		group(byAgent, rec.AgentID).add(rec, cost, priced)
+		group(byKind, rec.Kind).add(rec, cost, priced)
+		group(byDay, rec.Time.Local().Format("2006-01-02")).add(rec, cost, priced)
+	}
+
+	// Totals
+	lines = append(lines, fmt.Sprintf("  Input:     %s tokens", styles.LabelValueHighlight.Render(formatNumber(total.Input))))
+	lines = append(lines, fmt.Sprintf("  Output:    %s tokens", styles.LabelValueHighlight.Render(formatNumber(total.Output))))
+	lines = append(lines, fmt.Sprintf("  Est. Cost: %s", styles.LabelValueHighlight.Render(formatCost(total.Cost))))
+	if total.Unpriced > 0 {
+		lines = append(lines, "  "+styles.Muted.Render(fmt.Sprintf(
+			"%s tokens from models without pricing (set usage.pricing in config.yml)", formatNumber(total.Unpriced))))
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, renderUsageGroup("By Agent", "Agent", sortedUsage(byAgent, false), width)...)
+	lines = append(lines, renderUsageGroup("By Channel Kind", "Kind", sortedUsage(byKind, false), width)...)
+	lines = append(lines, renderUsageGroup("By Day", "Day", sortedUsage(byDay, true), width)...)
+
+	return scrollLines(lines, &u.scroll, height)
+}
+
+// sortedUsage returns groups by descending token count, or by name (newest
+// first) when byName is set
+func sortedUsage(m map[string]*usageTotals, byName bool) []*usageTotals {
+	groups := make([]*usageTotals, 0, len(m))
+	for _, t := range m {
+		groups = append(groups, t)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if byName {
+			return groups[i].Name > groups[j].Name
+		}
+		return groups[i].Input+groups[i].Output > groups[j].Input+groups[j].Output
+	})
+	return groups
+}
+
+// renderUsageGroup renders a usage table with a share-of-tokens bar per row
+func renderUsageGroup(title, column string, groups []*usageTotals, width int) []string {
+	lines := []string{styles.HelpSection.Render(title)}
+
+	maxTokens := 0
+	for _, g := range groups {
+		maxTokens = max(maxTokens, g.Input+g.Output)
+	}
+	nameWidth := 20
+	barWidth := max(width-nameWidth-40, 5)
+	lines = append(lines, styles.TableHeader.Render(fmt.Sprintf("  %-*s %10s %10s %10s", nameWidth, column, "Input", "Output", "Est.Cost")))
+	for _, g := range groups {
+		bar := ""
+		if maxTokens > 0 {
+			n := (g.Input + g.Output) * barWidth / maxTokens
+			bar = styles.Primary.Render(strings.Repeat("█", n))
+		}
+		cost := formatCost(g.Cost)
+		if g.Unpriced > 0 && g.Cost == 0 {
+			cost = "-"
+		}
+		lines = append(lines, fmt.Sprintf("  %s %10s %10s %10s %s", padRight(g.Name, nameWidth),
+			formatNumber(g.Input), formatNumber(g.Output), cost, bar))
+	}
+	return append(lines, "")
+}
+
+func formatCost(cost float64) string {
+	if cost > 0 && cost < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// WebhookTestMsg is sent when a webhook test delivery returns
+type WebhookTestMsg struct {
+	ID     string
+	Output string
+	Error  error
+}
+
+func (m WebhookTestMsg) failure() error { return m.Error }
+
+// webhookView holds the Hooks tab state
+type webhookView struct {
+	cursor  int
+	testing string // ID of the webhook being test-fired
+}
+
+// webhooks returns the configured webhooks and integrations from status
+func (a *App) webhooks() []models.WebhookInfo {
+	if a.openclawStatus == nil {
+		return nil
+	}
+	return a.openclawStatus.Webhooks
+}
+
+// failingWebhooks counts enabled webhooks whose last delivery failed
+func (a *App) failingWebhooks() int {
+	n := 0
+	for _, hook := range a.webhooks() {
+		if hook.Enabled && hook.LastDelivery != nil && !hook.LastDelivery.OK {
+			n++
+		}
+	}
+	return n
+}
+
+// moveWebhookCursor moves the Hooks tab selection by delta
+func (a *App) moveWebhookCursor(delta int) {
+	a.hooks.cursor = min(max(a.hooks.cursor+delta, 0), max(len(a.webhooks())-1, 0))
+}
+
+// testWebhook asks to confirm sending a test delivery to the selected
+// webhook
+func (a *App) testWebhook() tea.Cmd {
+	hooks := a.webhooks()
+	if a.cliAdapter() == nil || a.hooks.testing != "" || a.hooks.cursor >= len(hooks) {
+		return nil
+	}
+	if !a.canWrite("Test-firing webhooks") {
+		return nil
+	}
+
+	hook := hooks[a.hooks.cursor]
+	var details []string
+	if hook.URL != "" {
+		details = append(details, redactURL(hook.URL))
+	}
+	return a.confirmWrite(writeRequest{
+		title:   "Test Webhook",
+		prompt:  "Send a test delivery to " + hook.ID + " on " + a.cliAdapter().GetInstanceName() + "?",
+		details: details,
+		preview: func(c *gateway.CLIAdapter) error {
+			_, err := c.TestWebhook(hook.ID)
+			return err
+		},
+		run: func() tea.Cmd { return a.fireWebhook(hook.ID) },
+	})
+}
+
+// fireWebhook sends the confirmed test delivery
+func (a *App) fireWebhook(id string) tea.Cmd {
+	a.hooks.testing = id
+	return func() tea.Msg {
+		adapter := a.cliAdapter()
+		if adapter == nil {
+			return WebhookTestMsg{ID: id, Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		output, err := adapter.TestWebhook(id)
+		return WebhookTestMsg{ID: id, Output: output, Error: err}
+	}
+}
+
+func (a *App) handleWebhookTest(msg WebhookTestMsg) tea.Cmd {
+	a.hooks.testing = ""
+	if msg.Error != nil {
+		a.setFlash(msg.Error.Error(), true)
+		return nil
+	}
+	a.setFlash("Test delivery sent to "+msg.ID, false)
+	// Pick up the new last-delivery result
+	return a.fetchCLIStatus()
+}
+
+// redactURL hides credentials and query strings, which often carry tokens
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	u.User = nil
+	if u.RawQuery != "" {
+		u.RawQuery = "…"
+	}
+	return u.String()
+}
+
+func (a *App) renderWebhooksTab(width, height int) string {
+	var lines []string
+	hooks := a.webhooks()
+
+	header := styles.HelpSection.Render("Webhooks & Integrations")
+	if a.writesAllowed() {
+		header += "  " + styles.HintKey.Render("t") + styles.Muted.Render(":test-fire")
+	}
+	lines = append(lines, header)
+	lines = append(lines, "")
+
+	if a.openclawStatus == nil {
+		lines = append(lines, styles.Muted.Render("  No status loaded"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+	if len(hooks) == 0 {
+		lines = append(lines, styles.Muted.Render("  No webhooks or integrations reported by the gateway."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	nameWidth := 20
+	lines = append(lines, styles.TableHeader.Render(fmt.Sprintf("  %-*s %-10s %-8s %s", nameWidth, "Name", "Kind", "State", "Last Delivery")))
+	for i, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = hook.ID
+		}
+		state := styles.StatusOK.Render(fmt.Sprintf("%-8s", "enabled"))
+		if !hook.Enabled {
+			state = styles.Muted.Render(fmt.Sprintf("%-8s", "disabled"))
+		}
+		row := fmt.Sprintf("  %s %s ", padRight(name, nameWidth), padRight(hook.Kind, 10))
+		if i == a.hooks.cursor && a.focusedPane == PaneDetails {
+			row = styles.TableRowSelected.Render(row)
+		}
+		delivery := renderWebhookDelivery(hook.LastDelivery)
+		if hook.ID == a.hooks.testing {
+			delivery = styles.Muted.Render("testing...")
+		}
+		lines = append(lines, row+state+" "+delivery)
+	}
+	lines = append(lines, "")
+
+	// Details of the selected webhook
+	if a.hooks.cursor < len(hooks) {
+		hook := hooks[a.hooks.cursor]
+		lines = append(lines, styles.HelpSection.Render("Details"))
+		lines = append(lines, fmt.Sprintf("  ID:     %s", hook.ID))
+		if hook.URL != "" {
+			lines = append(lines, fmt.Sprintf("  URL:    %s", truncate(redactURL(hook.URL), width-12)))
+		}
+		if len(hook.Events) > 0 {
+			lines = append(lines, fmt.Sprintf("  Events: %s", truncate(strings.Join(hook.Events, ", "), width-12)))
+		}
+		if d := hook.LastDelivery; d != nil {
+			if d.StatusCode > 0 {
+				lines = append(lines, fmt.Sprintf("  Status: HTTP %d", d.StatusCode))
+			}
+			if d.DurationMs > 0 {
+				lines = append(lines, fmt.Sprintf("  Took:   %dms", d.DurationMs))
+			}
+			if d.Error != "" {
+				lines = append(lines, "  Error:  "+styles.LogError.Render(truncate(d.Error, width-12)))
+			}
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderWebhookDelivery summarizes a last-delivery result with its age
+func renderWebhookDelivery(d *models.WebhookDelivery) string {
+	if d == nil {
+		return styles.Muted.Render("never")
+	}
+	age := ""
+	if d.At > 0 {
+		age = " " + styles.Muted.Render(formatAge(time.Since(time.UnixMilli(d.At)).Milliseconds())+" ago")
+	}
+	if d.OK {
+		return styles.StatusOK.Render("● ok") + age
+	}
+	result := "failed"
+	if d.StatusCode > 0 {
+		result = fmt.Sprintf("HTTP %d", d.StatusCode)
+	}
+	return styles.StatusDown.Render("● "+result) + age
+}
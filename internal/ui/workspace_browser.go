@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// WorkspaceDirMsg is sent when a workspace directory listing completes
+type WorkspaceDirMsg struct {
+	Path    string
+	Entries []models.FileEntry
+	Error   error
+}
+
+// WorkspaceFileMsg is sent when a workspace file preview completes
+type WorkspaceFileMsg struct {
+	Path    string
+	Content string
+	Error   error
+}
+
+// workspaceBrowser holds the Agents tab workspace browser state
+type workspaceBrowser struct {
+	open    bool
+	agentID string
+	root    string // Agent WorkspaceDir; navigation never goes above it
+	cwd     string
+	entries []models.FileEntry
+	cursor  int
+	loading bool
+	err     string
+
+	// File preview (empty previewPath = listing view)
+	previewPath   string
+	preview       string
+	previewScroll int
+}
+
+// openWorkspaceBrowser opens the browser at the selected agent's workspace
+func (a *App) openWorkspaceBrowser() tea.Cmd {
+	if a.openclawStatus == nil || a.openclawStatus.Agents == nil {
+		return nil
+	}
+	agents := a.openclawStatus.Agents.Agents
+	if a.agentCursor < 0 || a.agentCursor >= len(agents) {
+		return nil
+	}
+	agent := agents[a.agentCursor]
+	if agent.WorkspaceDir == "" {
+		return nil
+	}
+
+	a.workspace = workspaceBrowser{
+		open:    true,
+		agentID: agent.ID,
+		root:    agent.WorkspaceDir,
+	}
+	return a.listWorkspaceDir(agent.WorkspaceDir)
+}
+
+func (a *App) listWorkspaceDir(dir string) tea.Cmd {
+	a.workspace.loading = true
+	a.workspace.err = ""
+	return func() tea.Msg {
+		adapter := a.cliAdapter()
+		if adapter == nil {
+			return WorkspaceDirMsg{Path: dir, Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		entries, err := adapter.ListDir(dir)
+		return WorkspaceDirMsg{Path: dir, Entries: entries, Error: err}
+	}
+}
+
+func (a *App) readWorkspaceFile(file string) tea.Cmd {
+	a.workspace.loading = true
+	a.workspace.err = ""
+	return func() tea.Msg {
+		adapter := a.cliAdapter()
+		if adapter == nil {
+			return WorkspaceFileMsg{Path: file, Error: fmt.Errorf("CLI adapter not initialized")}
+		}
+		content, err := adapter.ReadFile(file)
+		return WorkspaceFileMsg{Path: file, Content: content, Error: err}
+	}
+}
+
+func (a *App) handleWorkspaceDir(msg WorkspaceDirMsg) {
+	w := &a.workspace
+	w.loading = false
+	if msg.Error != nil {
+		w.err = msg.Error.Error()
+		return
+	}
+	w.cwd = msg.Path
+	w.entries = msg.Entries
+	w.cursor = 0
+}
+
+func (a *App) handleWorkspaceFile(msg WorkspaceFileMsg) {
+	w := &a.workspace
+	w.loading = false
+	if msg.Error != nil {
+		w.err = msg.Error.Error()
+		return
+	}
+	w.previewPath = msg.Path
+	w.preview = msg.Content
+	w.previewScroll = 0
+}
+
+// workspaceMove moves the selection (or scrolls the preview) by delta
+func (a *App) workspaceMove(delta int) {
+	w := &a.workspace
+	if w.previewPath != "" {
+		w.previewScroll += delta
+		if w.previewScroll < 0 {
+			w.previewScroll = 0
+		}
+		return
+	}
+	w.cursor += delta
+	if w.cursor >= len(w.entries) {
+		w.cursor = len(w.entries) - 1
+	}
+	if w.cursor < 0 {
+		w.cursor = 0
+	}
+}
+
+// workspaceEnter descends into the selected directory or previews the selected file
+func (a *App) workspaceEnter() tea.Cmd {
+	w := &a.workspace
+	if w.loading || w.previewPath != "" || w.cursor >= len(w.entries) {
+		return nil
+	}
+	entry := w.entries[w.cursor]
+	target := path.Join(w.cwd, entry.Name)
+	if entry.IsDir {
+		return a.listWorkspaceDir(target)
+	}
+	if entry.Size > gateway.MaxPreviewBytes {
+		w.err = fmt.Sprintf("%s is too large to preview (%s)", entry.Name, formatBytes(entry.Size))
+		return nil
+	}
+	return a.readWorkspaceFile(target)
+}
+
+// workspaceBack closes the preview, goes up a directory, or closes the browser
+func (a *App) workspaceBack() tea.Cmd {
+	w := &a.workspace
+	switch {
+	case w.previewPath != "":
+		w.previewPath = ""
+		w.preview = ""
+		return nil
+	case w.cwd != "" && w.cwd != w.root && strings.HasPrefix(w.cwd, w.root):
+		return a.listWorkspaceDir(path.Dir(w.cwd))
+	default:
+		a.workspace = workspaceBrowser{}
+		return nil
+	}
+}
+
+// renderWorkspaceBrowser renders the directory listing or a file preview
+func (a *App) renderWorkspaceBrowser(width, height int) string {
+	w := &a.workspace
+	var lines []string
+
+	rel := strings.TrimPrefix(strings.TrimPrefix(w.cwd, w.root), "/")
+	title := fmt.Sprintf("Workspace: %s", w.agentID)
+	if w.previewPath != "" {
+		rel = strings.TrimPrefix(strings.TrimPrefix(w.previewPath, w.root), "/")
+	}
+	lines = append(lines, styles.HelpSection.Render(title)+"  "+
+		styles.Muted.Render("enter: open  backspace: back  esc: close"))
+	lines = append(lines, "  "+styles.Muted.Render(truncatePath(path.Join(w.root, rel), width-4)))
+	lines = append(lines, "")
+
+	if w.err != "" {
+		lines = append(lines, "  "+styles.LogError.Render(w.err))
+		lines = append(lines, "")
+	}
+	if w.loading {
+		lines = append(lines, styles.Muted.Render("  Loading..."))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	maxVisible := height - len(lines) - 2
+	if maxVisible < 1 {
+		maxVisible = 1
+	}
+
+	// File preview
+	if w.previewPath != "" {
+		content := strings.Split(strings.ReplaceAll(w.preview, "\t", "    "), "\n")
+		if w.previewScroll > len(content)-1 {
+			w.previewScroll = len(content) - 1
+		}
+		end := w.previewScroll + maxVisible
+		if end > len(content) {
+			end = len(content)
+		}
+		for i, line := range content[w.previewScroll:end] {
+			num := styles.Muted.Render(fmt.Sprintf("%4d ", w.previewScroll+i+1))
+			lines = append(lines, num+truncate(line, width-6))
+		}
+		if len(w.preview) >= gateway.MaxPreviewBytes {
+			lines = append(lines, styles.Muted.Render("  (preview truncated)"))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	// Directory listing
+	if len(w.entries) == 0 {
+		lines = append(lines, styles.Muted.Render("  (empty directory)"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	start := 0
+	if w.cursor >= maxVisible {
+		start = w.cursor - maxVisible + 1
+	}
+	end := start + maxVisible
+	if end > len(w.entries) {
+		end = len(w.entries)
+	}
+	nameWidth := max(width-30, 10)
+	for i := start; i < end; i++ {
+		entry := w.entries[i]
+		name := entry.Name
+		size := formatBytes(entry.Size)
+		if entry.IsDir {
+			name += "/"
+			size = ""
+		}
+		modified := ""
+		if !entry.ModTime.IsZero() {
+			modified = entry.ModTime.Format("2006-01-02 15:04")
+		}
+		row := fmt.Sprintf("  %s %8s  %s", padRight(name, nameWidth), size, modified)
+		switch {
+		case i == w.cursor:
+			lines = append(lines, styles.TableRowSelected.Render(row))
+		case entry.IsDir:
+			lines = append(lines, styles.Primary.Render(row))
+		default:
+			lines = append(lines, row)
+		}
+	}
+	if end < len(w.entries) {
+		lines = append(lines, styles.Muted.Render(fmt.Sprintf("  ... %d more", len(w.entries)-end)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// formatBytes formats a byte count with binary units
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fG", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fM", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fK", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lazyclaw/lazyclaw/internal/gateway"
+	"github.com/lazyclaw/lazyclaw/internal/models"
+	"github.com/lazyclaw/lazyclaw/internal/ui/styles"
+)
+
+// writeRequest describes a write action to confirm before it runs
+type writeRequest struct {
+	title       string   // Dialog title, e.g. "Reset Session"
+	prompt      string   // What the action does, as a question
+	details     []string // Context shown under the prompt
+	warning     string   // What may be lost or disrupted
+	destructive bool     // Confirmed by typing the instance name back
+
+	// preview makes the action's adapter calls, against a dry run adapter
+	// that records the commands instead of running them
+	preview func(*gateway.CLIAdapter) error
+	// run starts the action once confirmed, returning the command the
+	// output viewer runs and whose result message it hands on
+	run func() tea.Cmd
+	// status, if set, reports what followed the action, such as the version
+	// an update brought, under its result in the output viewer
+	status func() string
+	// ownOutput has run's command shown by the action itself rather than in
+	// the output viewer, as pairing does with its QR code
+	ownOutput bool
+}
+
+// writeConfirm holds the confirmation every write action passes through,
+// showing the exact commands it runs and the instance they run on
+type writeConfirm struct {
+	req      writeRequest
+	instance string
+	target   string   // Where the commands run, e.g. "deploy@gw-1"
+	commands []string // As the dry run recorded them
+	typed    textinput.Model
+}
+
+func newWriteConfirm() writeConfirm {
+	in := textinput.New()
+	in.Prompt = ""
+	in.CharLimit = 128
+	return writeConfirm{typed: in}
+}
+
+// writesAllowed reports whether write actions are offered on the current
+// instance: allow_write_scopes is on and the instance is granted
+// operator.write, which a read_only one never is
+func (a *App) writesAllowed() bool {
+	return a.cliAdapter() != nil && a.writeRefusal("") == ""
+}
+
+// canWrite reports whether action may run on the current instance, flashing
+// why not otherwise
+func (a *App) canWrite(action string) bool {
+	if reason := a.writeRefusal(action); reason != "" {
+		a.setFlash(reason, true)
+		return false
+	}
+	return true
+}
+
+// writeRefusal returns why action may not run on the current instance, if
+// it may not
+func (a *App) writeRefusal(action string) string {
+	if adapter := a.cliAdapter(); adapter != nil && a.config.InstanceReadOnly(adapter.GetInstanceName()) {
+		return adapter.GetInstanceName() + " is read-only"
+	}
+	if !a.config.Security.AllowWriteScopes {
+		return action + " requires security.allow_write_scopes: true"
+	}
+	if adapter := a.cliAdapter(); adapter != nil && !a.config.InstanceWritable(adapter.GetInstanceName()) {
+		return fmt.Sprintf("%s requires %s in the scopes of %s", action, models.ScopeWrite, adapter.GetInstanceName())
+	}
+	return ""
+}
+
+// confirmWrite asks to confirm req, showing the commands a dry run of it
+// records. Destructive requests are confirmed by typing the instance name.
+func (a *App) confirmWrite(req writeRequest) tea.Cmd {
+	adapter := a.cliAdapter()
+	if adapter == nil {
+		return nil
+	}
+	w := &a.writeConfirm
+	w.req = req
+	w.instance, w.target = adapter.GetInstanceName(), adapter.Target()
+	w.commands = adapter.DryRun(req.preview)
+	a.mode = ModeWriteConfirm
+	if !req.destructive {
+		return nil
+	}
+	w.typed.Reset()
+	w.typed.Focus()
+	return textinput.Blink
+}
+
+// runWrite starts a confirmed write in the output viewer, unless
+// security.dry_run only has it shown
+func (a *App) runWrite(instance string, commands []string, req writeRequest) tea.Cmd {
+	if a.config.Security.DryRun {
+		a.setFlash("Dry run on "+instance+", not run: "+strings.Join(commands, "; "), false)
+		return nil
+	}
+	if req.ownOutput {
+		return req.run()
+	}
+	return a.runWithOutput(instance, commands, req)
+}
+
+// handleWriteConfirmKey handles keys while a write awaits confirmation
+func (a *App) handleWriteConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	w := &a.writeConfirm
+	if w.req.destructive {
+		switch {
+		case key.Matches(msg, a.keys.Escape):
+			a.closeWriteConfirm()
+			return nil
+		case key.Matches(msg, a.keys.Enter):
+			if strings.TrimSpace(w.typed.Value()) != w.instance {
+				a.setFlash("Type "+w.instance+" to confirm", true)
+				return nil
+			}
+			a.closeWriteConfirm()
+			return a.runWrite(w.instance, w.commands, w.req)
+		}
+		var cmd tea.Cmd
+		w.typed, cmd = w.typed.Update(msg)
+		return cmd
+	}
+	switch {
+	case msg.String() == "y" || key.Matches(msg, a.keys.Enter):
+		a.closeWriteConfirm()
+		return a.runWrite(w.instance, w.commands, w.req)
+	case msg.String() == "n" || key.Matches(msg, a.keys.Escape) || msg.String() == "q":
+		a.closeWriteConfirm()
+	}
+	return nil
+}
+
+func (a *App) closeWriteConfirm() {
+	a.mode = ModeNormal
+	a.writeConfirm.typed.Blur()
+}
+
+// renderWriteCommands renders the commands a write runs and where, wrapped
+// to width
+func (a *App) renderWriteCommands(instance, target string, commands []string, width int) string {
+	content := "Runs on " + instance
+	if target != instance {
+		content += " (" + target + ")"
+	}
+	content += ":\n\n"
+	if len(commands) == 0 {
+		content += "  " + styles.Muted.Render("(not known until it runs)") + "\n"
+	}
+	for _, command := range commands {
+		for _, line := range wrapText(command, width-2) {
+			content += "  " + styles.LabelValueHighlight.Render(line) + "\n"
+		}
+	}
+	if a.config.Security.DryRun {
+		content += "\n" + styles.Muted.Render("Dry run: confirming only shows the commands (security.dry_run)") + "\n"
+	}
+	return content
+}
+
+// renderWriteConfirm renders the write confirmation
+func (a *App) renderWriteConfirm() string {
+	w := &a.writeConfirm
+	width := max(a.width-16, 40)
+	content := styles.HelpTitle.Render(w.req.title) + "\n\n"
+	content += truncate(w.req.prompt, width) + "\n"
+	for _, detail := range w.req.details {
+		content += styles.Muted.Render(truncate(detail, width)) + "\n"
+	}
+	content += "\n" + a.renderWriteCommands(w.instance, w.target, w.commands, width)
+	if w.req.warning != "" {
+		content += "\n" + styles.LogWarn.Render(w.req.warning) + "\n"
+	}
+
+	content += "\n"
+	if w.req.destructive {
+		content += "Type " + styles.LabelValueHighlight.Render(w.instance) + " to confirm: " + w.typed.View() + "\n\n" +
+			styles.HintKey.Render("enter") + styles.Muted.Render(":confirm  ") +
+			styles.HintKey.Render("esc") + styles.Muted.Render(":cancel")
+	} else {
+		content += styles.HintKey.Render("y") + styles.Muted.Render(":confirm  ") +
+			styles.HintKey.Render("n") + styles.Muted.Render(":cancel")
+	}
+	overlay := styles.HelpOverlay.Render(content)
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, overlay)
+}